@@ -20,10 +20,14 @@ import (
 	"os"
 
 	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	iter8v1alpha3 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha3"
+	iter8config "github.com/iter8-tools/iter8-istio/pkg/config"
 	"github.com/iter8-tools/iter8-istio/pkg/controller"
+	"github.com/iter8-tools/iter8-istio/pkg/notifier"
 	"github.com/iter8-tools/iter8-istio/pkg/webhook"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
@@ -32,10 +36,13 @@ import (
 )
 
 func main() {
-	var metricsAddr string
-	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
-	flag.Parse()
-	logf.SetLogger(logf.ZapLogger(false))
+	controllerConfig, err := iter8config.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logf.SetLogger(logf.ZapLogger(false))
+		logf.Log.WithName("entrypoint").Error(err, "invalid controller config")
+		os.Exit(1)
+	}
+	logf.SetLogger(controllerConfig.Logger())
 	log := logf.Log.WithName("entrypoint")
 
 	// Get a config to talk to the apiserver
@@ -48,7 +55,10 @@ func main() {
 
 	// Create a new Cmd to provide shared dependencies and start components
 	log.Info("setting up manager")
-	options := manager.Options{MetricsBindAddress: metricsAddr}
+	options := controllerConfig.ManagerOptions()
+	log.Info("controller config", "leaderElection", options.LeaderElection,
+		"maxConcurrentReconciles", controllerConfig.MaxConcurrentReconciles,
+		"namespaces", controllerConfig.WatchedNamespaces())
 
 	mgr, err := manager.New(cfg, options)
 	if err != nil {
@@ -65,20 +75,53 @@ func main() {
 		os.Exit(1)
 	}
 
+	// v1alpha3 is the stored version of experiments, converted by the webhook
+	if err := iter8v1alpha3.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Error(err, "unable add APIs to scheme")
+		os.Exit(1)
+	}
+
 	if err := istiov1alpha3.AddToScheme(mgr.GetScheme()); err != nil {
 		log.Error(err, "unable add APIs to scheme")
 		os.Exit(1)
 	}
 
+	if controllerConfig.HealthProbeBindAddress != "" {
+		log.Info("setting up health probes")
+		if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+			log.Error(err, "unable to set up health check")
+			os.Exit(1)
+		}
+		if err := mgr.AddReadyzCheck("ping", healthz.Ping); err != nil {
+			log.Error(err, "unable to set up readiness check")
+			os.Exit(1)
+		}
+	}
+
 	// Setup all Controllers
 	log.Info("Setting up controller")
-	if err := controller.AddToManager(mgr); err != nil {
+	if err := controller.AddToManager(mgr, controllerConfig); err != nil {
 		log.Error(err, "unable to register controllers to the manager")
 		os.Exit(1)
 	}
 
+	if slackCallbackAddr := controllerConfig.SlackCallbackBindAddress; slackCallbackAddr != "" {
+		log.Info("setting up slack callback")
+		secret := os.Getenv(notifier.SlackSigningSecretEnv)
+		if secret == "" {
+			log.Error(nil, "missing signing secret of slack callback", "env", notifier.SlackSigningSecretEnv)
+			os.Exit(1)
+		}
+		server := notifier.NewSlackCallbackServer(slackCallbackAddr, secret, mgr.GetClient(),
+			mgr.GetEventRecorderFor("iter8-slack-callback"), logf.Log)
+		if err := mgr.Add(server); err != nil {
+			log.Error(err, "unable to register slack callback to the manager")
+			os.Exit(1)
+		}
+	}
+
 	log.Info("setting up webhooks")
-	if err := webhook.AddToManager(mgr); err != nil {
+	if err := webhook.AddToManager(mgr, controllerConfig); err != nil {
 		log.Error(err, "unable to register webhooks to the manager")
 		os.Exit(1)
 	}
@@ -0,0 +1,218 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// conversion-gen generates conversion functions between the match types re-typed in iter8 api
+// and their counterparts in networking.istio.io/v1alpha3.
+// Fields are paired by case-insensitive name; generation fails if either side has a field without counterpart.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+
+	networkingv1alpha3 "istio.io/api/networking/v1alpha3"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	iter8Pkg = "iter8v1alpha2"
+	istioPkg = "networkingv1alpha3"
+)
+
+// pairs of types to be converted
+var pairs = []struct {
+	iter8 interface{}
+	istio interface{}
+}{
+	{iter8v1alpha2.HTTPMatchRequest{}, networkingv1alpha3.HTTPMatchRequest{}},
+	{iter8v1alpha2.L4MatchAttributes{}, networkingv1alpha3.L4MatchAttributes{}},
+	{iter8v1alpha2.TLSMatchAttributes{}, networkingv1alpha3.TLSMatchAttributes{}},
+}
+
+var (
+	stringMatchType      = reflect.TypeOf(iter8v1alpha2.StringMatch{})
+	istioStringMatchType = reflect.TypeOf(networkingv1alpha3.StringMatch{})
+)
+
+func main() {
+	output := flag.String("o", "zz_generated.conversion.go", "output file")
+	pkg := flag.String("p", "istio", "package name of output file")
+	header := flag.String("h", "", "file containing header of output file")
+	flag.Parse()
+
+	buf := &bytes.Buffer{}
+	if *header != "" {
+		h, err := ioutil.ReadFile(*header)
+		if err != nil {
+			exit(err)
+		}
+		buf.Write(h)
+		buf.WriteString("\n")
+	}
+
+	fmt.Fprintf(buf, "// Code generated by conversion-gen. DO NOT EDIT.\n\npackage %s\n\n", *pkg)
+	fmt.Fprintf(buf, "import (\n%s %q\n\n%s %q\n)\n\n", istioPkg, "istio.io/api/networking/v1alpha3",
+		iter8Pkg, "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2")
+
+	for _, pair := range pairs {
+		if err := generate(buf, reflect.TypeOf(pair.iter8), reflect.TypeOf(pair.istio)); err != nil {
+			exit(err)
+		}
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		exit(err)
+	}
+	if err := ioutil.WriteFile(*output, out, 0644); err != nil {
+		exit(err)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// generate writes conversion functions in both directions for the pair of types
+func generate(buf *bytes.Buffer, iter8Type, istioType reflect.Type) error {
+	iter8Fields, err := exportedFields(iter8Type)
+	if err != nil {
+		return err
+	}
+	istioFields, err := exportedFields(istioType)
+	if err != nil {
+		return err
+	}
+	for key, f := range iter8Fields {
+		if _, ok := istioFields[key]; !ok {
+			return fmt.Errorf("%s.%s has no counterpart in %s", iter8Type.Name(), f.Name, istioType)
+		}
+	}
+
+	// follow field order of istio type
+	to, from := &bytes.Buffer{}, &bytes.Buffer{}
+	for i := 0; i < istioType.NumField(); i++ {
+		istioField := istioType.Field(i)
+		if skipField(istioField) {
+			continue
+		}
+		iter8Field, ok := iter8Fields[strings.ToLower(istioField.Name)]
+		if !ok {
+			return fmt.Errorf("%s.%s has no counterpart in %s", istioType, istioField.Name, iter8Type.Name())
+		}
+		if err := convertField(to, iter8Field, istioField, "toStringMatch"); err != nil {
+			return err
+		}
+		if err := convertField(from, istioField, iter8Field, "fromStringMatch"); err != nil {
+			return err
+		}
+	}
+
+	name := iter8Type.Name()
+	fmt.Fprintf(buf, "// convert%sToIstio converts %s into its istio counterpart\n", name, name)
+	fmt.Fprintf(buf, "func convert%sToIstio(in *%s.%s) *%s.%s {\n", name, iter8Pkg, name, istioPkg, istioType.Name())
+	fmt.Fprintf(buf, "if in == nil {\nreturn nil\n}\nout := &%s.%s{}\n%sreturn out\n}\n\n", istioPkg, istioType.Name(), to.String())
+	fmt.Fprintf(buf, "// convert%sFromIstio converts istio %s into its iter8 counterpart\n", name, istioType.Name())
+	fmt.Fprintf(buf, "func convert%sFromIstio(in *%s.%s) *%s.%s {\n", name, istioPkg, istioType.Name(), iter8Pkg, name)
+	fmt.Fprintf(buf, "if in == nil {\nreturn nil\n}\nout := &%s.%s{}\n%sreturn out\n}\n\n", iter8Pkg, name, from.String())
+	return nil
+}
+
+func skipField(f reflect.StructField) bool {
+	return f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_")
+}
+
+// exportedFields returns fields of the struct keyed by lower-cased name
+func exportedFields(t reflect.Type) (map[string]reflect.StructField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", t)
+	}
+	out := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if skipField(f) {
+			continue
+		}
+		out[strings.ToLower(f.Name)] = f
+	}
+	return out, nil
+}
+
+// convertField writes the statement assigning in.<src> to out.<dst>
+func convertField(buf *bytes.Buffer, src, dst reflect.StructField, stringMatchFunc string) error {
+	st, dt := src.Type, dst.Type
+	switch {
+	case isStringMatch(st) && isStringMatch(dt) && st.Kind() == reflect.Ptr && dt.Kind() == reflect.Ptr:
+		fmt.Fprintf(buf, "out.%s = %s(in.%s)\n", dst.Name, stringMatchFunc, src.Name)
+	case st.Kind() == reflect.Map && dt.Kind() == reflect.Map && isStringMatch(st.Elem()) && isStringMatch(dt.Elem()):
+		arg := "&val"
+		if st.Elem().Kind() == reflect.Ptr {
+			arg = "val"
+		}
+		deref := "*"
+		if dt.Elem().Kind() == reflect.Ptr {
+			deref = ""
+		}
+		fmt.Fprintf(buf, "if in.%s != nil {\nout.%s = make(%s, len(in.%s))\nfor key, val := range in.%s {\n",
+			src.Name, dst.Name, typeString(dt), src.Name, src.Name)
+		if deref == "" {
+			fmt.Fprintf(buf, "out.%s[key] = %s(%s)\n}\n}\n", dst.Name, stringMatchFunc, arg)
+		} else {
+			fmt.Fprintf(buf, "if m := %s(%s); m != nil {\nout.%s[key] = *m\n}\n}\n}\n", stringMatchFunc, arg, dst.Name)
+		}
+	case st != dt:
+		return fmt.Errorf("unsupported conversion of field %s from %s to %s", src.Name, st, dt)
+	case st.Kind() == reflect.Slice:
+		fmt.Fprintf(buf, "if in.%s != nil {\nout.%s = make(%s, len(in.%s))\ncopy(out.%s, in.%s)\n}\n",
+			src.Name, dst.Name, typeString(dt), src.Name, dst.Name, src.Name)
+	case st.Kind() == reflect.Map:
+		fmt.Fprintf(buf, "if in.%s != nil {\nout.%s = make(%s, len(in.%s))\nfor key, val := range in.%s {\nout.%s[key] = val\n}\n}\n",
+			src.Name, dst.Name, typeString(dt), src.Name, src.Name, dst.Name)
+	default:
+		fmt.Fprintf(buf, "out.%s = in.%s\n", dst.Name, src.Name)
+	}
+	return nil
+}
+
+func isStringMatch(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == stringMatchType || t == istioStringMatchType
+}
+
+// typeString returns the type as it is referred in generated code
+func typeString(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + typeString(t.Elem())
+	case reflect.Slice:
+		return "[]" + typeString(t.Elem())
+	case reflect.Map:
+		return "map[" + typeString(t.Key()) + "]" + typeString(t.Elem())
+	}
+	switch t {
+	case stringMatchType:
+		return iter8Pkg + ".StringMatch"
+	case istioStringMatchType:
+		return istioPkg + ".StringMatch"
+	}
+	return t.String()
+}
@@ -1,16 +1,23 @@
 #!/bin/bash
 #
 # A script to fix the bug in the controller-gen
+# and to add the conversion webhook, which controller-gen doesn't generate
 
 SCRIPTDIR=$( cd "$( dirname "${BASH_SOURCE[0]}" )" && pwd )
 VERSION=$1
 fn=${VERSION}/iter8.tools_experiments.yaml
 FILE_PATH=$SCRIPTDIR"/../install/helm/iter8-controller/templates/crds/${fn}"
 suffix=".original"
-line=$(grep 'lastTransitionTime' -n install/helm/iter8-controller/templates/crds/${fn} | sed 's/:.*//')
-line=$(( $line + 11 ))
 
-sed -i$suffix  "${line}s/object/string/" $FILE_PATH
+# the crd has a schema for each served version
+for line in $(grep 'lastTransitionTime' -n $FILE_PATH | sed 's/:.*//'); do
+  line=$(( $line + 11 ))
+  sed -i$suffix "${line}s/object/string/" $FILE_PATH
+  rm $FILE_PATH$suffix
+done
+
+# experiments are converted between versions by the controller
+sed -i$suffix '/^  scope: Namespaced$/r '$SCRIPTDIR'/crd_conversion.yaml' $FILE_PATH
 rm $FILE_PATH$suffix
 
 rm -f config/${fn}
@@ -17,7 +17,6 @@ package metrics
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
@@ -37,10 +36,13 @@ const (
 
 // Read will read metrics from configmap into experiment.
 // Configmap in the same namespace as the experiment will override the one in iter8 system namespace.
-func Read(context context.Context, c client.Client, instance *iter8v1alpha2.Experiment) error {
+func Read(context context.Context, c client.Client, namespace string, instance *iter8v1alpha2.Experiment) error {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
 	cmSystem := &corev1.ConfigMap{}
 
-	errSystem := c.Get(context, types.NamespacedName{Name: configMapName, Namespace: getConfigMapNamespace()}, cmSystem)
+	errSystem := c.Get(context, types.NamespacedName{Name: configMapName, Namespace: namespace}, cmSystem)
 
 	if errSystem != nil {
 		return fmt.Errorf("Fail to read metrics configmaps: %v", errSystem)
@@ -57,12 +59,5 @@ func Read(context context.Context, c client.Client, instance *iter8v1alpha2.Expe
 		return err
 	}
 
-	return nil
-}
-
-func getConfigMapNamespace() string {
-	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
-		return namespace
-	}
-	return defaultNamespace
+	return instance.Spec.Metrics.Validate()
 }
@@ -0,0 +1,72 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const testCounterMetrics = `
+- name: iter8_request_count
+  query_template: sum(increase(istio_requests_total{reporter='source'}[$interval])) by ($version_labels)
+- name: iter8_error_count
+  query_template: sum(increase(istio_requests_total{response_code=~'5..',reporter='source'}[$interval])) by ($version_labels)
+  provider: prometheus
+`
+
+func newTestConfigMap(counterMetrics, ratioMetrics string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: configMapName, Namespace: defaultNamespace},
+		Data: map[string]string{
+			counterMetricsName: counterMetrics,
+			ratioMetricsName:   ratioMetrics,
+		},
+	}
+}
+
+func TestReadProviders(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	instance := &iter8v1alpha2.Experiment{}
+
+	c := fake.NewFakeClientWithScheme(scheme, newTestConfigMap(testCounterMetrics, `
+- name: iter8_error_rate
+  numerator: iter8_error_count
+  denominator: iter8_request_count
+`))
+	g.Expect(Read(context.Background(), c, "", instance)).To(gomega.Succeed())
+	g.Expect(instance.Spec.Metrics.CounterMetrics[0].GetProvider()).To(gomega.Equal(iter8v1alpha2.MetricProviderPrometheus))
+	g.Expect(instance.Spec.Metrics.CounterMetrics[1].GetProvider()).To(gomega.Equal(iter8v1alpha2.MetricProviderPrometheus))
+
+	// analytics only queries prometheus
+	c = fake.NewFakeClientWithScheme(scheme, newTestConfigMap(testCounterMetrics+`
+- name: dd_request_count
+  query_template: sum:trace.http.request.hits{*} by {$version_labels}.as_count()
+  provider: datadog
+`, ""))
+	g.Expect(Read(context.Background(), c, "", instance)).NotTo(gomega.Succeed())
+}
@@ -0,0 +1,94 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analytics
+
+import (
+	"strings"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+// labelKeys are the keys of labels identifying the name and namespace of a version in metrics
+type labelKeys struct {
+	name      string
+	namespace string
+}
+
+// providerLabelKeys are the label keys of each provider of metrics,
+// for versions being workloads and services respectively
+var providerLabelKeys = map[iter8v1alpha2.MetricProviderType][2]labelKeys{
+	// istio telemetry
+	iter8v1alpha2.MetricProviderPrometheus: {
+		{name: "destination_workload", namespace: "destination_workload_namespace"},
+		{name: "destination_service_name", namespace: "destination_service_namespace"},
+	},
+}
+
+const (
+	nameVariable      = "$name"
+	namespaceVariable = "$namespace"
+)
+
+// ProviderVersionLabels returns the labels identifying the version of the experiment in metrics of the provider
+// Labels of prometheus are used for unknown providers
+func ProviderVersionLabels(instance *iter8v1alpha2.Experiment, provider iter8v1alpha2.MetricProviderType, version string) map[string]string {
+	keys, ok := providerLabelKeys[provider]
+	if !ok {
+		keys = providerLabelKeys[iter8v1alpha2.MetricProviderPrometheus]
+	}
+	key := keys[0]
+	if instance.Spec.Service.Kind == "Service" {
+		key = keys[1]
+	}
+
+	return renderVersionLabels(instance, map[string]string{
+		key.namespace: namespaceVariable,
+		key.name:      nameVariable,
+	}, version)
+}
+
+// VersionLabels returns the labels of prometheus metrics identifying the version of the experiment,
+// given by the template of versionLabels of the experiment if specified
+func VersionLabels(instance *iter8v1alpha2.Experiment, version string) map[string]string {
+	if vl := instance.Spec.VersionLabels; vl != nil && len(vl.Template) > 0 {
+		return renderVersionLabels(instance, vl.Template, version)
+	}
+	return ProviderVersionLabels(instance, iter8v1alpha2.MetricProviderPrometheus, version)
+}
+
+// renderVersionLabels replaces the variables in values of the template,
+// then applies the values of the version given by versionLabels of the experiment
+func renderVersionLabels(instance *iter8v1alpha2.Experiment, template map[string]string, version string) map[string]string {
+	replacer := strings.NewReplacer(namespaceVariable, instance.ServiceNamespace(), nameVariable, version)
+	labels := make(map[string]string, len(template))
+	for k, v := range template {
+		labels[k] = replacer.Replace(v)
+	}
+
+	if vl := instance.Spec.VersionLabels; vl != nil {
+		for _, values := range vl.Versions {
+			if values.Name != version {
+				continue
+			}
+			for k, v := range values.Labels {
+				if _, ok := labels[k]; ok {
+					labels[k] = v
+				}
+			}
+		}
+	}
+	return labels
+}
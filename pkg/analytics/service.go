@@ -29,12 +29,6 @@ import (
 )
 
 const (
-	destinationWorkloadKey          = "destination_workload"
-	destinationWorkloadNamespaceKey = "destination_workload_namespace"
-
-	destinationServiceNameKey      = "destination_service_name"
-	destinationServiceNamespaceKey = "destination_service_namespace"
-
 	baselineID        = "baseline"
 	candidateIDPrefix = "candidate-"
 )
@@ -49,25 +43,20 @@ func GetBaselineID() string {
 	return baselineID
 }
 
-// MakeRequest generates request payload to analytics
-func MakeRequest(instance *iter8v1alpha2.Experiment) (*v1alpha2.Request, error) {
-	destinationKey := destinationWorkloadKey
-	destinationNamespaceKey := destinationWorkloadNamespaceKey
-
-	if instance.Spec.Service.Kind == "Service" {
-		destinationKey = destinationServiceNameKey
-		destinationNamespaceKey = destinationServiceNamespaceKey
+// makeVersion returns the version of the experiment in the request, labeled as in prometheus metrics
+func makeVersion(instance *iter8v1alpha2.Experiment, id, version string) v1alpha2.Version {
+	return v1alpha2.Version{
+		ID:            id,
+		VersionLabels: VersionLabels(instance, version),
 	}
+}
 
-	serviceNamespace := instance.ServiceNamespace()
+// MakeRequest generates request payload to analytics
+func MakeRequest(instance *iter8v1alpha2.Experiment) (*v1alpha2.Request, error) {
 	// identify and define list of candidates
 	candidates := make([]v1alpha2.Version, len(instance.Spec.Service.Candidates))
 	for i, candidate := range instance.Spec.Candidates {
-		candidates[i].ID = GetCandidateID(i)
-		candidates[i].VersionLabels = map[string]string{
-			destinationNamespaceKey: serviceNamespace,
-			destinationKey:          candidate,
-		}
+		candidates[i] = makeVersion(instance, GetCandidateID(i), candidate)
 	}
 
 	// identify and define list of criteria
@@ -114,13 +103,7 @@ func MakeRequest(instance *iter8v1alpha2.Experiment) (*v1alpha2.Request, error)
 		Name:        instance.Name,
 		StartTime:   instance.Status.StartTimestamp.Format(time.RFC3339),
 		ServiceName: instance.Spec.Service.Name,
-		Baseline: v1alpha2.Version{
-			ID: GetBaselineID(),
-			VersionLabels: map[string]string{
-				destinationNamespaceKey: serviceNamespace,
-				destinationKey:          instance.Spec.Service.Baseline,
-			},
-		},
+		Baseline:    makeVersion(instance, GetBaselineID(), instance.Spec.Service.Baseline),
 		MetricSpecs: v1alpha2.Metrics{
 			CounterMetrics: counterMetrics,
 			RatioMetrics:   ratioMetrics,
@@ -0,0 +1,102 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analytics
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+func TestMakeRequestVersionLabels(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews", Namespace: "bookinfo"},
+				Baseline:        "reviews-v1",
+				Candidates:      []string{"reviews-v2"},
+			},
+			Metrics: &iter8v1alpha2.Metrics{
+				CounterMetrics: []iter8v1alpha2.CounterMetric{
+					{Name: "iter8_request_count", QueryTemplate: "requests"},
+				},
+			},
+		},
+	}
+	instance.InitStatus()
+	now := metav1.Now()
+	instance.Status.StartTimestamp = &now
+
+	request, err := MakeRequest(instance)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(request.Baseline.VersionLabels).To(gomega.Equal(map[string]string{
+		"destination_workload":           "reviews-v1",
+		"destination_workload_namespace": "bookinfo",
+	}))
+
+	// versions of services are identified by the service name
+	instance.Spec.Service.Kind = "Service"
+	request, err = MakeRequest(instance)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(request.Candidate[0].VersionLabels).To(gomega.Equal(map[string]string{
+		"destination_service_name":      "reviews-v2",
+		"destination_service_namespace": "bookinfo",
+	}))
+}
+
+func TestVersionLabels(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews"},
+				Baseline:        "reviews-v1",
+				Candidates:      []string{"reviews-v2"},
+			},
+			VersionLabels: &iter8v1alpha2.VersionLabels{
+				Template: map[string]string{"destination_app": "reviews", "destination_version": "$name", "ns": "$namespace"},
+				Versions: []iter8v1alpha2.VersionLabelValues{
+					{Name: "reviews-v2", Labels: map[string]string{"destination_version": "v2", "other": "ignored"}},
+				},
+			},
+		},
+	}
+	g.Expect(instance.Spec.Validate()).To(gomega.Succeed())
+
+	g.Expect(VersionLabels(instance, "reviews-v1")).To(gomega.Equal(map[string]string{
+		"destination_app": "reviews", "destination_version": "reviews-v1", "ns": "default",
+	}))
+	g.Expect(VersionLabels(instance, "reviews-v2")).To(gomega.Equal(map[string]string{
+		"destination_app": "reviews", "destination_version": "v2", "ns": "default",
+	}))
+
+	// the default labels are overridden without template
+	instance.Spec.VersionLabels.Template = nil
+	instance.Spec.VersionLabels.Versions[0].Labels = map[string]string{"destination_workload": "reviews-v2-canary"}
+	g.Expect(VersionLabels(instance, "reviews-v2")).To(gomega.Equal(map[string]string{
+		"destination_workload": "reviews-v2-canary", "destination_workload_namespace": "default",
+	}))
+
+	instance.Spec.VersionLabels.Versions[0].Name = "reviews-v3"
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+}
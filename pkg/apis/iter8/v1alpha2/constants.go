@@ -26,6 +26,20 @@ const (
 	OnTerminationKeepLast OnTerminationType = "keep_last"
 )
 
+// ProtocolType provides options for protocol of routes used in experiment
+type ProtocolType string
+
+const (
+	// ProtocolHTTP indicates traffic is shifted by http routes, which also serve gRPC
+	ProtocolHTTP ProtocolType = "http"
+
+	// ProtocolTCP indicates traffic is shifted by tcp routes
+	ProtocolTCP ProtocolType = "tcp"
+
+	// ProtocolTLS indicates traffic is shifted by tls routes
+	ProtocolTLS ProtocolType = "tls"
+)
+
 // StrategyType provides options for strategy used in experiment
 type StrategyType string
 
@@ -40,6 +54,31 @@ const (
 	StrategyUniform StrategyType = "uniform"
 )
 
+// OnConflictType provides options for handling an experiment whose router or targets are held by another experiment
+type OnConflictType string
+
+const (
+	// OnConflictFail indicates the experiment fails if its router or targets are held by another experiment
+	OnConflictFail OnConflictType = "fail"
+
+	// OnConflictQueue indicates the experiment waits until its router and targets are released by other experiments
+	OnConflictQueue OnConflictType = "queue"
+)
+
+// OnTargetChangeType provides options for reacting to changes of targets during the experiment
+type OnTargetChangeType string
+
+const (
+	// OnTargetChangeRestart restarts the assessment of the experiment with traffic sent back to baseline
+	OnTargetChangeRestart OnTargetChangeType = "restart"
+
+	// OnTargetChangePause pauses the experiment
+	OnTargetChangePause OnTargetChangeType = "pause"
+
+	// OnTargetChangeAbort terminates the experiment with traffic sent back to baseline
+	OnTargetChangeAbort OnTargetChangeType = "abort"
+)
+
 // ActionType provides options for override actions
 type ActionType string
 
@@ -52,6 +91,90 @@ const (
 
 	// ActionTerminate is an action to terminate the experiment
 	ActionTerminate ActionType = "terminate"
+
+	// ActionApprove is an action to approve the approval gate the experiment is waiting at
+	ActionApprove ActionType = "approve"
+)
+
+const (
+	// AnnotationOverrideBy records who triggered the latest manual override of the experiment
+	AnnotationOverrideBy = "iter8-tools/override-by"
+
+	// AnnotationApprove lists names of approved approval gates, separated by comma
+	AnnotationApprove = "iter8-tools/approve"
+)
+
+// ApprovalState is the state of an approval gate reached by the experiment
+type ApprovalState string
+
+const (
+	// ApprovalPending indicates the experiment is waiting for approval at the gate
+	ApprovalPending ApprovalState = "Pending"
+
+	// ApprovalApproved indicates the gate is approved
+	ApprovalApproved ApprovalState = "Approved"
+
+	// ApprovalRejected indicates the gate is not approved before timeout
+	ApprovalRejected ApprovalState = "Rejected"
+)
+
+// HookPhase is the lifecycle point of the experiment when a hook runs
+type HookPhase string
+
+const (
+	// HookPreTraffic runs before any traffic is sent to candidates
+	HookPreTraffic HookPhase = "preTraffic"
+
+	// HookPostTrafficUpdate runs after each update of traffic split
+	HookPostTrafficUpdate HookPhase = "postTrafficUpdate"
+
+	// HookPreCompletion runs after all iterations, before the experiment completes
+	HookPreCompletion HookPhase = "preCompletion"
+)
+
+// HookFailurePolicy determines how the experiment reacts to a failure of a hook
+type HookFailurePolicy string
+
+const (
+	// HookFailureRollback terminates the experiment sending all traffic to baseline
+	HookFailureRollback HookFailurePolicy = "rollback"
+
+	// HookFailurePause pauses the experiment
+	HookFailurePause HookFailurePolicy = "pause"
+)
+
+// HealthCheckFailurePolicy determines how the experiment reacts to unhealthy candidates at a step of the schedule
+type HealthCheckFailurePolicy string
+
+const (
+	// HealthCheckFailureRollback terminates the experiment sending all traffic to baseline
+	HealthCheckFailureRollback HealthCheckFailurePolicy = "rollback"
+
+	// HealthCheckFailurePause pauses the experiment
+	HealthCheckFailurePause HealthCheckFailurePolicy = "pause"
+)
+
+// MetricProviderType is the backend a metric is queried from by analytics
+// Analytics only queries prometheus
+type MetricProviderType string
+
+const (
+	// MetricProviderPrometheus queries PromQL templates against prometheus
+	MetricProviderPrometheus MetricProviderType = "prometheus"
+)
+
+// HookState is the state of a run of a hook
+type HookState string
+
+const (
+	// HookRunning indicates the hook is running
+	HookRunning HookState = "Running"
+
+	// HookSucceeded indicates the hook succeeded
+	HookSucceeded HookState = "Succeeded"
+
+	// HookFailed indicates the hook failed or timed out
+	HookFailed HookState = "Failed"
 )
 
 // ExperimentConditionType limits conditions can be set by controller
@@ -72,6 +195,16 @@ const (
 
 	// ExperimentConditionRoutingRulesReady has status True when routing rules are ready
 	ExperimentConditionRoutingRulesReady ExperimentConditionType = "RoutingRulesReady"
+
+	// ExperimentConditionTargetsReady has status True when all candidates are available to receive traffic
+	ExperimentConditionTargetsReady ExperimentConditionType = "TargetsReady"
+
+	// ExperimentConditionTargetsUnchanged has status False when targets are changed during the experiment
+	ExperimentConditionTargetsUnchanged ExperimentConditionType = "TargetsUnchanged"
+
+	// ExperimentConditionReady summarizes the other conditions; it has status True when targets are found
+	// and routing rules are ready, and none of the other conditions is False
+	ExperimentConditionReady ExperimentConditionType = "Ready"
 )
 
 // PhaseType has options for phases that an experiment can be at
@@ -86,6 +219,12 @@ const (
 
 	// PhaseCompleted indicates experiment has competed (successfully or not)
 	PhaseCompleted PhaseType = "Completed"
+
+	// PhaseQueued indicates experiment is waiting for its router or targets to be released by other experiments
+	PhaseQueued PhaseType = "Queued"
+
+	// PhaseAwaitingApproval indicates experiment is waiting for manual approval at an approval gate
+	PhaseAwaitingApproval PhaseType = "AwaitingApproval"
 )
 
 // A set of reason setting the experiment condition status
@@ -104,4 +243,25 @@ const (
 	ReasonRoutingRulesReady       = "RoutingRulesReady"
 	ReasonActionPause             = "ActionPause"
 	ReasonActionResume            = "ActionResume"
+	ReasonExperimentQueued        = "ExperimentQueued"
+	ReasonExperimentDequeued      = "ExperimentDequeued"
+	ReasonAwaitingApproval        = "AwaitingApproval"
+	ReasonApprovalGranted         = "ApprovalGranted"
+	ReasonApprovalRejected        = "ApprovalRejected"
+	ReasonHookSucceeded           = "HookSucceeded"
+	ReasonHookFailed              = "HookFailed"
+	ReasonTargetsReady            = "TargetsReady"
+	ReasonTargetsNotReady         = "TargetsNotReady"
+	ReasonCandidateCrashLoop      = "CandidateCrashLoop"
+	ReasonTargetsChanged          = "TargetsChanged"
+	ReasonExperimentReady         = "ExperimentReady"
+	ReasonExperimentNotReady      = "ExperimentNotReady"
+	ReasonLoadStarted             = "LoadStarted"
+	ReasonLoadError               = "LoadError"
+	ReasonStepUpdate              = "StepUpdate"
+	ReasonHealthCheckFailed       = "HealthCheckFailed"
+	ReasonGuardrailBreached       = "GuardrailBreached"
+	ReasonGuardrailError          = "GuardrailError"
+	ReasonReportWritten           = "ReportWritten"
+	ReasonReportError             = "ReportError"
 )
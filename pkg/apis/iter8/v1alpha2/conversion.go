@@ -0,0 +1,248 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+// This file contains conversion functions between v1alpha2 and the hub version v1alpha3
+// for fields conversion-gen can't convert; the rest is generated in zz_generated.conversion.go.
+
+//go:generate go run k8s.io/code-generator/cmd/conversion-gen --input-dirs github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2 -O zz_generated.conversion --go-header-file ../../../../hack/boilerplate.go.txt --output-base $GOPATH/src
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	analyticsv1alpha2 "github.com/iter8-tools/iter8-istio/pkg/analytics/api/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha3"
+)
+
+// AnnotationServiceRef keeps fields of the service reference which don't exist in v1alpha3,
+// so that they are restored when the experiment is converted back to v1alpha2
+const AnnotationServiceRef = "iter8.tools/v1alpha2-service-ref"
+
+// ConvertTo converts the experiment to the hub version v1alpha3
+func (src *Experiment) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha3.Experiment)
+	if err := Convert_v1alpha2_Experiment_To_v1alpha3_Experiment(src, dst, nil); err != nil {
+		return err
+	}
+
+	ref := src.Spec.Service.ObjectReference
+	if ref == nil || (ref.UID == "" && ref.ResourceVersion == "" && ref.FieldPath == "") {
+		return nil
+	}
+	kept, err := json.Marshal(&corev1.ObjectReference{UID: ref.UID, ResourceVersion: ref.ResourceVersion, FieldPath: ref.FieldPath})
+	if err != nil {
+		return err
+	}
+	dst.Annotations = copyAnnotations(src.Annotations)
+	dst.Annotations[AnnotationServiceRef] = string(kept)
+	return nil
+}
+
+// ConvertFrom converts the experiment from the hub version v1alpha3
+func (dst *Experiment) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha3.Experiment)
+	if err := Convert_v1alpha3_Experiment_To_v1alpha2_Experiment(src, dst, nil); err != nil {
+		return err
+	}
+
+	kept, ok := src.Annotations[AnnotationServiceRef]
+	if !ok {
+		return nil
+	}
+	ref := &corev1.ObjectReference{}
+	if err := json.Unmarshal([]byte(kept), ref); err != nil {
+		return fmt.Errorf("Invalid annotation %s: %v", AnnotationServiceRef, err)
+	}
+	dst.Spec.Service.ObjectReference.UID = ref.UID
+	dst.Spec.Service.ObjectReference.ResourceVersion = ref.ResourceVersion
+	dst.Spec.Service.ObjectReference.FieldPath = ref.FieldPath
+
+	dst.Annotations = copyAnnotations(src.Annotations)
+	delete(dst.Annotations, AnnotationServiceRef)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	return nil
+}
+
+// copyAnnotations returns a copy of annotations, which are otherwise shared by both versions of the experiment
+func copyAnnotations(annotations map[string]string) map[string]string {
+	out := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		out[k] = v
+	}
+	return out
+}
+
+// Convert_v1alpha2_Service_To_v1alpha3_Service converts the inline object reference into fields of the service
+func Convert_v1alpha2_Service_To_v1alpha3_Service(in *Service, out *v1alpha3.Service, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha2_Service_To_v1alpha3_Service(in, out, s); err != nil {
+		return err
+	}
+	if ref := in.ObjectReference; ref != nil {
+		out.Kind = ref.Kind
+		out.APIVersion = ref.APIVersion
+		out.Name = ref.Name
+		out.Namespace = ref.Namespace
+	}
+	return nil
+}
+
+// Convert_v1alpha3_Service_To_v1alpha2_Service converts fields of the service into the inline object reference
+func Convert_v1alpha3_Service_To_v1alpha2_Service(in *v1alpha3.Service, out *Service, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha3_Service_To_v1alpha2_Service(in, out, s); err != nil {
+		return err
+	}
+	out.ObjectReference = &corev1.ObjectReference{
+		Kind:       in.Kind,
+		APIVersion: in.APIVersion,
+		Name:       in.Name,
+		Namespace:  in.Namespace,
+	}
+	return nil
+}
+
+// Convert_v1alpha2_Threshold_To_v1alpha3_Threshold converts the float value of threshold into a quantity
+// Quantities are precise to 1e-9, smaller fractions are rounded up
+func Convert_v1alpha2_Threshold_To_v1alpha3_Threshold(in *Threshold, out *v1alpha3.Threshold, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha2_Threshold_To_v1alpha3_Threshold(in, out, s); err != nil {
+		return err
+	}
+	value, err := resource.ParseQuantity(strconv.FormatFloat(float64(in.Value), 'g', -1, 32))
+	if err != nil {
+		return fmt.Errorf("Invalid threshold value %v: %v", in.Value, err)
+	}
+	out.Value = value
+	return nil
+}
+
+// Convert_v1alpha3_Threshold_To_v1alpha2_Threshold converts the quantity of threshold into a float value
+func Convert_v1alpha3_Threshold_To_v1alpha2_Threshold(in *v1alpha3.Threshold, out *Threshold, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha3_Threshold_To_v1alpha2_Threshold(in, out, s); err != nil {
+		return err
+	}
+	// AsDec changes the internal representation of quantity, which is shared with the input
+	quantity := in.Value.DeepCopy()
+	value, err := strconv.ParseFloat(quantity.AsDec().String(), 32)
+	if err != nil {
+		return fmt.Errorf("Invalid threshold value %s: %v", in.Value.String(), err)
+	}
+	out.Value = float32(value)
+	return nil
+}
+
+// Convert_v1alpha2_WinnerAssessment_To_v1alpha3_WinnerAssessment flattens the assessment from analytics
+func Convert_v1alpha2_WinnerAssessment_To_v1alpha3_WinnerAssessment(in *WinnerAssessment, out *v1alpha3.WinnerAssessment, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha2_WinnerAssessment_To_v1alpha3_WinnerAssessment(in, out, s); err != nil {
+		return err
+	}
+	if in.WinnerAssessment != nil {
+		out.WinnerFound = in.WinnerAssessment.WinnerFound
+		out.CurrentBestVersion = in.WinnerAssessment.Winner
+		out.Probability = in.WinnerAssessment.Probability
+	}
+	return nil
+}
+
+// Convert_v1alpha3_WinnerAssessment_To_v1alpha2_WinnerAssessment restores the assessment from analytics
+func Convert_v1alpha3_WinnerAssessment_To_v1alpha2_WinnerAssessment(in *v1alpha3.WinnerAssessment, out *WinnerAssessment, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha3_WinnerAssessment_To_v1alpha2_WinnerAssessment(in, out, s); err != nil {
+		return err
+	}
+	out.WinnerAssessment = &analyticsv1alpha2.WinnerAssessment{
+		WinnerFound: in.WinnerFound,
+		Winner:      in.CurrentBestVersion,
+		Probability: in.Probability,
+	}
+	return nil
+}
+
+// Convert_v1alpha2_VersionAssessment_To_v1alpha3_VersionAssessment flattens the assessment from analytics
+func Convert_v1alpha2_VersionAssessment_To_v1alpha3_VersionAssessment(in *VersionAssessment, out *v1alpha3.VersionAssessment, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha2_VersionAssessment_To_v1alpha3_VersionAssessment(in, out, s); err != nil {
+		return err
+	}
+	out.ID = in.ID
+	out.WinProbability = in.WinProbability
+	out.RequestCount = in.RequestCount
+	out.CriterionAssessments = nil
+	if in.CriterionAssessments != nil {
+		out.CriterionAssessments = make([]v1alpha3.CriterionAssessment, len(in.CriterionAssessments))
+		for i, ca := range in.CriterionAssessments {
+			out.CriterionAssessments[i] = v1alpha3.CriterionAssessment{ID: ca.ID, MetricID: ca.MetricID}
+			if ca.Statistics != nil {
+				out.CriterionAssessments[i].Statistics = &v1alpha3.Statistics{Value: ca.Statistics.Value}
+				if rs := ca.Statistics.RatioStatistics; rs != nil {
+					out.CriterionAssessments[i].Statistics.RatioStatistics = &v1alpha3.RatioStatistics{
+						ImprovementOverBaseline:       v1alpha3.Interval(rs.ImprovementOverBaseline),
+						ProbabilityOfBeatingBaseline:  rs.ProbabilityOfBeatingBaseline,
+						ProbabilityOfBeingBestVersion: rs.ProbabilityOfBeingBestVersion,
+						CredibleInterval:              v1alpha3.Interval(rs.CredibleInterval),
+					}
+				}
+			}
+			if ta := ca.ThresholdAssessment; ta != nil {
+				out.CriterionAssessments[i].ThresholdAssessment = &v1alpha3.ThresholdAssessment{
+					ThresholdBreached:                ta.ThresholdBreached,
+					ProbabilityOfSatisfyingThreshold: ta.ProbabilityOfSatisfyingTHreshold,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Convert_v1alpha3_VersionAssessment_To_v1alpha2_VersionAssessment restores the assessment from analytics
+func Convert_v1alpha3_VersionAssessment_To_v1alpha2_VersionAssessment(in *v1alpha3.VersionAssessment, out *VersionAssessment, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha3_VersionAssessment_To_v1alpha2_VersionAssessment(in, out, s); err != nil {
+		return err
+	}
+	out.ID = in.ID
+	out.WinProbability = in.WinProbability
+	out.RequestCount = in.RequestCount
+	out.CriterionAssessments = nil
+	if in.CriterionAssessments != nil {
+		out.CriterionAssessments = make([]analyticsv1alpha2.CriterionAssessment, len(in.CriterionAssessments))
+		for i, ca := range in.CriterionAssessments {
+			out.CriterionAssessments[i] = analyticsv1alpha2.CriterionAssessment{ID: ca.ID, MetricID: ca.MetricID}
+			if ca.Statistics != nil {
+				out.CriterionAssessments[i].Statistics = &analyticsv1alpha2.Statistics{Value: ca.Statistics.Value}
+				if rs := ca.Statistics.RatioStatistics; rs != nil {
+					out.CriterionAssessments[i].Statistics.RatioStatistics = &analyticsv1alpha2.RatioStatistics{
+						ImprovementOverBaseline:       analyticsv1alpha2.Interval(rs.ImprovementOverBaseline),
+						ProbabilityOfBeatingBaseline:  rs.ProbabilityOfBeatingBaseline,
+						ProbabilityOfBeingBestVersion: rs.ProbabilityOfBeingBestVersion,
+						CredibleInterval:              analyticsv1alpha2.Interval(rs.CredibleInterval),
+					}
+				}
+			}
+			if ta := ca.ThresholdAssessment; ta != nil {
+				out.CriterionAssessments[i].ThresholdAssessment = &analyticsv1alpha2.ThresholdAssessment{
+					ThresholdBreached:                ta.ThresholdBreached,
+					ProbabilityOfSatisfyingTHreshold: ta.ProbabilityOfSatisfyingThreshold,
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/diff"
+
+	analyticsv1alpha2 "github.com/iter8-tools/iter8-istio/pkg/analytics/api/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha3"
+)
+
+const fuzzIterations = 500
+
+// conversionFuzzer fills in experiments with values which survive a round trip;
+// v1alpha2 always has a service reference and an analytics assessment after conversion,
+// and thresholds are bounded by the precision of both float32 and quantity
+func conversionFuzzer() *fuzz.Fuzzer {
+	return fuzz.New().NilChance(0.2).NumElements(0, 3).Funcs(
+		func(m *metav1.TypeMeta, c fuzz.Continue) {
+			*m = metav1.TypeMeta{}
+		},
+		func(r *runtime.RawExtension, c fuzz.Continue) {
+			*r = runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"%s":%d}`, c.RandString(), c.Int63()))}
+		},
+		func(s *Service, c fuzz.Continue) {
+			c.FuzzNoCustom(s)
+			if s.ObjectReference == nil {
+				s.ObjectReference = &corev1.ObjectReference{}
+			}
+		},
+		func(w *WinnerAssessment, c fuzz.Continue) {
+			c.FuzzNoCustom(w)
+			if w.WinnerAssessment == nil {
+				w.WinnerAssessment = &analyticsv1alpha2.WinnerAssessment{}
+			}
+		},
+		func(t *Threshold, c fuzz.Continue) {
+			c.FuzzNoCustom(t)
+			t.Value = fuzzThresholdValue(c)
+		},
+		func(q *resource.Quantity, c fuzz.Continue) {
+			*q = resource.MustParse(strconv.FormatFloat(float64(fuzzThresholdValue(c)), 'g', -1, 32))
+		},
+	)
+}
+
+func fuzzThresholdValue(c fuzz.Continue) float32 {
+	return float32(c.Int31n(2000000)-1000000) / 1000
+}
+
+func TestConversionRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	f := conversionFuzzer()
+
+	for i := 0; i < fuzzIterations; i++ {
+		original := &Experiment{}
+		f.Fuzz(original)
+
+		// generated conversions share memory with the input, so convert a copy
+		hub := &v1alpha3.Experiment{}
+		g.Expect(original.DeepCopy().ConvertTo(hub)).To(gomega.Succeed())
+		restored := &Experiment{}
+		g.Expect(restored.ConvertFrom(hub.DeepCopy())).To(gomega.Succeed())
+
+		if !equality.Semantic.DeepEqual(original, restored) {
+			t.Fatalf("v1alpha2 -> v1alpha3 -> v1alpha2 changes the experiment: %s", diff.ObjectReflectDiff(original, restored))
+		}
+	}
+
+	for i := 0; i < fuzzIterations; i++ {
+		original := &v1alpha3.Experiment{}
+		f.Fuzz(original)
+
+		spoke := &Experiment{}
+		g.Expect(spoke.ConvertFrom(original.DeepCopy())).To(gomega.Succeed())
+		restored := &v1alpha3.Experiment{}
+		g.Expect(spoke.DeepCopy().ConvertTo(restored)).To(gomega.Succeed())
+
+		if !equality.Semantic.DeepEqual(original, restored) {
+			t.Fatalf("v1alpha3 -> v1alpha2 -> v1alpha3 changes the experiment: %s", diff.ObjectReflectDiff(original, restored))
+		}
+	}
+}
+
+func TestConversionKeepsServiceReference(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	original := &Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Annotations: map[string]string{"owner": "bookinfo"}},
+		Spec: ExperimentSpec{
+			Service: Service{
+				ObjectReference: &corev1.ObjectReference{
+					Kind:            "Deployment",
+					Name:            "reviews",
+					UID:             "uid",
+					ResourceVersion: "1",
+				},
+				Baseline: "reviews-v1",
+			},
+		},
+	}
+
+	hub := &v1alpha3.Experiment{}
+	g.Expect(original.DeepCopy().ConvertTo(hub)).To(gomega.Succeed())
+	g.Expect(hub.Spec.Service.Name).To(gomega.Equal("reviews"))
+	g.Expect(hub.Annotations).To(gomega.HaveKey(AnnotationServiceRef))
+	g.Expect(original.Annotations).NotTo(gomega.HaveKey(AnnotationServiceRef))
+
+	restored := &Experiment{}
+	g.Expect(restored.ConvertFrom(hub)).To(gomega.Succeed())
+	g.Expect(restored.Spec.Service.ObjectReference).To(gomega.Equal(original.Spec.Service.ObjectReference))
+	g.Expect(restored.Annotations).To(gomega.Equal(map[string]string{"owner": "bookinfo"}))
+	g.Expect(hub.Annotations).To(gomega.HaveKey(AnnotationServiceRef))
+}
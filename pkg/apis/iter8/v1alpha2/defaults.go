@@ -13,8 +13,13 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
 )
 
 const (
@@ -27,6 +32,15 @@ const (
 	// DefaultCleanup indicate whether router and targets receiving no traffic should be deleted after expreriment
 	DefaultCleanup bool = false
 
+	// DefaultInheritTrafficPolicy indicate whether candidate subsets inherit the trafficPolicy of baseline subset by default, which is false
+	DefaultInheritTrafficPolicy bool = false
+
+	// DefaultProtocol is the default protocol of routes used in experiment, which is http
+	DefaultProtocol ProtocolType = ProtocolHTTP
+
+	// DefaultOnConflict is the default way to handle conflict with other experiments, which is fail
+	DefaultOnConflict OnConflictType = OnConflictFail
+
 	// DefaultStrategy is the default value for strategy, which is progressive
 	DefaultStrategy StrategyType = StrategyProgressive
 
@@ -47,6 +61,39 @@ const (
 
 	// DefaultAnalyticsEndpoint is the default endpoint of analytics
 	DefaultAnalyticsEndpoint string = "http://iter8-analytics:8080"
+
+	// DefaultOnTargetChange is the default reaction to changes of targets, which is pause
+	DefaultOnTargetChange OnTargetChangeType = OnTargetChangePause
+
+	// DefaultHookFailurePolicy is the default reaction to a failure of a hook, which is rollback
+	DefaultHookFailurePolicy HookFailurePolicy = HookFailureRollback
+
+	// DefaultHTTPHookTimeout is the default timeout of http hooks, which is 10 seconds
+	DefaultHTTPHookTimeout time.Duration = time.Second * 10
+
+	// DefaultJobHookTimeout is the default timeout of job hooks, which is 10 minutes
+	DefaultJobHookTimeout time.Duration = time.Minute * 10
+
+	// DefaultHealthCheckRequireReady indicates whether all replicas of candidates should be ready by default, which is true
+	DefaultHealthCheckRequireReady bool = true
+
+	// DefaultHealthCheckMaxRestarts is the default maximum number of container restarts of a candidate during a step, which is 0
+	DefaultHealthCheckMaxRestarts int32 = 0
+
+	// DefaultHealthCheckFailurePolicy is the default reaction to unhealthy candidates, which is rollback
+	DefaultHealthCheckFailurePolicy HealthCheckFailurePolicy = HealthCheckFailureRollback
+
+	// DefaultMetricProvider is the default provider of metrics, which is prometheus
+	DefaultMetricProvider MetricProviderType = MetricProviderPrometheus
+
+	// DefaultLoadQPS is the default number of requests per second of load, which is 8
+	DefaultLoadQPS int32 = 8
+
+	// MaxReportAttempts is the number of attempts to write the report before it is given up, which is 5
+	MaxReportAttempts int32 = 5
+
+	// DefaultLoadImage is the default image of fortio generating load
+	DefaultLoadImage string = "fortio/fortio:1.11.4"
 )
 
 // ServiceNamespace gets the namespace for targets
@@ -82,6 +129,14 @@ func (s *ExperimentSpec) Terminate() bool {
 	return false
 }
 
+// Approve indicates whether an approval of the pending approval gate is issued or not
+func (s *ExperimentSpec) Approve() bool {
+	if s.ManualOverride != nil && s.ManualOverride.Action == ActionApprove {
+		return true
+	}
+	return false
+}
+
 // GetAction retrieves the action specified in manual override if any
 func (s *ExperimentSpec) GetAction() ActionType {
 	if s.ManualOverride != nil {
@@ -99,7 +154,15 @@ func (s *ExperimentSpec) GetInterval() (time.Duration, error) {
 }
 
 // GetMaxIterations returns specified(or default) max of iterations
+// The number of iterations of an experiment with a step schedule is the sum of iterations of its steps
 func (s *ExperimentSpec) GetMaxIterations() int32 {
+	if steps := s.GetSteps(); len(steps) > 0 {
+		total := int32(0)
+		for i := range steps {
+			total += s.GetStepIterations(i)
+		}
+		return total
+	}
 	if s.Duration == nil || s.Duration.MaxIterations == nil {
 		return DefaultMaxIterations
 	}
@@ -130,6 +193,26 @@ func (s *ExperimentSpec) GetStrategy() string {
 	return string(*s.TrafficControl.Strategy)
 }
 
+// GetProtocol returns specified(or inferred from match) protocol of routes used in experiment
+func (s *ExperimentSpec) GetProtocol() ProtocolType {
+	tc := s.TrafficControl
+	if tc == nil {
+		return DefaultProtocol
+	}
+	if tc.Protocol != nil {
+		return *tc.Protocol
+	}
+	if tc.Match != nil {
+		if len(tc.Match.TCP) > 0 {
+			return ProtocolTCP
+		}
+		if len(tc.Match.TLS) > 0 {
+			return ProtocolTLS
+		}
+	}
+	return DefaultProtocol
+}
+
 // GetOnTermination returns specified(or default) onTermination strategy for traffic controller
 func (s *ExperimentSpec) GetOnTermination() OnTerminationType {
 	if s.TrafficControl == nil || s.TrafficControl.OnTermination == nil {
@@ -170,6 +253,228 @@ func (s *ExperimentSpec) GetCleanup() bool {
 	return *s.Cleanup
 }
 
+// GetInheritTrafficPolicy returns whether candidate subsets should inherit the trafficPolicy of baseline subset
+func (s *ExperimentSpec) GetInheritTrafficPolicy() bool {
+	if s.Networking == nil || s.Networking.InheritTrafficPolicy == nil {
+		return DefaultInheritTrafficPolicy
+	}
+	return *s.Networking.InheritTrafficPolicy
+}
+
+// GetOnConflict returns specified(or default) way to handle conflict with other experiments
+func (s *ExperimentSpec) GetOnConflict() OnConflictType {
+	if s.Networking == nil || s.Networking.OnConflict == nil {
+		return DefaultOnConflict
+	}
+	return *s.Networking.OnConflict
+}
+
+// GetOnTargetChange returns specified(or default) reaction to changes of targets
+func (s *ExperimentSpec) GetOnTargetChange() OnTargetChangeType {
+	if s.OnTargetChange == nil {
+		return DefaultOnTargetChange
+	}
+	return *s.OnTargetChange
+}
+
+// GetApprovalGate returns the approval gate with the name; nil if not found
+func (s *ExperimentSpec) GetApprovalGate(name string) *ApprovalGate {
+	for i := range s.ApprovalGates {
+		if s.ApprovalGates[i].Name == name {
+			return &s.ApprovalGates[i]
+		}
+	}
+	return nil
+}
+
+// GetTimeout returns the time to wait for approval of the gate; 0 means no timeout
+func (g *ApprovalGate) GetTimeout() (time.Duration, error) {
+	if g.Timeout == nil {
+		return 0, nil
+	}
+	return time.ParseDuration(*g.Timeout)
+}
+
+// IsPromotion tells whether the gate should be approved before the experiment completes
+func (g *ApprovalGate) IsPromotion() bool {
+	return g.Promotion != nil && *g.Promotion
+}
+
+// GetOnFailure returns specified(or default) reaction to a failure of the hook
+func (h *Hook) GetOnFailure() HookFailurePolicy {
+	if h.OnFailure == nil {
+		return DefaultHookFailurePolicy
+	}
+	return *h.OnFailure
+}
+
+// GetTimeout returns specified(or default) time allowed for the hook to complete
+func (h *Hook) GetTimeout() (time.Duration, error) {
+	if h.Timeout != nil {
+		return time.ParseDuration(*h.Timeout)
+	}
+	if h.HTTP != nil {
+		return DefaultHTTPHookTimeout, nil
+	}
+	return DefaultJobHookTimeout, nil
+}
+
+// GetJobSpec decodes the spec of the Job run by the hook
+func (h *Hook) GetJobSpec() (*batchv1.JobSpec, error) {
+	spec := &batchv1.JobSpec{}
+	if h.Job == nil || len(h.Job.Raw) == 0 {
+		return nil, fmt.Errorf("job of hook %s is not specified", h.Name)
+	}
+	if err := json.Unmarshal(h.Job.Raw, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// GetHook returns the hook with the name; nil if not found
+func (s *ExperimentSpec) GetHook(name string) *Hook {
+	for i := range s.Hooks {
+		if s.Hooks[i].Name == name {
+			return &s.Hooks[i]
+		}
+	}
+	return nil
+}
+
+// GetHooks returns the hooks running at the lifecycle point
+func (s *ExperimentSpec) GetHooks(phase HookPhase) []Hook {
+	var hooks []Hook
+	for _, h := range s.Hooks {
+		if h.Phase == phase {
+			hooks = append(hooks, h)
+		}
+	}
+	return hooks
+}
+
+// GetSteps returns the step schedule of traffic, which only applies to experiments without criteria
+func (s *ExperimentSpec) GetSteps() []Step {
+	if s.TrafficControl == nil || len(s.Criteria) > 0 {
+		return nil
+	}
+	return s.TrafficControl.Steps
+}
+
+// GetStepIterations returns the number of iterations the i-th step is held for,
+// which is its pause divided by interval rounded up, and at least 1
+func (s *ExperimentSpec) GetStepIterations(i int) int32 {
+	step := s.TrafficControl.Steps[i]
+	interval, err := s.GetInterval()
+	if step.Pause == nil || err != nil || interval <= 0 {
+		return 1
+	}
+	pause, err := time.ParseDuration(*step.Pause)
+	if err != nil || pause <= interval {
+		return 1
+	}
+	return int32((pause + interval - 1) / interval)
+}
+
+// GetStepAt returns the index of the step of the schedule active at the iteration
+// returns the number of steps once the schedule is over
+func (s *ExperimentSpec) GetStepAt(iteration int32) int32 {
+	end := int32(0)
+	for i := range s.GetSteps() {
+		end += s.GetStepIterations(i)
+		if iteration < end {
+			return int32(i)
+		}
+	}
+	return int32(len(s.GetSteps()))
+}
+
+// GetHealthCheck returns the health check gating steps of the schedule; nil if not specified
+func (s *ExperimentSpec) GetHealthCheck() *HealthCheck {
+	if len(s.GetSteps()) == 0 {
+		return nil
+	}
+	return s.TrafficControl.HealthCheck
+}
+
+// IsReadyRequired returns specified(or default) requireReady value
+func (h *HealthCheck) IsReadyRequired() bool {
+	if h.RequireReady == nil {
+		return DefaultHealthCheckRequireReady
+	}
+	return *h.RequireReady
+}
+
+// GetMaxRestarts returns specified(or default) maximum number of container restarts of a candidate during a step
+func (h *HealthCheck) GetMaxRestarts() int32 {
+	if h.MaxRestarts == nil {
+		return DefaultHealthCheckMaxRestarts
+	}
+	return *h.MaxRestarts
+}
+
+// GetOnFailure returns specified(or default) reaction to unhealthy candidates
+func (h *HealthCheck) GetOnFailure() HealthCheckFailurePolicy {
+	if h.OnFailure == nil {
+		return DefaultHealthCheckFailurePolicy
+	}
+	return *h.OnFailure
+}
+
+// GetQPS returns specified(or default) number of requests per second
+func (l *Load) GetQPS() int32 {
+	if l.QPS == nil {
+		return DefaultLoadQPS
+	}
+	return *l.QPS
+}
+
+// GetImage returns specified(or default) image of fortio generating load
+func (l *Load) GetImage() string {
+	if l.Image == nil {
+		return DefaultLoadImage
+	}
+	return *l.Image
+}
+
+// GetLoadDuration returns specified(or default) duration of load, which defaults to interval multiplied by maxIterations
+func (s *ExperimentSpec) GetLoadDuration() (time.Duration, error) {
+	if s.Load != nil && s.Load.Duration != nil {
+		return time.ParseDuration(*s.Load.Duration)
+	}
+	interval, err := s.GetInterval()
+	if err != nil {
+		return 0, err
+	}
+	return interval * time.Duration(s.GetMaxIterations()), nil
+}
+
+// GetLoadURL returns the URL requests of load are sent to, which defaults to the service of the experiment
+func (e *Experiment) GetLoadURL() string {
+	if l := e.Spec.Load; l != nil {
+		if l.URL != nil {
+			return *l.URL
+		}
+		if l.Host != nil {
+			return "http://" + *l.Host + "/"
+		}
+	}
+	url := fmt.Sprintf("http://%s.%s.svc.cluster.local", e.Spec.Service.Name, e.ServiceNamespace())
+	if e.Spec.Service.Port != nil {
+		url = fmt.Sprintf("%s:%d", url, *e.Spec.Service.Port)
+	}
+	return url + "/"
+}
+
+// ApprovedByAnnotation tells whether the approval gate is listed in the approve annotation of the experiment
+func (e *Experiment) ApprovedByAnnotation(gate string) bool {
+	for _, name := range strings.Split(e.GetAnnotations()[AnnotationApprove], ",") {
+		if strings.TrimSpace(name) == gate {
+			return true
+		}
+	}
+	return false
+}
+
 // IsZeroToOne returns specified(or default) zeroToOne value
 func (r *RatioMetric) IsZeroToOne() bool {
 	if r.ZeroToOne == nil {
@@ -178,6 +483,34 @@ func (r *RatioMetric) IsZeroToOne() bool {
 	return *r.ZeroToOne
 }
 
+// GetReportConfigMap returns the name of the ConfigMap holding the report of the experiment
+func (e *Experiment) GetReportConfigMap() string {
+	if e.Spec.Report != nil && e.Spec.Report.ConfigMap != nil {
+		return *e.Spec.Report.ConfigMap
+	}
+	return e.Name + "-report"
+}
+
+// GetProvider returns specified(or default) provider of the counter metric
+func (m *CounterMetric) GetProvider() MetricProviderType {
+	if m.Provider == nil {
+		return DefaultMetricProvider
+	}
+	return *m.Provider
+}
+
+// Validate checks whether counter metrics are queried from providers supported by analytics
+func (m *Metrics) Validate() error {
+	for i := range m.CounterMetrics {
+		cm := &m.CounterMetrics[i]
+		if cm.GetProvider() != MetricProviderPrometheus {
+			return fmt.Errorf("unsupported provider of metric %s: %s, analytics only queries %s",
+				cm.Name, cm.GetProvider(), MetricProviderPrometheus)
+		}
+	}
+	return nil
+}
+
 // TerminateExperiment terminates experiment
 func (s *ExperimentSpec) TerminateExperiment() {
 	s.ManualOverride = &ManualOverride{
@@ -207,5 +540,298 @@ func (s *ExperimentSpec) Validate() error {
 		return fmt.Errorf("Invalid kind/apiVerison pair: %s, %s", s.Kind, s.APIVersion)
 	}
 
+	if err := s.validateNotifications(); err != nil {
+		return err
+	}
+
+	if err := s.validateApprovalGates(); err != nil {
+		return err
+	}
+
+	if err := s.validateHooks(); err != nil {
+		return err
+	}
+
+	if err := s.validateLoad(); err != nil {
+		return err
+	}
+
+	if err := s.validateSteps(); err != nil {
+		return err
+	}
+
+	if err := s.validateGuardrails(); err != nil {
+		return err
+	}
+
+	if err := s.validateVersionLabels(); err != nil {
+		return err
+	}
+
+	if err := s.validateReport(); err != nil {
+		return err
+	}
+
+	return s.validateMatch()
+}
+
+// validateNotifications checks whether notification subscriptions are unique and reference a secret
+func (s *ExperimentSpec) validateNotifications() error {
+	names := make(map[string]bool)
+	for _, n := range s.Notifications {
+		if n.Name == "" {
+			return fmt.Errorf("name of notification subscription is required")
+		}
+		if names[n.Name] {
+			return fmt.Errorf("duplicate notification subscription: %s", n.Name)
+		}
+		names[n.Name] = true
+		if n.SecretRef.Name == "" {
+			return fmt.Errorf("secretRef of notification subscription %s is required", n.Name)
+		}
+	}
+	return nil
+}
+
+// validateApprovalGates checks whether approval gates are unique and each of them has exactly one milestone
+func (s *ExperimentSpec) validateApprovalGates() error {
+	names := make(map[string]bool)
+	for _, g := range s.ApprovalGates {
+		if g.Name == "" {
+			return fmt.Errorf("name of approval gate is required")
+		}
+		if names[g.Name] {
+			return fmt.Errorf("duplicate approval gate: %s", g.Name)
+		}
+		names[g.Name] = true
+
+		milestones := 0
+		if g.Weight != nil {
+			milestones++
+		}
+		if g.Iteration != nil {
+			milestones++
+		}
+		if g.IsPromotion() {
+			milestones++
+		}
+		if milestones != 1 {
+			return fmt.Errorf("approval gate %s should have exactly one of weight, iteration and promotion", g.Name)
+		}
+
+		if _, err := g.GetTimeout(); err != nil {
+			return fmt.Errorf("invalid timeout of approval gate %s: %v", g.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateHooks checks whether hooks are unique and each of them has exactly one of job and http
+func (s *ExperimentSpec) validateHooks() error {
+	names := make(map[string]bool)
+	for _, h := range s.Hooks {
+		if h.Name == "" {
+			return fmt.Errorf("name of hook is required")
+		}
+		if names[h.Name] {
+			return fmt.Errorf("duplicate hook: %s", h.Name)
+		}
+		names[h.Name] = true
+
+		if (h.Job == nil) == (h.HTTP == nil) {
+			return fmt.Errorf("hook %s should have exactly one of job and http", h.Name)
+		}
+		if h.Job != nil {
+			if _, err := h.GetJobSpec(); err != nil {
+				return fmt.Errorf("invalid job of hook %s: %v", h.Name, err)
+			}
+		}
+		if h.HTTP != nil && h.HTTP.URL == "" {
+			return fmt.Errorf("url of hook %s is required", h.Name)
+		}
+		if _, err := h.GetTimeout(); err != nil {
+			return fmt.Errorf("invalid timeout of hook %s: %v", h.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateSteps checks whether the step schedule is used without criteria and maxIterations, and its pauses are valid
+func (s *ExperimentSpec) validateSteps() error {
+	if s.TrafficControl == nil || len(s.TrafficControl.Steps) == 0 {
+		if s.TrafficControl != nil && s.TrafficControl.HealthCheck != nil {
+			return fmt.Errorf("healthCheck requires steps")
+		}
+		return nil
+	}
+	if len(s.Criteria) > 0 {
+		return fmt.Errorf("steps can not be specified along with criteria")
+	}
+	if s.Duration != nil && s.Duration.MaxIterations != nil {
+		return fmt.Errorf("steps can not be specified along with maxIterations")
+	}
+	for i, step := range s.TrafficControl.Steps {
+		if step.Weight < 0 || step.Weight > 100 {
+			return fmt.Errorf("invalid weight of step %d: %d", i, step.Weight)
+		}
+		if step.Pause != nil {
+			if _, err := time.ParseDuration(*step.Pause); err != nil {
+				return fmt.Errorf("invalid pause of step %d: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateLoad checks whether load has a destination and a valid duration
+func (s *ExperimentSpec) validateLoad() error {
+	if s.Load == nil {
+		return nil
+	}
+	if s.Load.URL == nil && s.Load.Host == nil && s.Name == "" {
+		return fmt.Errorf("url or host of load is required when name of service is not specified")
+	}
+	if s.Load.GetQPS() < 1 {
+		return fmt.Errorf("invalid qps of load: %d", s.Load.GetQPS())
+	}
+	duration, err := s.GetLoadDuration()
+	if err != nil {
+		return fmt.Errorf("invalid duration of load: %v", err)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("invalid duration of load: %s", duration)
+	}
+	return nil
+}
+
+// validateGuardrails checks whether guardrails are unique and each of them has a query and a bound
+func (s *ExperimentSpec) validateGuardrails() error {
+	names := make(map[string]bool)
+	for _, g := range s.Guardrails {
+		if g.Name == "" {
+			return fmt.Errorf("name of guardrail is required")
+		}
+		if names[g.Name] {
+			return fmt.Errorf("duplicate guardrail: %s", g.Name)
+		}
+		names[g.Name] = true
+
+		if g.Query == "" {
+			return fmt.Errorf("query of guardrail %s is required", g.Name)
+		}
+		if g.Max == nil && g.Min == nil {
+			return fmt.Errorf("guardrail %s should have at least one of max and min", g.Name)
+		}
+		if g.Max != nil && g.Min != nil && g.Min.Cmp(*g.Max) > 0 {
+			return fmt.Errorf("min of guardrail %s is greater than max", g.Name)
+		}
+	}
+	return nil
+}
+
+// validateVersionLabels checks whether labels are keyed and overridden for versions of the experiment only
+func (s *ExperimentSpec) validateVersionLabels() error {
+	if s.VersionLabels == nil {
+		return nil
+	}
+	for k := range s.VersionLabels.Template {
+		if k == "" {
+			return fmt.Errorf("empty key in template of versionLabels")
+		}
+	}
+	names := make(map[string]bool)
+	for _, values := range s.VersionLabels.Versions {
+		if names[values.Name] {
+			return fmt.Errorf("duplicate version in versionLabels: %s", values.Name)
+		}
+		names[values.Name] = true
+
+		found := values.Name == s.Service.Baseline
+		for _, candidate := range s.Service.Candidates {
+			found = found || values.Name == candidate
+		}
+		if !found {
+			return fmt.Errorf("unknown version in versionLabels: %s", values.Name)
+		}
+	}
+	return nil
+}
+
+// validateReport checks whether the report is written to either a ConfigMap or a valid url,
+// and that the secret of headers is only referenced along with the url
+func (s *ExperimentSpec) validateReport() error {
+	if s.Report == nil {
+		return nil
+	}
+	if s.Report.URL == nil {
+		if s.Report.SecretRef != nil {
+			return fmt.Errorf("secretRef of report requires url")
+		}
+		return nil
+	}
+	if s.Report.SecretRef != nil && s.Report.SecretRef.Name == "" {
+		return fmt.Errorf("name of secretRef of report is required")
+	}
+	if s.Report.ConfigMap != nil {
+		return fmt.Errorf("report can not be written to both configMap and url")
+	}
+	u, err := url.Parse(*s.Report.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url of report: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid url of report: %s", *s.Report.URL)
+	}
+	return nil
+}
+
+// validateMatch checks whether match clauses are consistent with the protocol of routes
+func (s *ExperimentSpec) validateMatch() error {
+	protocol := s.GetProtocol()
+	if s.TrafficControl == nil || s.TrafficControl.Match == nil {
+		if protocol == ProtocolTLS {
+			return fmt.Errorf("tls match is required for protocol tls")
+		}
+		return nil
+	}
+
+	match := s.TrafficControl.Match
+	switch protocol {
+	case ProtocolHTTP:
+		if len(match.TCP) > 0 || len(match.TLS) > 0 {
+			return fmt.Errorf("tcp/tls match is not allowed for protocol http")
+		}
+		for _, m := range match.HTTP {
+			if err := m.Validate(); err != nil {
+				return err
+			}
+		}
+		for _, m := range match.GRPC {
+			if m.Service == "" {
+				return fmt.Errorf("service is missing in grpc match")
+			}
+			if err := m.Validate(); err != nil {
+				return err
+			}
+		}
+	case ProtocolTCP:
+		if len(match.HTTP) > 0 || len(match.GRPC) > 0 || len(match.TLS) > 0 {
+			return fmt.Errorf("only tcp match is allowed for protocol tcp")
+		}
+	case ProtocolTLS:
+		if len(match.HTTP) > 0 || len(match.GRPC) > 0 || len(match.TCP) > 0 {
+			return fmt.Errorf("only tls match is allowed for protocol tls")
+		}
+		if len(match.TLS) == 0 {
+			return fmt.Errorf("tls match is required for protocol tls")
+		}
+		for _, m := range match.TLS {
+			if len(m.SniHosts) == 0 {
+				return fmt.Errorf("sniHosts is missing in tls match")
+			}
+		}
+	default:
+		return fmt.Errorf("Invalid protocol: %s", protocol)
+	}
 	return nil
 }
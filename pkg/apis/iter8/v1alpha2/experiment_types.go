@@ -14,6 +14,7 @@ package v1alpha2
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 
@@ -94,6 +95,236 @@ type ExperimentSpec struct {
 	// Networking describes how traffic network should be configured for the experiment
 	// +optional
 	Networking *Networking `json:"networking,omitempty"`
+
+	// Notifications lists notification channels subscribed to the experiment
+	// +optional
+	Notifications []NotificationSubscription `json:"notifications,omitempty"`
+
+	// ApprovalGates lists milestones at which the experiment waits for manual approval
+	// +optional
+	ApprovalGates []ApprovalGate `json:"approvalGates,omitempty"`
+
+	// Hooks lists checks run at lifecycle points of the experiment
+	// +optional
+	Hooks []Hook `json:"hooks,omitempty"`
+
+	// OnTargetChange determines how the experiment reacts when the pod template, selector or replicas of its targets
+	// are changed during the experiment
+	// default is pause
+	// +kubebuilder:validation:Enum={restart,pause,abort}
+	// +optional
+	OnTargetChange *OnTargetChangeType `json:"onTargetChange,omitempty"`
+
+	// Load is the load generated on the service during the experiment, such as for performance experiments
+	// +optional
+	Load *Load `json:"load,omitempty"`
+
+	// Guardrails lists prometheus queries evaluated by the controller in every iteration
+	// A breach of any guardrail rolls back the experiment regardless of the assessment of analytics
+	// +optional
+	Guardrails []Guardrail `json:"guardrails,omitempty"`
+
+	// VersionLabels determines the labels identifying versions in prometheus metrics and guardrails
+	// default is the labels of istio telemetry identifying the workload or service
+	// +optional
+	VersionLabels *VersionLabels `json:"versionLabels,omitempty"`
+
+	// Report is written when the experiment completes, for records of the change
+	// +optional
+	Report *Report `json:"report,omitempty"`
+}
+
+// NotificationSubscription describes a notification channel subscribed to the experiment
+type NotificationSubscription struct {
+	// Name of the subscription, unique in the experiment
+	Name string `json:"name"`
+
+	// Notifier is the type of the notification receiver
+	// +kubebuilder:validation:Enum={slack,webhook,teams,pagerduty}
+	Notifier string `json:"notifier"`
+
+	// SecretRef references a Secret in the namespace of the experiment holding the endpoint of the notifier
+	// The url is stored in key url; the routing key of PagerDuty is stored in key routingKey
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+
+	// Level specifies the informative level; default is normal
+	// +kubebuilder:validation:Enum={error,warning,normal,verbose}
+	// +optional
+	Level *string `json:"level,omitempty"`
+
+	// Template is the Go template of notification message; default template of the notifier is used if not specified
+	// +optional
+	Template *string `json:"template,omitempty"`
+}
+
+// ApprovalGate is a milestone of the experiment which is only passed after manual approval
+// Exactly one of weight, iteration and promotion should be specified
+type ApprovalGate struct {
+	// Name of the gate, unique in the experiment
+	Name string `json:"name"`
+
+	// Weight is the traffic percentage that no candidate can exceed before the gate is approved
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// Iteration is the number of completed iterations after which the experiment waits for approval
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Iteration *int32 `json:"iteration,omitempty"`
+
+	// Promotion indicates the experiment waits for approval before it completes
+	// +optional
+	Promotion *bool `json:"promotion,omitempty"`
+
+	// Timeout is how long to wait for approval once the gate is reached
+	// The experiment is terminated if the gate is not approved in time; wait forever if not specified
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// Hook is a check run at a lifecycle point of the experiment, as a Job or an HTTP call
+// Exactly one of job and http should be specified
+type Hook struct {
+	// Name of the hook, unique in the experiment
+	Name string `json:"name"`
+
+	// Phase is the lifecycle point when the hook runs
+	// +kubebuilder:validation:Enum={preTraffic,postTrafficUpdate,preCompletion}
+	Phase HookPhase `json:"phase"`
+
+	// Job is the spec (batch/v1 JobSpec) of the Job run in the namespace of the experiment
+	// The hook succeeds if the Job completes
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Job *runtime.RawExtension `json:"job,omitempty"`
+
+	// HTTP is the endpoint called by the hook
+	// +optional
+	HTTP *HTTPHook `json:"http,omitempty"`
+
+	// OnFailure determines how the experiment reacts to a failure of the hook
+	// default is rollback
+	// +kubebuilder:validation:Enum={rollback,pause}
+	// +optional
+	OnFailure *HookFailurePolicy `json:"onFailure,omitempty"`
+
+	// Timeout is the time allowed for the hook to complete
+	// default is 10s for http hooks and 10m for job hooks
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// HTTPHook describes an HTTP endpoint called by a hook
+// The hook succeeds if the endpoint responds to the POST request with a 2xx status code
+type HTTPHook struct {
+	// URL of the endpoint
+	URL string `json:"url"`
+
+	// Headers added to the request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Load describes requests sent by a Job running fortio in the namespace of the experiment
+// Requests are sent to url if specified, otherwise to host, otherwise to the service of the experiment
+type Load struct {
+	// URL the requests are sent to
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// Host the requests are sent to over http, such as an external host of networking
+	// +optional
+	Host *string `json:"host,omitempty"`
+
+	// QPS is the number of requests per second
+	// default is 8
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	QPS *int32 `json:"qps,omitempty"`
+
+	// Duration is how long the load is generated, such as 10m
+	// default is interval multiplied by maxIterations
+	// +optional
+	Duration *string `json:"duration,omitempty"`
+
+	// Headers added to the requests
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Payload is the body of the requests, which are sent as POST if specified
+	// +optional
+	Payload *string `json:"payload,omitempty"`
+
+	// Image of fortio run by the Job
+	// default is fortio/fortio:1.11.4
+	// +optional
+	Image *string `json:"image,omitempty"`
+}
+
+// Report describes where the report of the experiment is written when it completes
+// The report is written to a ConfigMap in the namespace of the experiment unless url is specified
+type Report struct {
+	// ConfigMap is the name of the ConfigMap holding report.json and report.md
+	// default is the name of the experiment suffixed by -report
+	// +optional
+	ConfigMap *string `json:"configMap,omitempty"`
+
+	// URL of an object store, such as a bucket of an S3-compatible store, the report is uploaded to
+	// by PUT requests to <url>/<namespace>/<name>/report.json and report.md
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// SecretRef references a Secret in the namespace of the experiment whose keys and values are headers
+	// added to the requests uploading the report, such as Authorization
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// VersionLabels are the keys and values of labels identifying versions in metrics
+type VersionLabels struct {
+	// Template maps keys of labels to their values, in which $name is replaced by the name of the version
+	// and $namespace by the namespace of the service, such as {destination_app: reviews, destination_version: $name}
+	// default is the labels of the provider of metrics
+	// +optional
+	Template map[string]string `json:"template,omitempty"`
+
+	// Versions lists values of labels of versions overriding the template
+	// Only labels in the template are overridden
+	// +optional
+	Versions []VersionLabelValues `json:"versions,omitempty"`
+}
+
+// VersionLabelValues are values of labels identifying a version, such as {destination_version: v2} of reviews-v2
+type VersionLabelValues struct {
+	// Name of the version
+	Name string `json:"name"`
+
+	// Labels maps keys of labels to their values
+	Labels map[string]string `json:"labels"`
+}
+
+// Guardrail is a prometheus query evaluated for each candidate, whose value must stay within max and min
+// The query is templated as metrics of iter8config-metrics:
+// $interval is replaced by the time elapsed since the experiment started,
+// $version_labels by the labels identifying the version
+// Guardrails are not evaluated for a candidate until the query returns a value for it
+type Guardrail struct {
+	// Name of the guardrail
+	Name string `json:"name"`
+
+	// Query is the PromQL query, such as
+	// histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket[$interval])) by (le, $version_labels))
+	Query string `json:"query"`
+
+	// Max is the maximum value of the query, breached if exceeded
+	// +optional
+	Max *resource.Quantity `json:"max,omitempty"`
+
+	// Min is the minimum value of the query, breached if not reached
+	// +optional
+	Min *resource.Quantity `json:"min,omitempty"`
 }
 
 // Service is a reference to the service that this experiment is targeting at
@@ -176,6 +407,12 @@ type TrafficControl struct {
 	// +optional
 	OnTermination *OnTerminationType `json:"onTermination,omitempty"`
 
+	// Protocol of the routes used to shift traffic
+	// default is tcp if tcp match is specified, tls if tls match is specified, and http otherwise
+	// +kubebuilder:validation:Enum={http,tcp,tls}
+	// +optional
+	Protocol *ProtocolType `json:"protocol,omitempty"`
+
 	// Only requests fulfill the match section would be used in experiment
 	// Istio matching rules are used
 	// +optional
@@ -195,6 +432,49 @@ type TrafficControl struct {
 	// If it's not specified, the first entry of effictive host will be used as the id
 	// +optional
 	RouterID *string `json:"routerID,omitempty"`
+
+	// Steps is the schedule of traffic sent to candidates in experiments without criteria
+	// It replaces increments of maxIncrement, and determines the number of iterations instead of maxIterations
+	// +optional
+	Steps []Step `json:"steps,omitempty"`
+
+	// HealthCheck gates each step of the schedule on the health of candidates
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+}
+
+// Step is a traffic split held for a while in the step schedule
+type Step struct {
+	// Weight is the percentage of traffic sent to candidates, split evenly among them
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// Pause is how long the traffic split is held before the next step, such as 5m
+	// It is rounded up to a multiple of interval
+	// default is interval
+	// +optional
+	Pause *string `json:"pause,omitempty"`
+}
+
+// HealthCheck describes checks of the pods of candidates run before moving to the next step of the schedule
+type HealthCheck struct {
+	// RequireReady requires all replicas of each candidate to be ready
+	// default is true
+	// +optional
+	RequireReady *bool `json:"requireReady,omitempty"`
+
+	// MaxRestarts is the maximum number of container restarts in the pods of each candidate during a step
+	// default is 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+
+	// OnFailure determines how the experiment reacts to unhealthy candidates
+	// default is rollback
+	// +kubebuilder:validation:Enum={rollback,pause}
+	// +optional
+	OnFailure *HealthCheckFailurePolicy `json:"onFailure,omitempty"`
 }
 
 // Match contains matching criteria for requests
@@ -202,12 +482,24 @@ type Match struct {
 	// Matching criteria for HTTP requests
 	// +optional
 	HTTP []*HTTPMatchRequest `json:"http,omitempty"`
+
+	// Matching criteria for gRPC requests, applied as HTTP matches
+	// +optional
+	GRPC []*GRPCMatchRequest `json:"grpc,omitempty"`
+
+	// Matching criteria for TCP connections
+	// +optional
+	TCP []*L4MatchAttributes `json:"tcp,omitempty"`
+
+	// Matching criteria for TLS connections
+	// +optional
+	TLS []*TLSMatchAttributes `json:"tls,omitempty"`
 }
 
 // ManualOverride defines actions that the user can perform to an experiment
 type ManualOverride struct {
 	// Action to perform
-	//+kubebuilder:validation:Enum={pause,resume,terminate}
+	//+kubebuilder:validation:Enum={pause,resume,terminate,approve}
 	Action ActionType `json:"action"`
 	// Traffic split status specification
 	// Applied to action terminate only
@@ -227,6 +519,17 @@ type Networking struct {
 	// List of hosts used to receive external traffic
 	// +optional
 	Hosts []Host `json:"hosts,omitempty"`
+
+	// OnConflict determines how to handle the experiment if its router or targets are held by another experiment
+	// default is fail
+	// +kubebuilder:validation:Enum={fail,queue}
+	// +optional
+	OnConflict *OnConflictType `json:"onConflict,omitempty"`
+
+	// InheritTrafficPolicy indicates whether candidate subsets should inherit the trafficPolicy of baseline subset
+	// default is false
+	// +optional
+	InheritTrafficPolicy *bool `json:"inheritTrafficPolicy,omitempty"`
 }
 
 // Metrics contains definitions for metrics used in the experiment
@@ -255,6 +558,13 @@ type CounterMetric struct {
 	// Unit of the metric value
 	// +optional
 	Unit *string `json:"unit,omitempty" yaml:"unit,omitempty"`
+
+	// Provider the metric is queried from, which determines the syntax of the query template
+	// and the labels identifying versions
+	// prometheus is the only provider queried by analytics, and the default
+	// +kubebuilder:validation:Enum={prometheus}
+	// +optional
+	Provider *MetricProviderType `json:"provider,omitempty" yaml:"provider,omitempty"`
 }
 
 // RatioMetric is the definiton of Ratio Metric
@@ -283,6 +593,10 @@ type ExperimentStatus struct {
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
 
+	// ObservedGeneration is the generation of the experiment reflected by the status
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// InitTimestamp is the timestamp when the experiment is initialized
 	// +optional
 	InitTimestamp *metav1.Time `json:"initTimestamp,omitempty"`
@@ -322,11 +636,145 @@ type ExperimentStatus struct {
 	// ExperimentType is type of experiment
 	ExperimentType string `json:"experimentType,omitempty"`
 
+	// ApprovalGates records the approval gates reached by the experiment
+	// +optional
+	ApprovalGates []ApprovalGateStatus `json:"approvalGates,omitempty"`
+
+	// Hooks records the result of the latest run of each hook
+	// +optional
+	Hooks []HookStatus `json:"hooks,omitempty"`
+
+	// Load records the Job generating load for the experiment
+	// +optional
+	Load *LoadStatus `json:"load,omitempty"`
+
+	// CurrentStep records the current step of the schedule
+	// +optional
+	CurrentStep *StepStatus `json:"currentStep,omitempty"`
+
+	// TrafficHistory records the traffic split among versions after each change, if report is specified
+	// +optional
+	TrafficHistory []TrafficSplit `json:"trafficHistory,omitempty"`
+
+	// ReportRef refers to the report written when the experiment completed
+	// +optional
+	ReportRef *ReportRef `json:"reportRef,omitempty"`
+
+	// ReportAttempts counts the failed attempts to write the report, which is given up after 5 attempts
+	// +optional
+	ReportAttempts int32 `json:"reportAttempts,omitempty"`
+
 	// EffectiveHosts is computed host for experiment.
 	// List of spec.Service.Name and spec.Service.Hosts[0].name
 	EffectiveHosts []string `json:"effectiveHosts,omitempty"`
 }
 
+// ApprovalGateStatus records the state of an approval gate reached by the experiment
+type ApprovalGateStatus struct {
+	// Name of the gate
+	Name string `json:"name"`
+
+	// State of the approval
+	State ApprovalState `json:"state"`
+
+	// ReachedTime is the time when the gate is reached
+	// +optional
+	ReachedTime *metav1.Time `json:"reachedTime,omitempty"`
+
+	// DecisionTime is the time when the gate is approved or rejected
+	// +optional
+	DecisionTime *metav1.Time `json:"decisionTime,omitempty"`
+
+	// DecidedBy records how the gate is approved or rejected
+	// +optional
+	DecidedBy *string `json:"decidedBy,omitempty"`
+}
+
+// HookStatus records the result of a run of a hook
+type HookStatus struct {
+	// Name of the hook
+	Name string `json:"name"`
+
+	// Phase is the lifecycle point when the hook ran
+	Phase HookPhase `json:"phase"`
+
+	// Iteration is the iteration when the hook ran
+	Iteration int32 `json:"iteration"`
+
+	// State of the run
+	State HookState `json:"state"`
+
+	// Job is the name of the Job run by the hook
+	// +optional
+	Job *string `json:"job,omitempty"`
+
+	// Message describes the result of the run
+	// +optional
+	Message *string `json:"message,omitempty"`
+
+	// StartTime is the time when the run started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the run completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// StepStatus records the current step of the schedule
+type StepStatus struct {
+	// Index of the step in the schedule
+	Index int32 `json:"index"`
+
+	// StartTime is the time when traffic is shifted to the step
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Restarts is the number of container restarts in the pods of each candidate when the step started
+	// +optional
+	Restarts map[string]int32 `json:"restarts,omitempty"`
+}
+
+// TrafficSplit is the traffic split among versions from the iteration
+type TrafficSplit struct {
+	// Iteration from which the split is applied
+	Iteration int32 `json:"iteration"`
+
+	// Time when the split is applied
+	Time metav1.Time `json:"time"`
+
+	// Weights maps names of versions to their percentage of traffic
+	Weights map[string]int32 `json:"weights"`
+}
+
+// ReportRef refers to the report of the experiment
+type ReportRef struct {
+	// ConfigMap is the name of the ConfigMap holding the report, in the namespace of the experiment
+	// +optional
+	ConfigMap *string `json:"configMap,omitempty"`
+
+	// URL of report.json uploaded to the object store; report.md is uploaded next to it
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// Time when the report is written
+	Time metav1.Time `json:"time"`
+}
+
+// LoadStatus records the Job generating load for the experiment
+type LoadStatus struct {
+	// Job is the name of the Job generating the load
+	Job string `json:"job"`
+
+	// StartTime is the time when the Job is created
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// StopTime is the time when the Job is deleted as the experiment completes
+	// +optional
+	StopTime *metav1.Time `json:"stopTime,omitempty"`
+}
+
 // Conditions is a list of ExperimentConditions
 type Conditions []*ExperimentCondition
 
@@ -338,10 +786,14 @@ type ExperimentCondition struct {
 	// Status of the condition
 	Status corev1.ConditionStatus `json:"status"`
 
-	// The time when this condition is last updated
+	// The time when the status of this condition last changed
 	// +optional
 	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
 
+	// ObservedGeneration is the generation of the experiment when this condition was last set
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Reason for the last update
 	// +optional
 	Reason *string `json:"reason,omitempty"`
@@ -12,7 +12,11 @@ limitations under the License.
 
 package v1alpha2
 
-// This file contains re-typed HTTPMatchRequest from networking.istio.io/v1alpha3.
+import (
+	"fmt"
+)
+
+// This file contains re-typed HTTPMatchRequest, L4MatchAttributes and TLSMatchAttributes from networking.istio.io/v1alpha3.
 // CRD generator from sigs.k8s.io/controller-tools fails to recognize orginal format.
 
 type HTTPMatchRequest struct {
@@ -48,14 +52,124 @@ type HTTPMatchRequest struct {
 
 	// Flag to specify whether the URI matching should be case-insensitive.
 	IgnoreURICase bool `json:"ignore_uri_case,omitempty"`
+
+	// Headers which must not be present in the request
+	WithoutHeaders map[string]StringMatch `json:"withoutHeaders,omitempty"`
+
+	// Source namespace for matching
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+}
+
+// GRPCMatchRequest specifies gRPC requests to match; it is applied as an HTTP match
+// on the request path /<service>/<method> and the gRPC content-type
+type GRPCMatchRequest struct {
+	// Fully qualified name of the gRPC service, e.g. helloworld.Greeter
+	Service string `json:"service"`
+
+	// Name of the gRPC method; all methods of the service are matched if not specified
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Headers (gRPC metadata) to match
+	// +optional
+	Headers map[string]StringMatch `json:"headers,omitempty"`
+}
+
+// L4MatchAttributes specifies TCP connections to match
+type L4MatchAttributes struct {
+	// IPv4 or IPv6 ip addresses of destination with optional subnet.
+	DestinationSubnets []string `json:"destinationSubnets,omitempty"`
+
+	// Specifies the port on the host that is being addressed.
+	Port uint32 `json:"port,omitempty"`
+
+	// IPv4 or IPv6 ip address of source with optional subnet.
+	SourceSubnet string `json:"sourceSubnet,omitempty"`
+
+	// SourceLabels for matching
+	SourceLabels map[string]string `json:"sourceLabels,omitempty"`
+
+	// Gateways for matching
+	Gateways []string `json:"gateways,omitempty"`
+
+	// Source namespace for matching
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
 }
 
+// TLSMatchAttributes specifies TLS connections to match
+type TLSMatchAttributes struct {
+	// SNI (server name indicator) to match on.
+	SniHosts []string `json:"sniHosts"`
+
+	// IPv4 or IPv6 ip addresses of destination with optional subnet.
+	DestinationSubnets []string `json:"destinationSubnets,omitempty"`
+
+	// Specifies the port on the host that is being addressed.
+	Port uint32 `json:"port,omitempty"`
+
+	// SourceLabels for matching
+	SourceLabels map[string]string `json:"sourceLabels,omitempty"`
+
+	// Gateways for matching
+	Gateways []string `json:"gateways,omitempty"`
+
+	// Source namespace for matching
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+}
+
+// StringMatch specifies how to match a string; exactly one of exact, prefix and regex should be set
 type StringMatch struct {
 	Exact  *string `json:"exact,omitempty"`
 	Prefix *string `json:"prefix,omitempty"`
 	Regex  *string `json:"regex,omitempty"`
 }
 
+// IsValid tells whether exactly one of exact, prefix and regex is set
 func (s *StringMatch) IsValid() bool {
-	return s.Exact != nil || s.Prefix != nil || s.Regex != nil
+	n := 0
+	for _, v := range []*string{s.Exact, s.Prefix, s.Regex} {
+		if v != nil {
+			n++
+		}
+	}
+	return n == 1
+}
+
+// Validate checks whether all string matches in the match request are valid
+func (m *HTTPMatchRequest) Validate() error {
+	for name, s := range map[string]*StringMatch{
+		"uri":       m.URI,
+		"scheme":    m.Scheme,
+		"method":    m.Method,
+		"authority": m.Authority,
+	} {
+		if s != nil && !s.IsValid() {
+			return fmt.Errorf("exactly one of exact, prefix and regex should be set in %s match", name)
+		}
+	}
+
+	for field, matches := range map[string]map[string]StringMatch{
+		"headers":        m.Headers,
+		"query_params":   m.QueryParams,
+		"withoutHeaders": m.WithoutHeaders,
+	} {
+		if err := validateStringMatches(field, matches); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks whether all string matches in the grpc match request are valid
+func (m *GRPCMatchRequest) Validate() error {
+	return validateStringMatches("headers", m.Headers)
+}
+
+func validateStringMatches(field string, matches map[string]StringMatch) error {
+	for key, s := range matches {
+		if !s.IsValid() {
+			return fmt.Errorf("exactly one of exact, prefix and regex should be set in %s match of %s", field, key)
+		}
+	}
+	return nil
 }
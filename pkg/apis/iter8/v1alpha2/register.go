@@ -16,7 +16,7 @@ limitations under the License.
 // Package v1alpha2 contains API Schema definitions for the iter8 v1alpha2 API group
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=package,register
-// +k8s:conversion-gen=github.com/iter8-tools/iter8-istio/pkg/apis/iter8
+// +k8s:conversion-gen=github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha3
 // +k8s:defaulter-gen=TypeMeta
 // +groupName=iter8.tools
 package v1alpha2
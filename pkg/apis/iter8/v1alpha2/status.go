@@ -14,6 +14,7 @@ package v1alpha2
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/iter8-tools/iter8-istio/pkg/analytics/api/v1alpha2"
 	corev1 "k8s.io/api/core/v1"
@@ -27,12 +28,29 @@ var experimentCondSet = []ExperimentConditionType{
 	ExperimentConditionExperimentCompleted,
 	ExperimentConditionAnalyticsServiceNormal,
 	ExperimentConditionRoutingRulesReady,
+	ExperimentConditionTargetsReady,
+	ExperimentConditionReady,
+}
+
+// readyRequired are the conditions which must be True for the experiment to be ready
+var readyRequired = []ExperimentConditionType{
+	ExperimentConditionTargetsProvided,
+	ExperimentConditionRoutingRulesReady,
+}
+
+// readyDegraded are the conditions which make the experiment not ready when False
+var readyDegraded = []ExperimentConditionType{
+	ExperimentConditionMetricsSynced,
+	ExperimentConditionAnalyticsServiceNormal,
+	ExperimentConditionTargetsReady,
+	ExperimentConditionTargetsUnchanged,
 }
 
 func (s *ExperimentStatus) addCondition(conditionType ExperimentConditionType) *ExperimentCondition {
 	condition := &ExperimentCondition{
-		Type:   conditionType,
-		Status: corev1.ConditionUnknown,
+		Type:               conditionType,
+		Status:             corev1.ConditionUnknown,
+		ObservedGeneration: s.ObservedGeneration,
 	}
 	now := metav1.Now()
 	condition.LastTransitionTime = &now
@@ -42,13 +60,37 @@ func (s *ExperimentStatus) addCondition(conditionType ExperimentConditionType) *
 
 // GetCondition returns condition of given conditionType
 func (s *ExperimentStatus) GetCondition(condition ExperimentConditionType) *ExperimentCondition {
+	if c := s.findCondition(condition); c != nil {
+		return c
+	}
+
+	return s.addCondition(condition)
+}
+
+// findCondition returns condition of given conditionType; nil if the condition is not set
+func (s *ExperimentStatus) findCondition(condition ExperimentConditionType) *ExperimentCondition {
 	for _, c := range s.Conditions {
-		if c.Type == condition {
+		if c != nil && c.Type == condition {
 			return c
 		}
 	}
+	return nil
+}
 
-	return s.addCondition(condition)
+// GetReason returns the reason of the condition; empty if not set
+func (c *ExperimentCondition) GetReason() string {
+	if c.Reason == nil {
+		return ""
+	}
+	return *c.Reason
+}
+
+// GetMessage returns the message of the condition; empty if not set
+func (c *ExperimentCondition) GetMessage() string {
+	if c.Message == nil {
+		return ""
+	}
+	return *c.Message
 }
 
 // IsTrue tells whether the experiment condition is true or not
@@ -83,9 +125,11 @@ func (e *Experiment) InitStatus() {
 		}
 	}
 
+	e.Status.ObservedGeneration = e.Generation
+
 	// sets relevant unset conditions to Unknown state.
 	for _, c := range experimentCondSet {
-		e.Status.addCondition(c)
+		e.Status.GetCondition(c)
 	}
 
 	currentTime := metav1.Now()
@@ -154,17 +198,84 @@ func (spec *ExperimentSpec) effectiveHosts() []string {
 	return hosts
 }
 
-func (c *ExperimentCondition) markCondition(status corev1.ConditionStatus, reason, messageFormat string, messageA ...interface{}) bool {
-	message := fmt.Sprintf(messageFormat, messageA...)
-	updated := status != c.Status || reason != *c.Reason || message != *c.Message
+// mark sets the condition; the transition time is only updated when the status changes
+// Return true if the status, reason or message changes
+func (c *ExperimentCondition) mark(status corev1.ConditionStatus, generation int64, reason, message string) bool {
+	updated := status != c.Status || reason != c.GetReason() || message != c.GetMessage()
+	if status != c.Status || c.LastTransitionTime == nil {
+		now := metav1.Now()
+		c.LastTransitionTime = &now
+	}
 	c.Status = status
+	c.ObservedGeneration = generation
 	c.Reason = &reason
 	c.Message = &message
-	now := metav1.Now()
-	c.LastTransitionTime = &now
 	return updated
 }
 
+// markCondition sets the condition of given conditionType and updates the Ready condition
+// Return true if the status, reason or message of the condition changes
+func (s *ExperimentStatus) markCondition(conditionType ExperimentConditionType, status corev1.ConditionStatus,
+	reason, messageFormat string, messageA ...interface{}) bool {
+	message := fmt.Sprintf(messageFormat, messageA...)
+	updated := s.GetCondition(conditionType).mark(status, s.ObservedGeneration, reason, message)
+	s.updateReady()
+	return updated
+}
+
+// ObserveGeneration records the generation of the experiment reflected by the status,
+// which is the observedGeneration of conditions set afterwards
+// The Ready condition keeps its observedGeneration until the conditions it summarizes are set again
+// Return true if the generation changes
+func (s *ExperimentStatus) ObserveGeneration(generation int64) bool {
+	if s.ObservedGeneration == generation {
+		return false
+	}
+	s.ObservedGeneration = generation
+	return true
+}
+
+// updateReady summarizes the other conditions into the Ready condition
+// It is False if any of the other conditions is False, Unknown if targets are not found or routing rules are not ready yet,
+// and True otherwise
+// Its observedGeneration is the oldest one of the conditions deciding its status, so it only reflects a new generation
+// once those conditions have been set for it
+func (s *ExperimentStatus) updateReady() {
+	ready := s.GetCondition(ExperimentConditionReady)
+	for _, t := range append(readyRequired, readyDegraded...) {
+		if c := s.findCondition(t); c != nil && c.IsFalse() {
+			reason := c.GetReason()
+			if reason == "" {
+				reason = ReasonExperimentNotReady
+			}
+			ready.mark(corev1.ConditionFalse, c.ObservedGeneration, reason, c.GetMessage())
+			return
+		}
+	}
+	generation := s.ObservedGeneration
+	for _, t := range readyRequired {
+		c := s.findCondition(t)
+		if c == nil {
+			ready.mark(corev1.ConditionUnknown, ready.ObservedGeneration, ReasonExperimentNotReady, fmt.Sprintf("Waiting for %s", t))
+			return
+		}
+		if !c.IsTrue() {
+			ready.mark(corev1.ConditionUnknown, c.ObservedGeneration, ReasonExperimentNotReady, fmt.Sprintf("Waiting for %s", t))
+			return
+		}
+		if c.ObservedGeneration < generation {
+			generation = c.ObservedGeneration
+		}
+	}
+	ready.mark(corev1.ConditionTrue, generation, ReasonExperimentReady, "")
+}
+
+// Ready returns whether status of ExperimentConditionReady is true or not
+func (s *ExperimentStatus) Ready() bool {
+	c := s.findCondition(ExperimentConditionReady)
+	return c != nil && c.IsTrue()
+}
+
 // MetricsSynced returns whether status of ExperimentConditionMetricsSynced is true or not
 func (s *ExperimentStatus) MetricsSynced() bool {
 	return s.GetCondition(ExperimentConditionMetricsSynced).Status == corev1.ConditionTrue
@@ -174,8 +285,7 @@ func (s *ExperimentStatus) MetricsSynced() bool {
 // Return true if it's converted from false or unknown
 func (s *ExperimentStatus) MarkMetricsSynced(messageFormat string, messageA ...interface{}) (bool, string) {
 	reason := ReasonSyncMetricsSucceeded
-	return s.GetCondition(ExperimentConditionMetricsSynced).
-		markCondition(corev1.ConditionTrue, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionMetricsSynced, corev1.ConditionTrue, reason, messageFormat, messageA...), reason
 }
 
 // MarkMetricsSyncedError sets the condition that the error occurs when syncing with the config map
@@ -185,8 +295,7 @@ func (s *ExperimentStatus) MarkMetricsSyncedError(messageFormat string, messageA
 	s.Phase = PhasePause
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Message = &message
-	return s.GetCondition(ExperimentConditionMetricsSynced).
-		markCondition(corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionMetricsSynced, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
 }
 
 // TargetsFound returns whether status of ExperimentConditionTargetsProvided is true or not
@@ -203,8 +312,7 @@ func (s *ExperimentStatus) RoutingRulesReady() bool {
 // Return true if it's converted from false or unknown
 func (s *ExperimentStatus) MarkTargetsFound(messageFormat string, messageA ...interface{}) (bool, string) {
 	reason := ReasonTargetsFound
-	return s.GetCondition(ExperimentConditionTargetsProvided).
-		markCondition(corev1.ConditionTrue, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionTargetsProvided, corev1.ConditionTrue, reason, messageFormat, messageA...), reason
 }
 
 // MarkTargetsError sets the condition that there is error in finding all targets
@@ -214,8 +322,67 @@ func (s *ExperimentStatus) MarkTargetsError(messageFormat string, messageA ...in
 	s.Phase = PhasePause
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Message = &message
-	return s.GetCondition(ExperimentConditionTargetsProvided).
-		markCondition(corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionTargetsProvided, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+}
+
+// TargetsReady returns whether status of ExperimentConditionTargetsReady is true or not
+func (s *ExperimentStatus) TargetsReady() bool {
+	return s.GetCondition(ExperimentConditionTargetsReady).Status == corev1.ConditionTrue
+}
+
+// MarkTargetsReady sets the condition that all candidates are available to receive traffic
+// Return true if it's converted from false or unknown
+func (s *ExperimentStatus) MarkTargetsReady(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonTargetsReady
+	return s.markCondition(ExperimentConditionTargetsReady, corev1.ConditionTrue, reason, messageFormat, messageA...), reason
+}
+
+// MarkTargetsNotReady sets the condition that some candidates are not available to receive traffic
+// Return true if it's converted from true or unknown
+func (s *ExperimentStatus) MarkTargetsNotReady(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonTargetsNotReady
+	message := composeMessage(reason, messageFormat, messageA...)
+	s.Message = &message
+	return s.markCondition(ExperimentConditionTargetsReady, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+}
+
+// MarkCandidateCrashLoop sets the condition that a candidate receiving traffic is crashlooping
+// Return true if it's converted from true or unknown
+func (s *ExperimentStatus) MarkCandidateCrashLoop(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonCandidateCrashLoop
+	message := composeMessage(reason, messageFormat, messageA...)
+	s.Message = &message
+	return s.markCondition(ExperimentConditionTargetsReady, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+}
+
+// MarkTargetsChanged sets the condition that targets are changed during the experiment
+// Return true if the condition is updated
+func (s *ExperimentStatus) MarkTargetsChanged(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonTargetsChanged
+	message := composeMessage(reason, messageFormat, messageA...)
+	s.Message = &message
+	return s.markCondition(ExperimentConditionTargetsUnchanged, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+}
+
+// RestartAssessment discards the assessment of all versions and sends all traffic back to baseline
+func (s *ExperimentStatus) RestartAssessment() {
+	s.AnalysisState = &runtime.RawExtension{Raw: []byte("{}")}
+	s.Assessment.Winner = nil
+	s.Assessment.Baseline.Weight = 100
+	s.Assessment.Baseline.VersionAssessment = v1alpha2.VersionAssessment{
+		CriterionAssessments: make([]v1alpha2.CriterionAssessment, 0),
+	}
+	for i := range s.Assessment.Candidates {
+		s.Assessment.Candidates[i].Weight = 0
+		s.Assessment.Candidates[i].Rollback = false
+		s.Assessment.Candidates[i].VersionAssessment = v1alpha2.VersionAssessment{
+			CriterionAssessments: make([]v1alpha2.CriterionAssessment, 0),
+		}
+	}
+	// traffic of the current step of the schedule is shifted again
+	s.CurrentStep = nil
+	// run the next iteration right away
+	s.LastUpdateTime = nil
 }
 
 // MarkRoutingRulesReady sets the condition that the routing rules are ready
@@ -224,8 +391,7 @@ func (s *ExperimentStatus) MarkRoutingRulesReady(messageFormat string, messageA
 	reason := ReasonRoutingRulesReady
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Message = &message
-	return s.GetCondition(ExperimentConditionRoutingRulesReady).
-		markCondition(corev1.ConditionTrue, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionRoutingRulesReady, corev1.ConditionTrue, reason, messageFormat, messageA...), reason
 }
 
 // MarkRoutingRulesError sets the condition that the routing rules are not ready
@@ -235,16 +401,14 @@ func (s *ExperimentStatus) MarkRoutingRulesError(messageFormat string, messageA
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Phase = PhasePause
 	s.Message = &message
-	return s.GetCondition(ExperimentConditionRoutingRulesReady).
-		markCondition(corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionRoutingRulesReady, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
 }
 
 // MarkAnalyticsServiceRunning sets the condition that the analytics service is operating normally
 // Return true if it's converted from false or unknown
 func (s *ExperimentStatus) MarkAnalyticsServiceRunning(messageFormat string, messageA ...interface{}) (bool, string) {
 	reason := ReasonAnalyticsServiceRunning
-	return s.GetCondition(ExperimentConditionAnalyticsServiceNormal).
-		markCondition(corev1.ConditionTrue, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionAnalyticsServiceNormal, corev1.ConditionTrue, reason, messageFormat, messageA...), reason
 }
 
 // MarkAnalyticsServiceError sets the condition that the analytics service breaks down
@@ -254,8 +418,7 @@ func (s *ExperimentStatus) MarkAnalyticsServiceError(messageFormat string, messa
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Message = &message
 	s.Phase = PhasePause
-	return s.GetCondition(ExperimentConditionAnalyticsServiceNormal).
-		markCondition(corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionAnalyticsServiceNormal, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
 }
 
 // ExperimentCompleted returns whether experiment is completed or not
@@ -269,8 +432,7 @@ func (s *ExperimentStatus) MarkExperimentCompleted(messageFormat string, message
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Phase = PhaseCompleted
 	s.Message = &message
-	return s.GetCondition(ExperimentConditionExperimentCompleted).
-		markCondition(corev1.ConditionTrue, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionTrue, reason, messageFormat, messageA...), reason
 }
 
 // MarkIterationUpdate sets the condition that the iteration updated
@@ -281,8 +443,7 @@ func (s *ExperimentStatus) MarkIterationUpdate(messageFormat string, messageA ..
 	s.Message = &message
 	now := metav1.Now()
 	s.LastUpdateTime = &now
-	return s.GetCondition(ExperimentConditionExperimentCompleted).
-		markCondition(corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
 }
 
 // MarkAssessmentUpdate sets the condition that assessment for experiment updated
@@ -291,8 +452,7 @@ func (s *ExperimentStatus) MarkAssessmentUpdate(messageFormat string, messageA .
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Phase = PhaseProgressing
 	s.Message = &message
-	return s.GetCondition(ExperimentConditionExperimentCompleted).
-		markCondition(corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
 }
 
 // MarkTrafficUpdate sets the condition that traffic to targets has beeen changed
@@ -301,8 +461,7 @@ func (s *ExperimentStatus) MarkTrafficUpdate(messageFormat string, messageA ...i
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Phase = PhaseProgressing
 	s.Message = &message
-	return s.GetCondition(ExperimentConditionExperimentCompleted).
-		markCondition(corev1.ConditionFalse, reason, messageFormat, messageA...), reason
+	return s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...), reason
 }
 
 // MarkExperimentPause sets the phase and status that experiment is paused by manualOverrides
@@ -312,8 +471,7 @@ func (s *ExperimentStatus) MarkExperimentPause(messageFormat string, messageA ..
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Phase = PhasePause
 	s.Message = &message
-	s.GetCondition(ExperimentConditionExperimentCompleted).
-		markCondition(corev1.ConditionFalse, reason, messageFormat, messageA...)
+	s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...)
 	return true, reason
 }
 
@@ -324,11 +482,288 @@ func (s *ExperimentStatus) MarkExperimentResume(messageFormat string, messageA .
 	message := composeMessage(reason, messageFormat, messageA...)
 	s.Phase = PhaseProgressing
 	s.Message = &message
-	s.GetCondition(ExperimentConditionExperimentCompleted).
-		markCondition(corev1.ConditionFalse, reason, messageFormat, messageA...)
+	s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...)
+	return true, reason
+}
+
+// MarkExperimentQueued sets the phase and status that experiment is waiting for other experiments to release its router or targets
+// returns true if it's converted from other phase
+func (s *ExperimentStatus) MarkExperimentQueued(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonExperimentQueued
+	message := composeMessage(reason, messageFormat, messageA...)
+	updated := s.Phase != PhaseQueued
+	s.Phase = PhaseQueued
+	s.Message = &message
+	s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...)
+	return updated, reason
+}
+
+// MarkExperimentDequeued sets the phase and status that experiment acquires its router and targets after being queued
+// returns true if it's converted from queued phase
+func (s *ExperimentStatus) MarkExperimentDequeued(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonExperimentDequeued
+	if s.Phase != PhaseQueued {
+		return false, reason
+	}
+	message := composeMessage(reason, messageFormat, messageA...)
+	s.Phase = PhaseProgressing
+	s.Message = &message
+	s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...)
+	return true, reason
+}
+
+// GetApprovalGate returns the status of the approval gate with the name; nil if the gate is not reached
+func (s *ExperimentStatus) GetApprovalGate(name string) *ApprovalGateStatus {
+	for i := range s.ApprovalGates {
+		if s.ApprovalGates[i].Name == name {
+			return &s.ApprovalGates[i]
+		}
+	}
+	return nil
+}
+
+// PendingApprovalGate returns the status of the approval gate the experiment is waiting at; nil if not waiting
+func (s *ExperimentStatus) PendingApprovalGate() *ApprovalGateStatus {
+	for i := range s.ApprovalGates {
+		if s.ApprovalGates[i].State == ApprovalPending {
+			return &s.ApprovalGates[i]
+		}
+	}
+	return nil
+}
+
+// MarkAwaitingApproval sets the phase and status that experiment is waiting for approval at the gate
+// returns true if it's converted from other phase
+func (s *ExperimentStatus) MarkAwaitingApproval(gate string, messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonAwaitingApproval
+	if s.GetApprovalGate(gate) == nil {
+		now := metav1.Now()
+		s.ApprovalGates = append(s.ApprovalGates, ApprovalGateStatus{
+			Name:        gate,
+			State:       ApprovalPending,
+			ReachedTime: &now,
+		})
+	}
+	message := composeMessage(reason, messageFormat, messageA...)
+	updated := s.Phase != PhaseAwaitingApproval
+	s.Phase = PhaseAwaitingApproval
+	s.Message = &message
+	s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...)
+	return updated, reason
+}
+
+// MarkApprovalGranted sets the phase and status that the pending approval gate is approved
+// returns true if there is a pending gate
+func (s *ExperimentStatus) MarkApprovalGranted(by string, messageFormat string, messageA ...interface{}) (bool, string) {
+	return s.decideApproval(ApprovalApproved, ReasonApprovalGranted, by, messageFormat, messageA...)
+}
+
+// MarkApprovalRejected sets the status that the pending approval gate is not approved in time
+// returns true if there is a pending gate
+func (s *ExperimentStatus) MarkApprovalRejected(by string, messageFormat string, messageA ...interface{}) (bool, string) {
+	return s.decideApproval(ApprovalRejected, ReasonApprovalRejected, by, messageFormat, messageA...)
+}
+
+func (s *ExperimentStatus) decideApproval(state ApprovalState, reason, by string, messageFormat string, messageA ...interface{}) (bool, string) {
+	gate := s.PendingApprovalGate()
+	if gate == nil {
+		return false, reason
+	}
+	now := metav1.Now()
+	gate.State = state
+	gate.DecisionTime = &now
+	gate.DecidedBy = &by
+
+	message := composeMessage(reason, messageFormat, messageA...)
+	s.Phase = PhaseProgressing
+	s.Message = &message
+	s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...)
+	return true, reason
+}
+
+// GetHookStatus returns the status of the latest run of the hook; nil if the hook never ran
+func (s *ExperimentStatus) GetHookStatus(name string) *HookStatus {
+	for i := range s.Hooks {
+		if s.Hooks[i].Name == name {
+			return &s.Hooks[i]
+		}
+	}
+	return nil
+}
+
+// StartHook records a new run of the hook, replacing the previous one
+func (s *ExperimentStatus) StartHook(name string, phase HookPhase) *HookStatus {
+	now := metav1.Now()
+	status := HookStatus{
+		Name:      name,
+		Phase:     phase,
+		Iteration: *s.CurrentIteration,
+		State:     HookRunning,
+		StartTime: &now,
+	}
+	if hs := s.GetHookStatus(name); hs != nil {
+		*hs = status
+		return hs
+	}
+	s.Hooks = append(s.Hooks, status)
+	return &s.Hooks[len(s.Hooks)-1]
+}
+
+// MarkHookSucceeded sets the status that the running hook succeeded
+// returns true if the hook is running
+func (s *ExperimentStatus) MarkHookSucceeded(name string, messageFormat string, messageA ...interface{}) (bool, string) {
+	return s.completeHook(name, HookSucceeded, ReasonHookSucceeded, messageFormat, messageA...)
+}
+
+// MarkHookFailed sets the status that the running hook failed
+// returns true if the hook is running
+func (s *ExperimentStatus) MarkHookFailed(name string, messageFormat string, messageA ...interface{}) (bool, string) {
+	updated, reason := s.completeHook(name, HookFailed, ReasonHookFailed, messageFormat, messageA...)
+	if updated {
+		message := composeMessage(reason, messageFormat, messageA...)
+		s.Message = &message
+		s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...)
+	}
+	return updated, reason
+}
+
+func (s *ExperimentStatus) completeHook(name string, state HookState, reason string, messageFormat string, messageA ...interface{}) (bool, string) {
+	hs := s.GetHookStatus(name)
+	if hs == nil || hs.State != HookRunning {
+		return false, reason
+	}
+	now := metav1.Now()
+	message := fmt.Sprintf(messageFormat, messageA...)
+	hs.State = state
+	hs.CompletionTime = &now
+	hs.Message = &message
+	return true, reason
+}
+
+// MarkStepUpdate records the step of the schedule traffic is shifted to,
+// along with the restarts of candidates when it starts
+// returns true if the step is changed
+func (s *ExperimentStatus) MarkStepUpdate(index int32, restarts map[string]int32, messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonStepUpdate
+	if s.CurrentStep != nil && s.CurrentStep.Index == index {
+		return false, reason
+	}
+	now := metav1.Now()
+	s.CurrentStep = &StepStatus{Index: index, StartTime: &now, Restarts: restarts}
+	message := composeMessage(reason, messageFormat, messageA...)
+	s.Message = &message
+	return true, reason
+}
+
+// MarkHealthCheckFailed sets the status that candidates are unhealthy before moving to the next step
+func (s *ExperimentStatus) MarkHealthCheckFailed(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonHealthCheckFailed
+	message := composeMessage(reason, messageFormat, messageA...)
+	s.Message = &message
+	s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...)
+	return true, reason
+}
+
+// MarkGuardrailBreached sets the status that a guardrail of the experiment is breached
+func (s *ExperimentStatus) MarkGuardrailBreached(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonGuardrailBreached
+	message := composeMessage(reason, messageFormat, messageA...)
+	s.Message = &message
+	s.markCondition(ExperimentConditionExperimentCompleted, corev1.ConditionFalse, reason, messageFormat, messageA...)
+	return true, reason
+}
+
+// MarkGuardrailError sets the status that guardrails can't be evaluated
+// returns true if the message is changed
+func (s *ExperimentStatus) MarkGuardrailError(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonGuardrailError
+	message := composeMessage(reason, messageFormat, messageA...)
+	updated := s.Message == nil || *s.Message != message
+	s.Message = &message
+	return updated, reason
+}
+
+// MarkLoadStarted records the Job generating load for the experiment
+// returns true if the Job is newly recorded
+func (s *ExperimentStatus) MarkLoadStarted(job string, messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonLoadStarted
+	if s.Load != nil && s.Load.Job == job {
+		return false, reason
+	}
+	now := metav1.Now()
+	s.Load = &LoadStatus{Job: job, StartTime: &now}
+	message := composeMessage(reason, messageFormat, messageA...)
+	s.Message = &message
+	return true, reason
+}
+
+// MarkLoadStopped records that the Job generating load is deleted
+// returns true if the load was running
+func (s *ExperimentStatus) MarkLoadStopped() bool {
+	if s.Load == nil || s.Load.StopTime != nil {
+		return false
+	}
+	now := metav1.Now()
+	s.Load.StopTime = &now
+	return true
+}
+
+// MarkLoadError sets the status that the Job generating load can't be created
+// returns true if the message is changed
+func (s *ExperimentStatus) MarkLoadError(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonLoadError
+	message := composeMessage(reason, messageFormat, messageA...)
+	updated := s.Message == nil || *s.Message != message
+	s.Message = &message
+	return updated, reason
+}
+
+// MarkReportWritten records the report written as the experiment completes
+// returns true if the report is newly recorded
+func (s *ExperimentStatus) MarkReportWritten(ref ReportRef, messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonReportWritten
+	if s.ReportRef != nil {
+		return false, reason
+	}
+	s.ReportRef = &ref
 	return true, reason
 }
 
+// MarkReportError counts a failed attempt to write the report
+// returns true if no attempt is left, in which case writing the report is given up
+func (s *ExperimentStatus) MarkReportError(messageFormat string, messageA ...interface{}) (bool, string) {
+	reason := ReasonReportError
+	s.ReportAttempts++
+	return s.ReportGivenUp(), reason
+}
+
+// ReportGivenUp returns true if writing the report is given up after failed attempts
+func (s *ExperimentStatus) ReportGivenUp() bool {
+	return s.ReportRef == nil && s.ReportAttempts >= MaxReportAttempts
+}
+
+// RecordTrafficSplit appends the current traffic split to the traffic history if it is changed
+// returns true if the split is recorded
+func (s *ExperimentStatus) RecordTrafficSplit() bool {
+	if s.Assessment == nil {
+		return false
+	}
+	weights := map[string]int32{s.Assessment.Baseline.Name: s.Assessment.Baseline.Weight}
+	for _, c := range s.Assessment.Candidates {
+		weights[c.Name] = c.Weight
+	}
+	if n := len(s.TrafficHistory); n > 0 && reflect.DeepEqual(s.TrafficHistory[n-1].Weights, weights) {
+		return false
+	}
+
+	split := TrafficSplit{Time: metav1.Now(), Weights: weights}
+	if s.CurrentIteration != nil {
+		split.Iteration = *s.CurrentIteration
+	}
+	s.TrafficHistory = append(s.TrafficHistory, split)
+	return true
+}
+
 // IsWinnerFound tells whether winner has been found by analytics
 func (s *ExperimentStatus) IsWinnerFound() bool {
 	return s.Assessment != nil && s.Assessment.Winner != nil &&
@@ -0,0 +1,99 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMarkCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// conditions loaded without reason and message
+	past := metav1.NewTime(time.Now().Add(-time.Minute))
+	s := &ExperimentStatus{
+		ObservedGeneration: 2,
+		Conditions: Conditions{
+			&ExperimentCondition{Type: ExperimentConditionTargetsProvided, Status: corev1.ConditionTrue, LastTransitionTime: &past},
+		},
+	}
+
+	updated, _ := s.MarkTargetsFound("")
+	g.Expect(updated).To(gomega.BeTrue())
+	c := s.GetCondition(ExperimentConditionTargetsProvided)
+	g.Expect(c.GetReason()).To(gomega.Equal(ReasonTargetsFound))
+	g.Expect(c.ObservedGeneration).To(gomega.Equal(int64(2)))
+	g.Expect(c.LastTransitionTime).To(gomega.Equal(&past))
+
+	updated, _ = s.MarkTargetsFound("")
+	g.Expect(updated).To(gomega.BeFalse())
+
+	updated, _ = s.MarkTargetsError("missing %s", "reviews-v3")
+	g.Expect(updated).To(gomega.BeTrue())
+	g.Expect(c.GetMessage()).To(gomega.Equal("missing reviews-v3"))
+	g.Expect(c.LastTransitionTime.After(past.Time)).To(gomega.BeTrue())
+}
+
+func TestReadyCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	e := &Experiment{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+	e.InitStatus()
+	g.Expect(e.Status.ObservedGeneration).To(gomega.Equal(int64(1)))
+	g.Expect(e.Status.GetCondition(ExperimentConditionReady).Status).To(gomega.Equal(corev1.ConditionUnknown))
+
+	e.Status.MarkTargetsFound("")
+	e.Status.MarkRoutingRulesReady("")
+	g.Expect(e.Status.Ready()).To(gomega.BeTrue())
+
+	e.Status.MarkAnalyticsServiceError("analytics unreachable")
+	ready := e.Status.GetCondition(ExperimentConditionReady)
+	g.Expect(ready.Status).To(gomega.Equal(corev1.ConditionFalse))
+	g.Expect(ready.GetReason()).To(gomega.Equal(ReasonAnalyticsServiceError))
+	g.Expect(ready.GetMessage()).To(gomega.Equal("analytics unreachable"))
+
+	e.Status.MarkAnalyticsServiceRunning("")
+	g.Expect(e.Status.Ready()).To(gomega.BeTrue())
+
+	// the summary reflects the latest generation once the conditions it summarizes are set again
+	g.Expect(e.Status.ObserveGeneration(2)).To(gomega.BeTrue())
+	g.Expect(e.Status.ObserveGeneration(2)).To(gomega.BeFalse())
+	g.Expect(ready.ObservedGeneration).To(gomega.Equal(int64(1)))
+	e.Status.MarkTargetsFound("")
+	g.Expect(ready.ObservedGeneration).To(gomega.Equal(int64(1)))
+	e.Status.MarkRoutingRulesReady("")
+	g.Expect(ready.ObservedGeneration).To(gomega.Equal(int64(2)))
+	g.Expect(e.Status.Ready()).To(gomega.BeTrue())
+
+	// a failure set for the new generation is reflected right away
+	g.Expect(e.Status.ObserveGeneration(3)).To(gomega.BeTrue())
+	e.Status.MarkTargetsError("baseline deleted")
+	g.Expect(ready.Status).To(gomega.Equal(corev1.ConditionFalse))
+	g.Expect(ready.ObservedGeneration).To(gomega.Equal(int64(3)))
+
+	count := 0
+	for _, c := range e.Status.Conditions {
+		if c.Type == ExperimentConditionReady {
+			count++
+		}
+	}
+	g.Expect(count).To(gomega.Equal(1))
+}
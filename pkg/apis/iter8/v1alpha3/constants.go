@@ -0,0 +1,220 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+// OnTerminationType provides options for onTermination
+type OnTerminationType string
+
+const (
+	// OnTerminationToWinner indicates all traffic should go to winner candidate when experiment is terminated
+	OnTerminationToWinner OnTerminationType = "to_winner"
+
+	// OnTerminationToBaseline indicates all traffic should go to baseline when experiment is terminated
+	OnTerminationToBaseline OnTerminationType = "to_baseline"
+
+	// OnTerminationKeepLast keeps the last traffic status when experiment is terminated
+	OnTerminationKeepLast OnTerminationType = "keep_last"
+)
+
+// ProtocolType provides options for protocol of routes used in experiment
+type ProtocolType string
+
+const (
+	// ProtocolHTTP indicates traffic is shifted by http routes, which also serve gRPC
+	ProtocolHTTP ProtocolType = "http"
+
+	// ProtocolTCP indicates traffic is shifted by tcp routes
+	ProtocolTCP ProtocolType = "tcp"
+
+	// ProtocolTLS indicates traffic is shifted by tls routes
+	ProtocolTLS ProtocolType = "tls"
+)
+
+// StrategyType provides options for strategy used in experiment
+type StrategyType string
+
+const (
+	// StrategyProgressive is the progressive strategy
+	StrategyProgressive StrategyType = "progressive"
+
+	// StrategyTop2 is the top_2 strategy
+	StrategyTop2 StrategyType = "top_2"
+
+	// StrategyUniform is the uniform strategy
+	StrategyUniform StrategyType = "uniform"
+)
+
+// OnConflictType provides options for handling an experiment whose router or targets are held by another experiment
+type OnConflictType string
+
+const (
+	// OnConflictFail indicates the experiment fails if its router or targets are held by another experiment
+	OnConflictFail OnConflictType = "fail"
+
+	// OnConflictQueue indicates the experiment waits until its router and targets are released by other experiments
+	OnConflictQueue OnConflictType = "queue"
+)
+
+// OnTargetChangeType provides options for reacting to changes of targets during the experiment
+type OnTargetChangeType string
+
+const (
+	// OnTargetChangeRestart restarts the assessment of the experiment with traffic sent back to baseline
+	OnTargetChangeRestart OnTargetChangeType = "restart"
+
+	// OnTargetChangePause pauses the experiment
+	OnTargetChangePause OnTargetChangeType = "pause"
+
+	// OnTargetChangeAbort terminates the experiment with traffic sent back to baseline
+	OnTargetChangeAbort OnTargetChangeType = "abort"
+)
+
+// ActionType provides options for override actions
+type ActionType string
+
+const (
+	// ActionPause is an action to pause the experiment
+	ActionPause ActionType = "pause"
+
+	// ActionResume is an action to resume the experiment
+	ActionResume ActionType = "resume"
+
+	// ActionTerminate is an action to terminate the experiment
+	ActionTerminate ActionType = "terminate"
+
+	// ActionApprove is an action to approve the approval gate the experiment is waiting at
+	ActionApprove ActionType = "approve"
+)
+
+// ApprovalState is the state of an approval gate reached by the experiment
+type ApprovalState string
+
+const (
+	// ApprovalPending indicates the experiment is waiting for approval at the gate
+	ApprovalPending ApprovalState = "Pending"
+
+	// ApprovalApproved indicates the gate is approved
+	ApprovalApproved ApprovalState = "Approved"
+
+	// ApprovalRejected indicates the gate is not approved before timeout
+	ApprovalRejected ApprovalState = "Rejected"
+)
+
+// HookPhase is the lifecycle point of the experiment when a hook runs
+type HookPhase string
+
+const (
+	// HookPreTraffic runs before any traffic is sent to candidates
+	HookPreTraffic HookPhase = "preTraffic"
+
+	// HookPostTrafficUpdate runs after each update of traffic split
+	HookPostTrafficUpdate HookPhase = "postTrafficUpdate"
+
+	// HookPreCompletion runs after all iterations, before the experiment completes
+	HookPreCompletion HookPhase = "preCompletion"
+)
+
+// HookFailurePolicy determines how the experiment reacts to a failure of a hook
+type HookFailurePolicy string
+
+const (
+	// HookFailureRollback terminates the experiment sending all traffic to baseline
+	HookFailureRollback HookFailurePolicy = "rollback"
+
+	// HookFailurePause pauses the experiment
+	HookFailurePause HookFailurePolicy = "pause"
+)
+
+// HealthCheckFailurePolicy determines how the experiment reacts to unhealthy candidates at a step of the schedule
+type HealthCheckFailurePolicy string
+
+const (
+	// HealthCheckFailureRollback terminates the experiment sending all traffic to baseline
+	HealthCheckFailureRollback HealthCheckFailurePolicy = "rollback"
+
+	// HealthCheckFailurePause pauses the experiment
+	HealthCheckFailurePause HealthCheckFailurePolicy = "pause"
+)
+
+// MetricProviderType is the backend a metric is queried from by analytics
+// Analytics only queries prometheus
+type MetricProviderType string
+
+const (
+	// MetricProviderPrometheus queries PromQL templates against prometheus
+	MetricProviderPrometheus MetricProviderType = "prometheus"
+)
+
+// HookState is the state of a run of a hook
+type HookState string
+
+const (
+	// HookRunning indicates the hook is running
+	HookRunning HookState = "Running"
+
+	// HookSucceeded indicates the hook succeeded
+	HookSucceeded HookState = "Succeeded"
+
+	// HookFailed indicates the hook failed or timed out
+	HookFailed HookState = "Failed"
+)
+
+// ExperimentConditionType limits conditions can be set by controller
+type ExperimentConditionType string
+
+const (
+	// ExperimentConditionTargetsProvided has status True when the Experiment detects all elements specified in targetService
+	ExperimentConditionTargetsProvided ExperimentConditionType = "TargetsProvided"
+
+	// ExperimentConditionAnalyticsServiceNormal has status True when the analytics service is operating normally
+	ExperimentConditionAnalyticsServiceNormal ExperimentConditionType = "AnalyticsServiceNormal"
+
+	// ExperimentConditionMetricsSynced has status True when metrics are successfully synced with config map
+	ExperimentConditionMetricsSynced ExperimentConditionType = "MetricsSynced"
+
+	// ExperimentConditionExperimentCompleted has status True when the experiment is completed
+	ExperimentConditionExperimentCompleted ExperimentConditionType = "ExperimentCompleted"
+
+	// ExperimentConditionRoutingRulesReady has status True when routing rules are ready
+	ExperimentConditionRoutingRulesReady ExperimentConditionType = "RoutingRulesReady"
+
+	// ExperimentConditionTargetsReady has status True when all candidates are available to receive traffic
+	ExperimentConditionTargetsReady ExperimentConditionType = "TargetsReady"
+
+	// ExperimentConditionTargetsUnchanged has status False when targets are changed during the experiment
+	ExperimentConditionTargetsUnchanged ExperimentConditionType = "TargetsUnchanged"
+
+	// ExperimentConditionReady summarizes the other conditions; it has status True when targets are found
+	// and routing rules are ready, and none of the other conditions is False
+	ExperimentConditionReady ExperimentConditionType = "Ready"
+)
+
+// PhaseType has options for phases that an experiment can be at
+type PhaseType string
+
+const (
+	// PhasePause indicates experiment is paused
+	PhasePause PhaseType = "Pause"
+
+	// PhaseProgressing indicates experiment is progressing
+	PhaseProgressing PhaseType = "Progressing"
+
+	// PhaseCompleted indicates experiment has competed (successfully or not)
+	PhaseCompleted PhaseType = "Completed"
+
+	// PhaseQueued indicates experiment is waiting for its router or targets to be released by other experiments
+	PhaseQueued PhaseType = "Queued"
+
+	// PhaseAwaitingApproval indicates experiment is waiting for manual approval at an approval gate
+	PhaseAwaitingApproval PhaseType = "AwaitingApproval"
+)
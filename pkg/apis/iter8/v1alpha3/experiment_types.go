@@ -0,0 +1,928 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Experiment contains the sections for --
+// defining an experiment,
+// showing experiment status,
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:categories=all,iter8
+// +kubebuilder:printcolumn:name="type",type="string",JSONPath=".status.experimentType",description="Type of experiment",format="byte"
+// +kubebuilder:printcolumn:name="hosts",type="string",JSONPath=".status.effectiveHosts",description="Names of candidates",format="byte"
+// +kubebuilder:printcolumn:name="phase",type="string",JSONPath=".status.phase",description="Phase of the experiment",format="byte"
+// +kubebuilder:printcolumn:name="winner found",type="boolean",JSONPath=".status.assessment.winner.winnerFound",description="Winner identified",format="byte"
+// +kubebuilder:printcolumn:name="current best",type="string",JSONPath=".status.assessment.winner.name",description="Current best version",format="byte"
+// +kubebuilder:printcolumn:name="confidence",priority=1,type="string",JSONPath=".status.assessment.winner.probability",description="Confidence current bets version will be the winner",format="float"
+// +kubebuilder:printcolumn:name="status",type="string",JSONPath=".status.message",description="Detailed Status of the experiment",format="byte"
+// +kubebuilder:printcolumn:name="baseline",priority=1,type="string",JSONPath=".spec.service.baseline",description="Name of baseline",format="byte"
+// +kubebuilder:printcolumn:name="candidates",priority=1,type="string",JSONPath=".spec.service.candidates",description="Names of candidates",format="byte"
+type Experiment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ExperimentSpec `json:"spec"`
+	// +optional
+	Status ExperimentStatus `json:"status,omitempty"`
+}
+
+// ExperimentList contains a list of Experiment
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ExperimentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Experiment `json:"items"`
+}
+
+// ExperimentSpec defines the desired state of Experiment
+type ExperimentSpec struct {
+	// Service is a reference to the service componenets that this experiment is targeting at
+	Service `json:"service"`
+
+	// Criteria contains a list of Criterion for assessing the target service
+	// Noted that at most one reward metric is allowed
+	// If more than one reward criterion is included, the first would be used while others would be omitted
+	// +optional
+	Criteria []Criterion `json:"criteria,omitempty"`
+
+	// TrafficControl provides instructions on traffic management for an experiment
+	// +optional
+	TrafficControl *TrafficControl `json:"trafficControl,omitempty"`
+
+	// Endpoint of reaching analytics service
+	// default is http://iter8-analytics:8080
+	// +optional
+	AnalyticsEndpoint *string `json:"analyticsEndpoint,omitempty"`
+
+	// Duration specifies how often/many times the expriment should re-evaluate the assessment
+	// +optional
+	Duration *Duration `json:"duration,omitempty"`
+
+	// Cleanup indicates whether routing rules and deployment receiving no traffic should be deleted at the end of experiment
+	// +optional
+	Cleanup *bool `json:"cleanup,omitempty"`
+
+	// The metrics used in the experiment
+	// +optional
+	Metrics *Metrics `json:"metrics,omitempty"`
+
+	// User actions to override the current status of the experiment
+	// +optional
+	ManualOverride *ManualOverride `json:"manualOverride,omitempty"`
+
+	// Networking describes how traffic network should be configured for the experiment
+	// +optional
+	Networking *Networking `json:"networking,omitempty"`
+
+	// Notifications lists notification channels subscribed to the experiment
+	// +optional
+	Notifications []NotificationSubscription `json:"notifications,omitempty"`
+
+	// ApprovalGates lists milestones at which the experiment waits for manual approval
+	// +optional
+	ApprovalGates []ApprovalGate `json:"approvalGates,omitempty"`
+
+	// Hooks lists checks run at lifecycle points of the experiment
+	// +optional
+	Hooks []Hook `json:"hooks,omitempty"`
+
+	// OnTargetChange determines how the experiment reacts when the pod template, selector or replicas of its targets
+	// are changed during the experiment
+	// default is pause
+	// +kubebuilder:validation:Enum={restart,pause,abort}
+	// +optional
+	OnTargetChange *OnTargetChangeType `json:"onTargetChange,omitempty"`
+
+	// Load is the load generated on the service during the experiment, such as for performance experiments
+	// +optional
+	Load *Load `json:"load,omitempty"`
+
+	// Guardrails lists prometheus queries evaluated by the controller in every iteration
+	// A breach of any guardrail rolls back the experiment regardless of the assessment of analytics
+	// +optional
+	Guardrails []Guardrail `json:"guardrails,omitempty"`
+
+	// VersionLabels determines the labels identifying versions in prometheus metrics and guardrails
+	// default is the labels of istio telemetry identifying the workload or service
+	// +optional
+	VersionLabels *VersionLabels `json:"versionLabels,omitempty"`
+
+	// Report is written when the experiment completes, for records of the change
+	// +optional
+	Report *Report `json:"report,omitempty"`
+}
+
+// NotificationSubscription describes a notification channel subscribed to the experiment
+type NotificationSubscription struct {
+	// Name of the subscription, unique in the experiment
+	Name string `json:"name"`
+
+	// Notifier is the type of the notification receiver
+	// +kubebuilder:validation:Enum={slack,webhook,teams,pagerduty}
+	Notifier string `json:"notifier"`
+
+	// SecretRef references a Secret in the namespace of the experiment holding the endpoint of the notifier
+	// The url is stored in key url; the routing key of PagerDuty is stored in key routingKey
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+
+	// Level specifies the informative level; default is normal
+	// +kubebuilder:validation:Enum={error,warning,normal,verbose}
+	// +optional
+	Level *string `json:"level,omitempty"`
+
+	// Template is the Go template of notification message; default template of the notifier is used if not specified
+	// +optional
+	Template *string `json:"template,omitempty"`
+}
+
+// ApprovalGate is a milestone of the experiment which is only passed after manual approval
+// Exactly one of weight, iteration and promotion should be specified
+type ApprovalGate struct {
+	// Name of the gate, unique in the experiment
+	Name string `json:"name"`
+
+	// Weight is the traffic percentage that no candidate can exceed before the gate is approved
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// Iteration is the number of completed iterations after which the experiment waits for approval
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Iteration *int32 `json:"iteration,omitempty"`
+
+	// Promotion indicates the experiment waits for approval before it completes
+	// +optional
+	Promotion *bool `json:"promotion,omitempty"`
+
+	// Timeout is how long to wait for approval once the gate is reached
+	// The experiment is terminated if the gate is not approved in time; wait forever if not specified
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// Hook is a check run at a lifecycle point of the experiment, as a Job or an HTTP call
+// Exactly one of job and http should be specified
+type Hook struct {
+	// Name of the hook, unique in the experiment
+	Name string `json:"name"`
+
+	// Phase is the lifecycle point when the hook runs
+	// +kubebuilder:validation:Enum={preTraffic,postTrafficUpdate,preCompletion}
+	Phase HookPhase `json:"phase"`
+
+	// Job is the spec (batch/v1 JobSpec) of the Job run in the namespace of the experiment
+	// The hook succeeds if the Job completes
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Job *runtime.RawExtension `json:"job,omitempty"`
+
+	// HTTP is the endpoint called by the hook
+	// +optional
+	HTTP *HTTPHook `json:"http,omitempty"`
+
+	// OnFailure determines how the experiment reacts to a failure of the hook
+	// default is rollback
+	// +kubebuilder:validation:Enum={rollback,pause}
+	// +optional
+	OnFailure *HookFailurePolicy `json:"onFailure,omitempty"`
+
+	// Timeout is the time allowed for the hook to complete
+	// default is 10s for http hooks and 10m for job hooks
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// HTTPHook describes an HTTP endpoint called by a hook
+// The hook succeeds if the endpoint responds to the POST request with a 2xx status code
+type HTTPHook struct {
+	// URL of the endpoint
+	URL string `json:"url"`
+
+	// Headers added to the request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Load describes requests sent by a Job running fortio in the namespace of the experiment
+// Requests are sent to url if specified, otherwise to host, otherwise to the service of the experiment
+type Load struct {
+	// URL the requests are sent to
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// Host the requests are sent to over http, such as an external host of networking
+	// +optional
+	Host *string `json:"host,omitempty"`
+
+	// QPS is the number of requests per second
+	// default is 8
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	QPS *int32 `json:"qps,omitempty"`
+
+	// Duration is how long the load is generated, such as 10m
+	// default is interval multiplied by maxIterations
+	// +optional
+	Duration *string `json:"duration,omitempty"`
+
+	// Headers added to the requests
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Payload is the body of the requests, which are sent as POST if specified
+	// +optional
+	Payload *string `json:"payload,omitempty"`
+
+	// Image of fortio run by the Job
+	// default is fortio/fortio:1.11.4
+	// +optional
+	Image *string `json:"image,omitempty"`
+}
+
+// Report describes where the report of the experiment is written when it completes
+// The report is written to a ConfigMap in the namespace of the experiment unless url is specified
+type Report struct {
+	// ConfigMap is the name of the ConfigMap holding report.json and report.md
+	// default is the name of the experiment suffixed by -report
+	// +optional
+	ConfigMap *string `json:"configMap,omitempty"`
+
+	// URL of an object store, such as a bucket of an S3-compatible store, the report is uploaded to
+	// by PUT requests to <url>/<namespace>/<name>/report.json and report.md
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// SecretRef references a Secret in the namespace of the experiment whose keys and values are headers
+	// added to the requests uploading the report, such as Authorization
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// VersionLabels are the keys and values of labels identifying versions in metrics
+type VersionLabels struct {
+	// Template maps keys of labels to their values, in which $name is replaced by the name of the version
+	// and $namespace by the namespace of the service, such as {destination_app: reviews, destination_version: $name}
+	// default is the labels of the provider of metrics
+	// +optional
+	Template map[string]string `json:"template,omitempty"`
+
+	// Versions lists values of labels of versions overriding the template
+	// Only labels in the template are overridden
+	// +optional
+	Versions []VersionLabelValues `json:"versions,omitempty"`
+}
+
+// VersionLabelValues are values of labels identifying a version, such as {destination_version: v2} of reviews-v2
+type VersionLabelValues struct {
+	// Name of the version
+	Name string `json:"name"`
+
+	// Labels maps keys of labels to their values
+	Labels map[string]string `json:"labels"`
+}
+
+// Guardrail is a prometheus query evaluated for each candidate, whose value must stay within max and min
+// The query is templated as metrics of iter8config-metrics:
+// $interval is replaced by the time elapsed since the experiment started,
+// $version_labels by the labels identifying the version
+// Guardrails are not evaluated for a candidate until the query returns a value for it
+type Guardrail struct {
+	// Name of the guardrail
+	Name string `json:"name"`
+
+	// Query is the PromQL query, such as
+	// histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket[$interval])) by (le, $version_labels))
+	Query string `json:"query"`
+
+	// Max is the maximum value of the query, breached if exceeded
+	// +optional
+	Max *resource.Quantity `json:"max,omitempty"`
+
+	// Min is the minimum value of the query, breached if not reached
+	// +optional
+	Min *resource.Quantity `json:"min,omitempty"`
+}
+
+// Service is a reference to the service that this experiment is targeting at
+type Service struct {
+	// Kind of the baseline and candidates, Deployment or Service
+	// default is Deployment
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// APIVersion of the baseline and candidates
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Name of the service
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the service; default is the namespace of the experiment
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the baseline
+	Baseline string `json:"baseline"`
+
+	// List of names of candidates
+	Candidates []string `json:"candidates"`
+
+	// Port number exposed by internal services
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// Host holds the name of host and gateway associated with it
+type Host struct {
+	// Name of the Host
+	Name string `json:"name"`
+
+	// The gateway associated with the host
+	Gateway string `json:"gateway"`
+}
+
+// Criterion defines the criterion for assessing a target
+type Criterion struct {
+	// Name of metric used in the assessment
+	Metric string `json:"metric"`
+
+	// Threshold specifies the numerical value for a success criterion
+	// Metric value above threhsold violates the criterion
+	// +optional
+	Threshold *Threshold `json:"threshold,omitempty"`
+
+	// IsReward indicates whether the metric is a reward metric or not
+	// +optional
+	IsReward *bool `json:"isReward,omitempty"`
+}
+
+// Threshold defines the value and type of a criterion threshold
+type Threshold struct {
+	// Type of threshold
+	// relative: value of threshold specifies the relative amount of changes
+	// absolute: value of threshold indicates an absolute value
+	//+kubebuilder:validation:Enum={relative,absolute}
+	Type string `json:"type"`
+
+	// Value of threshold
+	Value resource.Quantity `json:"value"`
+
+	// Once a target metric violates this threshold, traffic to the target should be cutoff or not
+	// +optional
+	CutoffTrafficOnViolation *bool `json:"cutoffTrafficOnViolation,omitempty"`
+}
+
+// Duration specifies how often/many times the expriment should re-evaluate the assessment
+type Duration struct {
+	// Interval specifies duration between iterations
+	// default is 30s
+	// +optional
+	Interval *string `json:"interval,omitempty"`
+	// MaxIterations indicates the amount of iteration
+	// default is 100
+	// +optional
+	MaxIterations *int32 `json:"maxIterations,omitempty"`
+}
+
+// TrafficControl specifies constrains on traffic and stratgy used to update the traffic
+type TrafficControl struct {
+	// Strategy used to shift traffic
+	// default is progressive
+	// +kubebuilder:validation:Enum={progressive, top_2, uniform}
+	// +optional
+	Strategy *StrategyType `json:"strategy,omitempty"`
+
+	// OnTermination determines traffic split status at the end of experiment
+	// +kubebuilder:validation:Enum={to_winner,to_baseline,keep_last}
+	// +optional
+	OnTermination *OnTerminationType `json:"onTermination,omitempty"`
+
+	// Protocol of the routes used to shift traffic
+	// default is tcp if tcp match is specified, tls if tls match is specified, and http otherwise
+	// +kubebuilder:validation:Enum={http,tcp,tls}
+	// +optional
+	Protocol *ProtocolType `json:"protocol,omitempty"`
+
+	// Only requests fulfill the match section would be used in experiment
+	// Istio matching rules are used
+	// +optional
+	Match *Match `json:"match,omitempty"`
+
+	// Percentage specifies the amount of traffic to service that would be used in experiment
+	// default is 100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// MaxIncrement is the upperlimit of traffic increment for a target in one iteration
+	// default is 2
+	// +optional
+	MaxIncrement *int32 `json:"maxIncrement,omitempty"`
+
+	// RouterID refers to the id of router used to handle traffic for the experiment
+	// If it's not specified, the first entry of effictive host will be used as the id
+	// +optional
+	RouterID *string `json:"routerID,omitempty"`
+
+	// Steps is the schedule of traffic sent to candidates in experiments without criteria
+	// It replaces increments of maxIncrement, and determines the number of iterations instead of maxIterations
+	// +optional
+	Steps []Step `json:"steps,omitempty"`
+
+	// HealthCheck gates each step of the schedule on the health of candidates
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+}
+
+// Step is a traffic split held for a while in the step schedule
+type Step struct {
+	// Weight is the percentage of traffic sent to candidates, split evenly among them
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// Pause is how long the traffic split is held before the next step, such as 5m
+	// It is rounded up to a multiple of interval
+	// default is interval
+	// +optional
+	Pause *string `json:"pause,omitempty"`
+}
+
+// HealthCheck describes checks of the pods of candidates run before moving to the next step of the schedule
+type HealthCheck struct {
+	// RequireReady requires all replicas of each candidate to be ready
+	// default is true
+	// +optional
+	RequireReady *bool `json:"requireReady,omitempty"`
+
+	// MaxRestarts is the maximum number of container restarts in the pods of each candidate during a step
+	// default is 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+
+	// OnFailure determines how the experiment reacts to unhealthy candidates
+	// default is rollback
+	// +kubebuilder:validation:Enum={rollback,pause}
+	// +optional
+	OnFailure *HealthCheckFailurePolicy `json:"onFailure,omitempty"`
+}
+
+// Match contains matching criteria for requests
+type Match struct {
+	// Matching criteria for HTTP requests
+	// +optional
+	HTTP []*HTTPMatchRequest `json:"http,omitempty"`
+
+	// Matching criteria for gRPC requests, applied as HTTP matches
+	// +optional
+	GRPC []*GRPCMatchRequest `json:"grpc,omitempty"`
+
+	// Matching criteria for TCP connections
+	// +optional
+	TCP []*L4MatchAttributes `json:"tcp,omitempty"`
+
+	// Matching criteria for TLS connections
+	// +optional
+	TLS []*TLSMatchAttributes `json:"tls,omitempty"`
+}
+
+// ManualOverride defines actions that the user can perform to an experiment
+type ManualOverride struct {
+	// Action to perform
+	//+kubebuilder:validation:Enum={pause,resume,terminate,approve}
+	Action ActionType `json:"action"`
+	// Traffic split status specification
+	// Applied to action terminate only
+	// example:
+	//   reviews-v2:80
+	//   reviews-v3:20
+	// +optional
+	TrafficSplit map[string]int32 `json:"trafficSplit,omitempty"`
+}
+
+// Networking describes how traffic network should be configured for the experiment
+type Networking struct {
+	// id of router
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// List of hosts used to receive external traffic
+	// +optional
+	Hosts []Host `json:"hosts,omitempty"`
+
+	// OnConflict determines how to handle the experiment if its router or targets are held by another experiment
+	// default is fail
+	// +kubebuilder:validation:Enum={fail,queue}
+	// +optional
+	OnConflict *OnConflictType `json:"onConflict,omitempty"`
+
+	// InheritTrafficPolicy indicates whether candidate subsets should inherit the trafficPolicy of baseline subset
+	// default is false
+	// +optional
+	InheritTrafficPolicy *bool `json:"inheritTrafficPolicy,omitempty"`
+}
+
+// Metrics contains definitions for metrics used in the experiment
+type Metrics struct {
+	// List of counter metrics definiton
+	// +optional
+	CounterMetrics []CounterMetric `json:"counterMetrics,omitempty"`
+
+	// List of ratio metrics definiton
+	// +optional
+	RatioMetrics []RatioMetric `json:"ratioMetrics,omitempty"`
+}
+
+// CounterMetric is the definition of Counter Metric
+type CounterMetric struct {
+	// Name of metric
+	Name string `json:"name"`
+
+	// Query template of this metric
+	QueryTemplate string `json:"queryTemplate"`
+
+	// Preferred direction of the metric value
+	// +optional
+	PreferredDirection *string `json:"preferredDirection,omitempty"`
+
+	// Unit of the metric value
+	// +optional
+	Unit *string `json:"unit,omitempty"`
+
+	// Provider the metric is queried from, which determines the syntax of the query template
+	// and the labels identifying versions
+	// prometheus is the only provider queried by analytics, and the default
+	// +kubebuilder:validation:Enum={prometheus}
+	// +optional
+	Provider *MetricProviderType `json:"provider,omitempty"`
+}
+
+// RatioMetric is the definiton of Ratio Metric
+type RatioMetric struct {
+	// name of metric
+	Name string `json:"name"`
+
+	// Counter metric used in numerator
+	Numerator string `json:"numerator"`
+
+	// Counter metric used in denominator
+	Denominator string `json:"denominator"`
+
+	// Boolean flag indicating if the value of this metric is always in the range 0 to 1
+	// +optional
+	ZeroToOne *bool `json:"zeroToOne,omitempty"`
+
+	// Preferred direction of the metric value
+	// +optional
+	PreferredDirection *string `json:"preferredDirection,omitempty"`
+}
+
+// ExperimentStatus defines the observed state of Experiment
+type ExperimentStatus struct {
+	// List of conditions
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the experiment reflected by the status
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// InitTimestamp is the timestamp when the experiment is initialized
+	// +optional
+	InitTimestamp *metav1.Time `json:"initTimestamp,omitempty"`
+
+	// StartTimestamp is the timestamp when the experiment starts
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// EndTimestamp is the timestamp when experiment completes
+	// +optional
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+
+	// LastUpdateTime is the last time iteration has been updated
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// CurrentIteration is the current iteration number
+	// +optional
+	CurrentIteration *int32 `json:"currentIteration,omitempty"`
+
+	// Assessment returned by the last analyis
+	// +optional
+	Assessment *Assessment `json:"assessment,omitempty"`
+
+	// Phase marks the Phase the experiment is at
+	// +optional
+	Phase PhaseType `json:"phase,omitempty"`
+
+	// Message specifies message to show in the kubectl printer
+	// +optional
+	Message *string `json:"message,omitempty"`
+
+	// AnalysisState is the last recorded analysis state
+	// +optional
+	AnalysisState *runtime.RawExtension `json:"analysisState,omitempty"`
+
+	// ExperimentType is type of experiment
+	ExperimentType string `json:"experimentType,omitempty"`
+
+	// ApprovalGates records the approval gates reached by the experiment
+	// +optional
+	ApprovalGates []ApprovalGateStatus `json:"approvalGates,omitempty"`
+
+	// Hooks records the result of the latest run of each hook
+	// +optional
+	Hooks []HookStatus `json:"hooks,omitempty"`
+
+	// Load records the Job generating load for the experiment
+	// +optional
+	Load *LoadStatus `json:"load,omitempty"`
+
+	// CurrentStep records the current step of the schedule
+	// +optional
+	CurrentStep *StepStatus `json:"currentStep,omitempty"`
+
+	// TrafficHistory records the traffic split among versions after each change, if report is specified
+	// +optional
+	TrafficHistory []TrafficSplit `json:"trafficHistory,omitempty"`
+
+	// ReportRef refers to the report written when the experiment completed
+	// +optional
+	ReportRef *ReportRef `json:"reportRef,omitempty"`
+
+	// ReportAttempts counts the failed attempts to write the report, which is given up after 5 attempts
+	// +optional
+	ReportAttempts int32 `json:"reportAttempts,omitempty"`
+
+	// EffectiveHosts is computed host for experiment.
+	// List of spec.Service.Name and spec.Service.Hosts[0].name
+	EffectiveHosts []string `json:"effectiveHosts,omitempty"`
+}
+
+// ApprovalGateStatus records the state of an approval gate reached by the experiment
+type ApprovalGateStatus struct {
+	// Name of the gate
+	Name string `json:"name"`
+
+	// State of the approval
+	State ApprovalState `json:"state"`
+
+	// ReachedTime is the time when the gate is reached
+	// +optional
+	ReachedTime *metav1.Time `json:"reachedTime,omitempty"`
+
+	// DecisionTime is the time when the gate is approved or rejected
+	// +optional
+	DecisionTime *metav1.Time `json:"decisionTime,omitempty"`
+
+	// DecidedBy records how the gate is approved or rejected
+	// +optional
+	DecidedBy *string `json:"decidedBy,omitempty"`
+}
+
+// HookStatus records the result of a run of a hook
+type HookStatus struct {
+	// Name of the hook
+	Name string `json:"name"`
+
+	// Phase is the lifecycle point when the hook ran
+	Phase HookPhase `json:"phase"`
+
+	// Iteration is the iteration when the hook ran
+	Iteration int32 `json:"iteration"`
+
+	// State of the run
+	State HookState `json:"state"`
+
+	// Job is the name of the Job run by the hook
+	// +optional
+	Job *string `json:"job,omitempty"`
+
+	// Message describes the result of the run
+	// +optional
+	Message *string `json:"message,omitempty"`
+
+	// StartTime is the time when the run started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the run completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// StepStatus records the current step of the schedule
+type StepStatus struct {
+	// Index of the step in the schedule
+	Index int32 `json:"index"`
+
+	// StartTime is the time when traffic is shifted to the step
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Restarts is the number of container restarts in the pods of each candidate when the step started
+	// +optional
+	Restarts map[string]int32 `json:"restarts,omitempty"`
+}
+
+// TrafficSplit is the traffic split among versions from the iteration
+type TrafficSplit struct {
+	// Iteration from which the split is applied
+	Iteration int32 `json:"iteration"`
+
+	// Time when the split is applied
+	Time metav1.Time `json:"time"`
+
+	// Weights maps names of versions to their percentage of traffic
+	Weights map[string]int32 `json:"weights"`
+}
+
+// ReportRef refers to the report of the experiment
+type ReportRef struct {
+	// ConfigMap is the name of the ConfigMap holding the report, in the namespace of the experiment
+	// +optional
+	ConfigMap *string `json:"configMap,omitempty"`
+
+	// URL of report.json uploaded to the object store; report.md is uploaded next to it
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// Time when the report is written
+	Time metav1.Time `json:"time"`
+}
+
+// LoadStatus records the Job generating load for the experiment
+type LoadStatus struct {
+	// Job is the name of the Job generating the load
+	Job string `json:"job"`
+
+	// StartTime is the time when the Job is created
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// StopTime is the time when the Job is deleted as the experiment completes
+	// +optional
+	StopTime *metav1.Time `json:"stopTime,omitempty"`
+}
+
+// Conditions is a list of ExperimentConditions
+type Conditions []*ExperimentCondition
+
+// ExperimentCondition describes a condition of an experiment
+type ExperimentCondition struct {
+	// Type of the condition
+	Type ExperimentConditionType `json:"type"`
+
+	// Status of the condition
+	Status corev1.ConditionStatus `json:"status"`
+
+	// The time when the status of this condition last changed
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// ObservedGeneration is the generation of the experiment when this condition was last set
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Reason for the last update
+	// +optional
+	Reason *string `json:"reason,omitempty"`
+
+	// Detailed explanation on the update
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
+
+// Assessment details for the each target
+type Assessment struct {
+	// Assessment details of baseline
+	Baseline VersionAssessment `json:"baseline"`
+
+	// Assessment details of each candidate
+	Candidates []VersionAssessment `json:"candidates"`
+
+	// Assessment for winner target if exists
+	// +optional
+	Winner *WinnerAssessment `json:"winner,omitempty"`
+}
+
+// WinnerAssessment shows assessment details for winner of an experiment
+type WinnerAssessment struct {
+	// name of winner version
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// WinnerFound indicates whether or not a clear winner has emerged
+	WinnerFound bool `json:"winnerFound"`
+
+	// CurrentBestVersion is the id of the version with the maximum probability of winning
+	// +optional
+	CurrentBestVersion string `json:"currentBestVersion,omitempty"`
+
+	// Probability is the posterior probability of the current best version being the winner
+	// +optional
+	Probability float32 `json:"probability,omitempty"`
+}
+
+// VersionAssessment contains assessment details for each version
+type VersionAssessment struct {
+	// name of version
+	Name string `json:"name"`
+
+	// Weight of traffic
+	Weight int32 `json:"weight"`
+
+	// ID of the version in analytics
+	ID string `json:"id"`
+
+	// WinProbability is the probability of the version being the winner
+	WinProbability float32 `json:"winProbability"`
+
+	// RequestCount is the number of requests received by the version
+	RequestCount int32 `json:"requestCount"`
+
+	// CriterionAssessments contains assessment of each criterion
+	// +optional
+	CriterionAssessments []CriterionAssessment `json:"criterionAssessments,omitempty"`
+
+	// A flag indicates whether traffic to this target should be cutoff
+	// +optional
+	Rollback bool `json:"rollback,omitempty"`
+}
+
+// CriterionAssessment contains assessment of a criterion for a version
+type CriterionAssessment struct {
+	// ID of version
+	ID string `json:"id"`
+
+	// ID of metric
+	MetricID string `json:"metricID"`
+
+	// Statistics for this metric
+	// +optional
+	Statistics *Statistics `json:"statistics,omitempty"`
+
+	// Assessment of how well this metric is doing with respect to threshold
+	// Defined only for metrics with a threshold
+	// +optional
+	ThresholdAssessment *ThresholdAssessment `json:"thresholdAssessment,omitempty"`
+}
+
+// Statistics for a metric
+type Statistics struct {
+	// Value of a counter metric
+	// +optional
+	Value *float32 `json:"value,omitempty"`
+
+	// Statistics of a ratio metric
+	// +optional
+	RatioStatistics *RatioStatistics `json:"ratioStatistics,omitempty"`
+}
+
+// RatioStatistics is statistics for a ratio metric
+type RatioStatistics struct {
+	ImprovementOverBaseline       Interval `json:"improvementOverBaseline"`
+	ProbabilityOfBeatingBaseline  float32  `json:"probabilityOfBeatingBaseline"`
+	ProbabilityOfBeingBestVersion float32  `json:"probabilityOfBeingBestVersion"`
+	CredibleInterval              Interval `json:"credibleInterval"`
+}
+
+// Interval for probability
+type Interval struct {
+	Lower float32 `json:"lower"`
+	Upper float32 `json:"upper"`
+}
+
+// ThresholdAssessment is the assessment for a threshold
+type ThresholdAssessment struct {
+	// A flag indicating whether threshold is breached
+	ThresholdBreached bool `json:"thresholdBreached"`
+
+	// Probability of satisfying the threshold
+	// Defined only for ratio metrics
+	ProbabilityOfSatisfyingThreshold float32 `json:"probabilityOfSatisfyingThreshold"`
+}
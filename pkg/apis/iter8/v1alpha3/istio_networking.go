@@ -0,0 +1,121 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+// This file contains re-typed HTTPMatchRequest, L4MatchAttributes and TLSMatchAttributes from networking.istio.io/v1alpha3.
+// CRD generator from sigs.k8s.io/controller-tools fails to recognize orginal format.
+
+type HTTPMatchRequest struct {
+	// The name assigned to a match.
+	Name string `json:"name,omitempty"`
+
+	// URI to match
+	URI *StringMatch `json:"uri,omitempty"`
+
+	// Scheme Scheme
+	Scheme *StringMatch `json:"scheme,omitempty"`
+
+	// HTTP Method
+	Method *StringMatch `json:"method,omitempty"`
+
+	// HTTP Authority
+	Authority *StringMatch `json:"authority,omitempty"`
+
+	// Headers to match
+	Headers map[string]StringMatch `json:"headers,omitempty"`
+
+	// Specifies the ports on the host that is being addressed.
+	Port uint32 `json:"port,omitempty"`
+
+	// SourceLabels for matching
+	SourceLabels map[string]string `json:"sourceLabels,omitempty"`
+
+	// Gateways for matching
+	Gateways []string `json:"gateways,omitempty"`
+
+	// Query parameters for matching.
+	QueryParams map[string]StringMatch `json:"queryParams,omitempty"`
+
+	// Flag to specify whether the URI matching should be case-insensitive.
+	IgnoreURICase bool `json:"ignoreUriCase,omitempty"`
+
+	// Headers which must not be present in the request
+	WithoutHeaders map[string]StringMatch `json:"withoutHeaders,omitempty"`
+
+	// Source namespace for matching
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+}
+
+// GRPCMatchRequest specifies gRPC requests to match; it is applied as an HTTP match
+// on the request path /<service>/<method> and the gRPC content-type
+type GRPCMatchRequest struct {
+	// Fully qualified name of the gRPC service, e.g. helloworld.Greeter
+	Service string `json:"service"`
+
+	// Name of the gRPC method; all methods of the service are matched if not specified
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Headers (gRPC metadata) to match
+	// +optional
+	Headers map[string]StringMatch `json:"headers,omitempty"`
+}
+
+// L4MatchAttributes specifies TCP connections to match
+type L4MatchAttributes struct {
+	// IPv4 or IPv6 ip addresses of destination with optional subnet.
+	DestinationSubnets []string `json:"destinationSubnets,omitempty"`
+
+	// Specifies the port on the host that is being addressed.
+	Port uint32 `json:"port,omitempty"`
+
+	// IPv4 or IPv6 ip address of source with optional subnet.
+	SourceSubnet string `json:"sourceSubnet,omitempty"`
+
+	// SourceLabels for matching
+	SourceLabels map[string]string `json:"sourceLabels,omitempty"`
+
+	// Gateways for matching
+	Gateways []string `json:"gateways,omitempty"`
+
+	// Source namespace for matching
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+}
+
+// TLSMatchAttributes specifies TLS connections to match
+type TLSMatchAttributes struct {
+	// SNI (server name indicator) to match on.
+	SniHosts []string `json:"sniHosts"`
+
+	// IPv4 or IPv6 ip addresses of destination with optional subnet.
+	DestinationSubnets []string `json:"destinationSubnets,omitempty"`
+
+	// Specifies the port on the host that is being addressed.
+	Port uint32 `json:"port,omitempty"`
+
+	// SourceLabels for matching
+	SourceLabels map[string]string `json:"sourceLabels,omitempty"`
+
+	// Gateways for matching
+	Gateways []string `json:"gateways,omitempty"`
+
+	// Source namespace for matching
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+}
+
+// StringMatch specifies how to match a string; exactly one of exact, prefix and regex should be set
+type StringMatch struct {
+	Exact  *string `json:"exact,omitempty"`
+	Prefix *string `json:"prefix,omitempty"`
+	Regex  *string `json:"regex,omitempty"`
+}
@@ -0,0 +1,224 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	// ModeBinary sends event attributes as ce- headers and data as body
+	ModeBinary = "binary"
+	// ModeStructured sends the whole event as a JSON envelope
+	ModeStructured = "structured"
+
+	// environment variables used to configure the emitter
+	envSink    = "CLOUDEVENTS_SINK"
+	envMode    = "CLOUDEVENTS_MODE"
+	envRetries = "CLOUDEVENTS_RETRIES"
+	envSource  = "CLOUDEVENTS_SOURCE"
+
+	defaultRetries    = 3
+	defaultRetryDelay = time.Second
+	defaultTimeout    = 10 * time.Second
+	// number of events buffered before being dropped
+	queueSize = 100
+)
+
+// Config defines how events are delivered
+type Config struct {
+	// Sink is the URL events are sent to; events are not emitted if empty
+	Sink string
+	// Mode is the content mode of HTTP binding, binary or structured
+	Mode string
+	// Retries is the maximum number of retries of a failed delivery
+	Retries int
+	// RetryDelay is the delay before the first retry; it is doubled after each retry
+	RetryDelay time.Duration
+	// Source is the source attribute of events
+	Source string
+}
+
+// ConfigFromEnv reads the config of emitter from environment variables
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Sink:       os.Getenv(envSink),
+		Mode:       os.Getenv(envMode),
+		Retries:    defaultRetries,
+		RetryDelay: defaultRetryDelay,
+		Source:     os.Getenv(envSource),
+	}
+
+	if raw := os.Getenv(envRetries); raw != "" {
+		retries, err := strconv.Atoi(raw)
+		if err != nil || retries < 0 {
+			return cfg, fmt.Errorf("Invalid %s: %s", envRetries, raw)
+		}
+		cfg.Retries = retries
+	}
+
+	return cfg, cfg.validateAndSetDefault()
+}
+
+func (c *Config) validateAndSetDefault() error {
+	switch c.Mode {
+	case ModeBinary, ModeStructured:
+		// valid
+	case "":
+		c.Mode = ModeBinary
+	default:
+		return fmt.Errorf("Unsupported mode: %s", c.Mode)
+	}
+
+	if c.Source == "" {
+		c.Source = DefaultSource
+	}
+	return nil
+}
+
+// Emitter sends CloudEvents to a sink over HTTP
+// Events are delivered in order by a single worker so that the reconcile loop is never blocked
+type Emitter struct {
+	config Config
+	client *http.Client
+	queue  chan *Event
+	logger logr.Logger
+}
+
+// NewEmitter returns a new Emitter; events are not delivered until it is started
+func NewEmitter(cfg Config, logger logr.Logger) *Emitter {
+	return &Emitter{
+		config: cfg,
+		client: &http.Client{Timeout: defaultTimeout},
+		queue:  make(chan *Event, queueSize),
+		logger: logger,
+	}
+}
+
+// Enabled tells whether a sink is configured
+func (e *Emitter) Enabled() bool {
+	return e != nil && e.config.Sink != ""
+}
+
+// Emit queues an event of the transition of the experiment
+func (e *Emitter) Emit(instance *iter8v1alpha2.Experiment, reason string, messageFormat string, messageA ...interface{}) {
+	if !e.Enabled() {
+		return
+	}
+
+	event := NewEvent(e.config.Source, instance, reason, messageFormat, messageA...)
+	select {
+	case e.queue <- event:
+	default:
+		e.logger.Info("CloudEvent dropped, queue full", "type", event.Type, "subject", event.Subject)
+	}
+}
+
+// Start delivers queued events until stop is closed
+// It implements the Runnable interface of controller-runtime manager
+func (e *Emitter) Start(stop <-chan struct{}) error {
+	for {
+		select {
+		case <-stop:
+			return nil
+		case event := <-e.queue:
+			if err := e.deliver(event, stop); err != nil {
+				e.logger.Error(err, "Fail to deliver CloudEvent", "type", event.Type, "subject", event.Subject)
+			}
+		}
+	}
+}
+
+// deliver sends the event, retrying with exponential backoff
+func (e *Emitter) deliver(event *Event, stop <-chan struct{}) (err error) {
+	delay := e.config.RetryDelay
+	for attempt := 0; ; attempt++ {
+		if err = e.send(event); err == nil || attempt >= e.config.Retries {
+			return
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(delay):
+			delay *= 2
+		}
+	}
+}
+
+// send posts the event to the sink following the HTTP protocol binding of CloudEvents
+func (e *Emitter) send(event *Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	req := (*http.Request)(nil)
+	switch e.config.Mode {
+	case ModeStructured:
+		body, err := json.Marshal(map[string]interface{}{
+			"specversion":     SpecVersion,
+			"id":              event.ID,
+			"source":          event.Source,
+			"type":            event.Type,
+			"subject":         event.Subject,
+			"time":            event.Time.Format(time.RFC3339Nano),
+			"datacontenttype": "application/json",
+			"data":            json.RawMessage(data),
+		})
+		if err != nil {
+			return err
+		}
+		if req, err = http.NewRequest(http.MethodPost, e.config.Sink, bytes.NewBuffer(body)); err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/cloudevents+json")
+	default:
+		if req, err = http.NewRequest(http.MethodPost, e.config.Sink, bytes.NewBuffer(data)); err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("ce-specversion", SpecVersion)
+		req.Header.Set("ce-id", event.ID)
+		req.Header.Set("ce-source", event.Source)
+		req.Header.Set("ce-type", event.Type)
+		req.Header.Set("ce-subject", event.Subject)
+		req.Header.Set("ce-time", event.Time.Format(time.RFC3339Nano))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("sink responded %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+type receivedEvent struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver starts a local sink which fails the first failures requests
+func newReceiver(failures int32) (*httptest.Server, chan receivedEvent) {
+	received := make(chan receivedEvent, 10)
+	count := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		received <- receivedEvent{header: r.Header, body: body}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	return server, received
+}
+
+func newTestExperiment() *iter8v1alpha2.Experiment {
+	winner := "reviews-v2"
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default", UID: "1234"},
+	}
+	instance.InitStatus()
+	instance.Status.Assessment = &iter8v1alpha2.Assessment{
+		Baseline:   iter8v1alpha2.VersionAssessment{Name: "reviews-v1", Weight: 20},
+		Candidates: []iter8v1alpha2.VersionAssessment{{Name: "reviews-v2", Weight: 80}},
+		Winner:     &iter8v1alpha2.WinnerAssessment{Name: &winner},
+	}
+	return instance
+}
+
+func startEmitter(cfg Config) (*Emitter, chan struct{}) {
+	cfg.RetryDelay = 10 * time.Millisecond
+	_ = cfg.validateAndSetDefault()
+	e := NewEmitter(cfg, logf.Log)
+	stop := make(chan struct{})
+	go e.Start(stop)
+	return e, stop
+}
+
+func TestEmitBinary(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server, received := newReceiver(0)
+	defer server.Close()
+
+	e, stop := startEmitter(Config{Sink: server.URL})
+	defer close(stop)
+
+	e.Emit(newTestExperiment(), iter8v1alpha2.ReasonTrafficUpdate, "New Traffic, %s", "baseline: 20")
+
+	var event receivedEvent
+	g.Eventually(received).Should(gomega.Receive(&event))
+	g.Expect(event.header.Get("ce-specversion")).To(gomega.Equal(SpecVersion))
+	g.Expect(event.header.Get("ce-type")).To(gomega.Equal("tools.iter8.experiment.trafficupdate"))
+	g.Expect(event.header.Get("ce-source")).To(gomega.Equal(DefaultSource))
+	g.Expect(event.header.Get("ce-subject")).To(gomega.Equal("default/exp"))
+	g.Expect(event.header.Get("ce-id")).NotTo(gomega.BeEmpty())
+
+	data := ExperimentData{}
+	g.Expect(json.Unmarshal(event.body, &data)).To(gomega.Succeed())
+	g.Expect(data.Experiment.UID).To(gomega.Equal("1234"))
+	g.Expect(data.Message).To(gomega.Equal("New Traffic, baseline: 20"))
+	g.Expect(*data.Baseline).To(gomega.Equal(VersionWeight{Name: "reviews-v1", Weight: 20}))
+	g.Expect(data.Candidates).To(gomega.Equal([]VersionWeight{{Name: "reviews-v2", Weight: 80}}))
+	g.Expect(data.Winner.Name).To(gomega.Equal("reviews-v2"))
+}
+
+func TestEmitStructuredWithRetry(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server, received := newReceiver(2)
+	defer server.Close()
+
+	e, stop := startEmitter(Config{Sink: server.URL, Mode: ModeStructured, Retries: 2})
+	defer close(stop)
+
+	e.Emit(newTestExperiment(), iter8v1alpha2.ReasonExperimentCompleted, "")
+
+	var event receivedEvent
+	g.Eventually(received).Should(gomega.Receive(&event))
+	g.Expect(event.header.Get("Content-Type")).To(gomega.Equal("application/cloudevents+json"))
+
+	envelope := map[string]interface{}{}
+	g.Expect(json.Unmarshal(event.body, &envelope)).To(gomega.Succeed())
+	g.Expect(envelope["specversion"]).To(gomega.Equal(SpecVersion))
+	g.Expect(envelope["type"]).To(gomega.Equal("tools.iter8.experiment.experimentcompleted"))
+	g.Expect(envelope["data"]).To(gomega.HaveKeyWithValue("reason", iter8v1alpha2.ReasonExperimentCompleted))
+}
+
+func TestEmitGiveUpAfterRetries(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server, received := newReceiver(2)
+	defer server.Close()
+
+	e, stop := startEmitter(Config{Sink: server.URL, Retries: 1})
+	defer close(stop)
+
+	e.Emit(newTestExperiment(), iter8v1alpha2.ReasonActionPause, "")
+	e.Emit(newTestExperiment(), iter8v1alpha2.ReasonActionResume, "")
+
+	// first event is dropped after 2 failed attempts; second one is delivered
+	var event receivedEvent
+	g.Eventually(received).Should(gomega.Receive(&event))
+	g.Expect(event.header.Get("ce-type")).To(gomega.Equal("tools.iter8.experiment.actionresume"))
+}
+
+func TestEmitterDisabled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var e *Emitter
+	g.Expect(e.Enabled()).To(gomega.BeFalse())
+	e.Emit(newTestExperiment(), iter8v1alpha2.ReasonTargetsFound, "")
+
+	e = NewEmitter(Config{}, logf.Log)
+	g.Expect(e.Enabled()).To(gomega.BeFalse())
+}
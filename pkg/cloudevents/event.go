@@ -0,0 +1,134 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cloudevents emits CloudEvents for lifecycle transitions of experiments
+package cloudevents
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	// SpecVersion is the version of CloudEvents specification followed by the events
+	SpecVersion = "1.0"
+
+	// EventTypePrefix is the prefix of type of all events emitted by iter8
+	// Type of an event is the prefix followed by the lowercased reason of the transition,
+	// e.g., tools.iter8.experiment.targetsfound
+	EventTypePrefix = "tools.iter8.experiment."
+
+	// DefaultSource is the source of events if not specified
+	DefaultSource = "iter8-controller"
+)
+
+// Event is a CloudEvent describing a transition of an experiment
+type Event struct {
+	ID      string
+	Source  string
+	Type    string
+	Subject string
+	Time    time.Time
+	Data    *ExperimentData
+}
+
+// ExperimentReference identifies the experiment of an event
+type ExperimentReference struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	UID        string `json:"uid"`
+	Generation int64  `json:"generation"`
+}
+
+// VersionWeight is the traffic weight of a version
+type VersionWeight struct {
+	Name   string `json:"name"`
+	Weight int32  `json:"weight"`
+}
+
+// Winner is the winner assessment of the experiment
+type Winner struct {
+	Found bool   `json:"found"`
+	Name  string `json:"name,omitempty"`
+}
+
+// ExperimentData is the data of an event
+type ExperimentData struct {
+	Experiment ExperimentReference `json:"experiment"`
+	Reason     string              `json:"reason"`
+	Message    string              `json:"message,omitempty"`
+	Phase      string              `json:"phase,omitempty"`
+	Iteration  *int32              `json:"iteration,omitempty"`
+	Baseline   *VersionWeight      `json:"baseline,omitempty"`
+	Candidates []VersionWeight     `json:"candidates,omitempty"`
+	Winner     *Winner             `json:"winner,omitempty"`
+}
+
+// NewEvent builds the event of a transition of the experiment
+// The data is a snapshot of the experiment when the transition happens
+func NewEvent(source string, instance *iter8v1alpha2.Experiment, reason string, messageFormat string, messageA ...interface{}) *Event {
+	data := &ExperimentData{
+		Experiment: ExperimentReference{
+			Name:       instance.GetName(),
+			Namespace:  instance.GetNamespace(),
+			UID:        string(instance.GetUID()),
+			Generation: instance.GetGeneration(),
+		},
+		Reason:  reason,
+		Message: fmt.Sprintf(messageFormat, messageA...),
+		Phase:   string(instance.Status.Phase),
+	}
+
+	if instance.Status.CurrentIteration != nil {
+		iteration := *instance.Status.CurrentIteration
+		data.Iteration = &iteration
+	}
+
+	if assessment := instance.Status.Assessment; assessment != nil {
+		data.Baseline = &VersionWeight{
+			Name:   assessment.Baseline.Name,
+			Weight: assessment.Baseline.Weight,
+		}
+		for _, candidate := range assessment.Candidates {
+			data.Candidates = append(data.Candidates, VersionWeight{
+				Name:   candidate.Name,
+				Weight: candidate.Weight,
+			})
+		}
+		if assessment.Winner != nil {
+			data.Winner = &Winner{}
+			if assessment.Winner.WinnerAssessment != nil {
+				data.Winner.Found = assessment.Winner.WinnerFound
+			}
+			if assessment.Winner.Name != nil {
+				data.Winner.Name = *assessment.Winner.Name
+			}
+		}
+	}
+
+	return &Event{
+		ID:      string(uuid.NewUUID()),
+		Source:  source,
+		Type:    EventTypePrefix + strings.ToLower(reason),
+		Subject: instance.GetNamespace() + "/" + instance.GetName(),
+		Time:    time.Now().UTC(),
+		Data:    data,
+	}
+}
@@ -0,0 +1,324 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config defines the configuration of iter8 controller, which is read from a config file
+// and overridden by command line flags
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/yaml"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	// LogFormatJSON logs in JSON
+	LogFormatJSON = "json"
+	// LogFormatConsole logs in human readable text
+	LogFormatConsole = "console"
+
+	// DefaultLeaderElectionID is the name of the configmap used as the lock of leader election
+	DefaultLeaderElectionID = "iter8-controller-lock"
+
+	// DefaultPrometheusURL is the endpoint of the prometheus installed with istio
+	DefaultPrometheusURL = "http://prometheus.istio-system:9090"
+
+	// DefaultWebhookServiceName is the name of the service routing to the webhook server
+	DefaultWebhookServiceName = "iter8-controller"
+
+	envPodNamespace  = "POD_NAMESPACE"
+	defaultNamespace = "iter8"
+)
+
+// Config is the configuration of iter8 controller
+type Config struct {
+	// MetricsBindAddress is the address the metric endpoint binds to
+	MetricsBindAddress string `json:"metricsBindAddress,omitempty"`
+
+	// HealthProbeBindAddress is the address the health and readiness probes bind to; disabled if empty
+	// Probes are served at /healthz and /readyz
+	HealthProbeBindAddress string `json:"healthProbeBindAddress,omitempty"`
+
+	// SlackCallbackBindAddress is the address the slack callback endpoint binds to; disabled if empty
+	SlackCallbackBindAddress string `json:"slackCallbackBindAddress,omitempty"`
+
+	// LeaderElection allows more than one replica of the controller to run, only the leader reconciles
+	LeaderElection LeaderElection `json:"leaderElection,omitempty"`
+
+	// MaxConcurrentReconciles is the maximum number of experiments reconciled concurrently
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+
+	// Namespaces restricts the controller to experiments and targets in these namespaces
+	// All namespaces are watched if empty
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Log configures the logger of the controller
+	Log Log `json:"log,omitempty"`
+
+	// Iter8Namespace is the namespace of configmaps of metrics and notifiers
+	// Defaults to the namespace of the controller pod
+	Iter8Namespace string `json:"iter8Namespace,omitempty"`
+
+	// AnalyticsEndpoint is the endpoint of analytics used by experiments not specifying one
+	AnalyticsEndpoint string `json:"analyticsEndpoint,omitempty"`
+
+	// PrometheusURL is the endpoint of prometheus evaluating guardrails of experiments
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+
+	// Webhook configures the webhook server converting experiments between API versions
+	Webhook Webhook `json:"webhook,omitempty"`
+}
+
+// LeaderElection configures leader election among replicas of the controller
+type LeaderElection struct {
+	// Enabled tells whether leader election is used
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ID is the name of the configmap used as the lock
+	ID string `json:"id,omitempty"`
+
+	// Namespace is the namespace of the lock; defaults to iter8Namespace
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Webhook configures the webhook server of the controller
+type Webhook struct {
+	// Port is the port the webhook server binds to
+	Port int `json:"port,omitempty"`
+
+	// CertDir is the directory the serving certificate is written to
+	CertDir string `json:"certDir,omitempty"`
+
+	// ServiceName is the name of the service in iter8Namespace routing to the webhook server
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+// Log configures the logger of the controller
+type Log struct {
+	// Level is the minimum level of logs, one of debug, info and error
+	Level string `json:"level,omitempty"`
+
+	// Format is the format of logs, json or console
+	Format string `json:"format,omitempty"`
+}
+
+// Default returns the config used for values set neither in the config file nor by flags
+func Default() *Config {
+	namespace := defaultNamespace
+	if ns := os.Getenv(envPodNamespace); ns != "" {
+		namespace = ns
+	}
+
+	return &Config{
+		MetricsBindAddress:      ":8080",
+		LeaderElection:          LeaderElection{ID: DefaultLeaderElectionID},
+		MaxConcurrentReconciles: 1,
+		Log:                     Log{Level: "info", Format: LogFormatJSON},
+		Iter8Namespace:          namespace,
+		AnalyticsEndpoint:       iter8v1alpha2.DefaultAnalyticsEndpoint,
+		PrometheusURL:           DefaultPrometheusURL,
+		Webhook: Webhook{
+			Port:        9443,
+			CertDir:     filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"),
+			ServiceName: DefaultWebhookServiceName,
+		},
+	}
+}
+
+// Load reads the config file at path on top of the default config
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if err := cfg.readFile(path); err != nil {
+		return nil, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// Parse reads the config file given by the --config flag; other flags explicitly set take precedence over the file
+func Parse(fs *flag.FlagSet, args []string) (*Config, error) {
+	cfg := Default()
+	var path string
+	fs.StringVar(&path, "config", "", "The path of the controller config file.")
+	fs.StringVar(&cfg.MetricsBindAddress, "metrics-addr", cfg.MetricsBindAddress, "The address the metric endpoint binds to.")
+	fs.StringVar(&cfg.HealthProbeBindAddress, "health-probe-addr", cfg.HealthProbeBindAddress, "The address the health and readiness probes bind to; disabled if empty.")
+	fs.StringVar(&cfg.SlackCallbackBindAddress, "slack-callback-addr", cfg.SlackCallbackBindAddress, "The address the slack callback endpoint binds to; disabled if empty.")
+	fs.BoolVar(&cfg.LeaderElection.Enabled, "enable-leader-election", cfg.LeaderElection.Enabled, "Enable leader election, so that only one replica of the controller is active.")
+	fs.IntVar(&cfg.MaxConcurrentReconciles, "max-concurrent-reconciles", cfg.MaxConcurrentReconciles, "The maximum number of experiments reconciled concurrently.")
+	fs.Var((*namespaces)(&cfg.Namespaces), "namespaces", "Comma separated namespaces watched by the controller; all namespaces are watched if empty.")
+	fs.StringVar(&cfg.Log.Level, "log-level", cfg.Log.Level, "The minimum level of logs, one of debug, info and error.")
+	fs.StringVar(&cfg.Log.Format, "log-format", cfg.Log.Format, "The format of logs, json or console.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// values of flags explicitly set are applied again after the file is read
+	set := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = f.Value.String() })
+	if err := cfg.readFile(path); err != nil {
+		return nil, err
+	}
+	for name, value := range set {
+		if err := fs.Set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, cfg.Validate()
+}
+
+func (c *Config) readFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Fail to read config file: %v", err)
+	}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return fmt.Errorf("Invalid config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// Validate checks the config and fills in values derived from others
+func (c *Config) Validate() error {
+	if c.MaxConcurrentReconciles < 1 {
+		return fmt.Errorf("Invalid maxConcurrentReconciles: %d", c.MaxConcurrentReconciles)
+	}
+	if _, err := c.logLevel(); err != nil {
+		return err
+	}
+	switch c.Log.Format {
+	case LogFormatJSON, LogFormatConsole:
+	default:
+		return fmt.Errorf("Unsupported log format: %s", c.Log.Format)
+	}
+	if c.Iter8Namespace == "" {
+		c.Iter8Namespace = defaultNamespace
+	}
+	if c.LeaderElection.ID == "" {
+		c.LeaderElection.ID = DefaultLeaderElectionID
+	}
+	if c.LeaderElection.Namespace == "" {
+		c.LeaderElection.Namespace = c.Iter8Namespace
+	}
+	if c.AnalyticsEndpoint == "" {
+		c.AnalyticsEndpoint = iter8v1alpha2.DefaultAnalyticsEndpoint
+	}
+	if c.PrometheusURL == "" {
+		c.PrometheusURL = DefaultPrometheusURL
+	}
+	if c.Webhook.Port < 1 || c.Webhook.Port > 65535 {
+		return fmt.Errorf("Invalid webhook port: %d", c.Webhook.Port)
+	}
+	if c.Webhook.CertDir == "" {
+		return fmt.Errorf("Missing webhook certDir")
+	}
+	if c.Webhook.ServiceName == "" {
+		c.Webhook.ServiceName = DefaultWebhookServiceName
+	}
+	return nil
+}
+
+// ManagerOptions returns the options of the controller manager
+func (c *Config) ManagerOptions() manager.Options {
+	options := manager.Options{
+		MetricsBindAddress:      c.MetricsBindAddress,
+		HealthProbeBindAddress:  c.HealthProbeBindAddress,
+		LeaderElection:          c.LeaderElection.Enabled,
+		LeaderElectionID:        c.LeaderElection.ID,
+		LeaderElectionNamespace: c.LeaderElection.Namespace,
+		Port:                    c.Webhook.Port,
+		CertDir:                 c.Webhook.CertDir,
+	}
+
+	// configmaps of metrics and notifiers are read through the cache
+	watched := c.WatchedNamespaces()
+	switch len(watched) {
+	case 0:
+	case 1:
+		options.Namespace = watched[0]
+	default:
+		options.NewCache = cache.MultiNamespacedCacheBuilder(watched)
+	}
+	return options
+}
+
+// WatchedNamespaces returns the namespaces watched by the cache of the controller, including iter8Namespace
+// returns nil if all namespaces are watched
+func (c *Config) WatchedNamespaces() []string {
+	if len(c.Namespaces) == 0 {
+		return nil
+	}
+	watched := append([]string{}, c.Namespaces...)
+	for _, ns := range c.Namespaces {
+		if ns == c.Iter8Namespace {
+			return watched
+		}
+	}
+	return append(watched, c.Iter8Namespace)
+}
+
+// Logger returns the logger configured by the config
+func (c *Config) Logger() logr.Logger {
+	level, _ := c.logLevel()
+	opts := []zap.Opts{zap.Level(level)}
+	if c.Log.Format == LogFormatConsole {
+		opts = append(opts, zap.Encoder(zapcore.NewConsoleEncoder(uberzap.NewProductionEncoderConfig())))
+	}
+	return zap.New(opts...)
+}
+
+func (c *Config) logLevel() (zapcore.Level, error) {
+	switch c.Log.Level {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info", "":
+		return zapcore.InfoLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	}
+	return zapcore.InfoLevel, fmt.Errorf("Unsupported log level: %s", c.Log.Level)
+}
+
+// namespaces is a flag value of comma separated namespaces
+type namespaces []string
+
+func (n *namespaces) String() string {
+	return strings.Join(*n, ",")
+}
+
+func (n *namespaces) Set(value string) error {
+	*n = nil
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			*n = append(*n, ns)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+const testConfig = `
+metricsBindAddress: ":9090"
+healthProbeBindAddress: ":8081"
+leaderElection:
+  enabled: true
+maxConcurrentReconciles: 4
+namespaces: [bookinfo, reviews]
+log:
+  level: debug
+  format: console
+iter8Namespace: iter8-system
+analyticsEndpoint: http://analytics.iter8-system:8080
+prometheusURL: http://prometheus.monitoring:9090
+`
+
+func writeTestConfig(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "iter8-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := Load("")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cfg.MetricsBindAddress).To(gomega.Equal(":8080"))
+	g.Expect(cfg.MaxConcurrentReconciles).To(gomega.Equal(1))
+	g.Expect(cfg.LeaderElection.Namespace).To(gomega.Equal(cfg.Iter8Namespace))
+	g.Expect(cfg.WatchedNamespaces()).To(gomega.BeNil())
+	g.Expect(cfg.PrometheusURL).To(gomega.Equal(DefaultPrometheusURL))
+	g.Expect(cfg.ManagerOptions().Port).To(gomega.Equal(9443))
+
+	cfg, err = Load(writeTestConfig(t, testConfig))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cfg.MetricsBindAddress).To(gomega.Equal(":9090"))
+	g.Expect(cfg.PrometheusURL).To(gomega.Equal("http://prometheus.monitoring:9090"))
+	g.Expect(cfg.LeaderElection).To(gomega.Equal(LeaderElection{
+		Enabled:   true,
+		ID:        DefaultLeaderElectionID,
+		Namespace: "iter8-system",
+	}))
+	g.Expect(cfg.MaxConcurrentReconciles).To(gomega.Equal(4))
+	g.Expect(cfg.WatchedNamespaces()).To(gomega.Equal([]string{"bookinfo", "reviews", "iter8-system"}))
+	g.Expect(cfg.ManagerOptions().NewCache).NotTo(gomega.BeNil())
+
+	_, err = Load(writeTestConfig(t, "maxConcurrentReconciles: 0"))
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, err = Load(writeTestConfig(t, "log:\n  level: trace"))
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, err = Load(writeTestConfig(t, "webhook:\n  port: 0"))
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, err = Load(writeTestConfig(t, "unknownField: true"))
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestParse(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	path := writeTestConfig(t, testConfig)
+
+	// flags explicitly set take precedence over the file
+	cfg, err := Parse(flag.NewFlagSet("test", flag.ContinueOnError), []string{
+		"--config", path,
+		"--max-concurrent-reconciles", "2",
+		"--namespaces", "bookinfo",
+		"--log-format", "json",
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cfg.MetricsBindAddress).To(gomega.Equal(":9090"))
+	g.Expect(cfg.MaxConcurrentReconciles).To(gomega.Equal(2))
+	g.Expect(cfg.Namespaces).To(gomega.Equal([]string{"bookinfo"}))
+	g.Expect(cfg.Log).To(gomega.Equal(Log{Level: "debug", Format: LogFormatJSON}))
+
+	cfg, err = Parse(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--namespaces", "iter8,bookinfo"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	cfg.Iter8Namespace = "iter8"
+	g.Expect(cfg.WatchedNamespaces()).To(gomega.Equal([]string{"iter8", "bookinfo"}))
+}
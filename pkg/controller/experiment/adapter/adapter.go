@@ -17,12 +17,17 @@ package adapter
 
 import (
 	"context"
-	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
 )
 
 // Interface defines the interface for iter8cache
@@ -40,11 +45,21 @@ type Interface interface {
 	MarkDeploymentDeleted(name, namespace string) bool
 	MarkServiceDeleted(name, namespace string) bool
 
+	MarkDeploymentChanged(name, namespace, change string) bool
+	MarkServiceChanged(name, namespace, change string) bool
+
 	Inspect()
+
+	// Start rebuilds the index from experiments in the cluster
+	manager.Runnable
 }
 
 var _ Interface = &Impl{}
 
+// removedTTL is how long removed experiments are not looked up again, which covers the delay of the reader
+// in reflecting that they are completed or being deleted
+const removedTTL = 5 * time.Minute
+
 // Impl is the implementation of Iter8Cache
 type Impl struct {
 	logger logr.Logger
@@ -60,44 +75,68 @@ type Impl struct {
 
 	// a lookup map from target service to experiment
 	service2Experiment map[string]string
+
+	// reader of experiments indexed by TargetIndex; targets missing in the maps are looked up in it
+	reader client.Reader
+
+	// uids of removed experiments mapped to when they were removed, which are not looked up again
+	// even if the reader is not yet updated; entries expire after removedTTL
+	removed map[types.UID]time.Time
 }
 
 // New returns a new iter8cache implementation
-func New(logger logr.Logger) Interface {
+// reader should be a cache where experiments are indexed by TargetIndex; it can be nil
+func New(logger logr.Logger, reader client.Reader) Interface {
 	return &Impl{
 		experimentAbstractStore: make(map[string]*experiment),
 		deployment2Experiment:   make(map[string]string),
 		service2Experiment:      make(map[string]string),
 		logger:                  logger,
+		reader:                  reader,
+		removed:                 make(map[types.UID]time.Time),
 	}
 }
 
-// RegisterExperiment creates new abstracts into the cache and snapshot the abstract into context
-func (c *Impl) RegisterExperiment(ctx context.Context, instance *iter8v1alpha2.Experiment) (context.Context, error) {
+// Start rebuilds the maps from all experiments which are not completed, in order of creation,
+// so that events of targets are mapped to experiments before they are reconciled again
+func (c *Impl) Start(stop <-chan struct{}) error {
+	if c.reader == nil {
+		return nil
+	}
+
+	experiments := &iter8v1alpha2.ExperimentList{}
+	if err := c.reader.List(context.Background(), experiments); err != nil {
+		return err
+	}
+	sort.Slice(experiments.Items, func(i, j int) bool {
+		return experiments.Items[i].CreationTimestamp.Before(&experiments.Items[j].CreationTimestamp)
+	})
+
 	c.m.Lock()
 	defer c.m.Unlock()
-
-	eakey := experimentKey(instance)
-	if _, ok := c.experimentAbstractStore[eakey]; !ok {
-		serviceKeys, err := c.checkAndGetServices(instance)
-		if err != nil {
-			return ctx, err
+	for i := range experiments.Items {
+		instance := &experiments.Items[i]
+		if c.isRemoved(instance) {
+			continue
 		}
-
-		deploymentKeys, err := c.checkAndGetDeployments(instance)
-		if err != nil {
-			return ctx, err
+		if err := c.register(instance); err != nil {
+			c.logger.Info("ExperimentNotRestored", "experiment", experimentKey(instance), "reason", err.Error())
 		}
+	}
 
-		c.experimentAbstractStore[eakey] = newExperiment(serviceKeys, deploymentKeys)
+	c.logger.Info("AdapterRebuilt", "experiments", len(c.experimentAbstractStore))
+	c.Inspect()
+	return nil
+}
 
-		for _, svc := range serviceKeys {
-			c.service2Experiment[svc] = eakey
-		}
+// RegisterExperiment creates new abstracts into the cache and snapshot the abstract into context
+func (c *Impl) RegisterExperiment(ctx context.Context, instance *iter8v1alpha2.Experiment) (context.Context, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
 
-		for _, dep := range deploymentKeys {
-			c.deployment2Experiment[dep] = eakey
-		}
+	eakey := experimentKey(instance)
+	if err := c.register(instance); err != nil {
+		return ctx, err
 	}
 
 	ea := c.experimentAbstractStore[eakey]
@@ -108,6 +147,102 @@ func (c *Impl) RegisterExperiment(ctx context.Context, instance *iter8v1alpha2.E
 	return ctx, nil
 }
 
+// register adds targets of the experiment into the maps
+// Targets of an experiment already registered are replaced if they are changed in its spec
+func (c *Impl) register(instance *iter8v1alpha2.Experiment) error {
+	eakey := experimentKey(instance)
+	serviceKeys, err := c.checkAndGetServices(instance)
+	if err != nil {
+		return err
+	}
+
+	deploymentKeys, err := c.checkAndGetDeployments(instance)
+	if err != nil {
+		return err
+	}
+
+	ea, ok := c.experimentAbstractStore[eakey]
+	if ok && ea.hasTargets(serviceKeys, deploymentKeys) {
+		return nil
+	}
+	if ok {
+		c.unregisterTargets(ea)
+		ea.serviceKeys, ea.deploymentKeys = serviceKeys, deploymentKeys
+	} else {
+		ea = newExperiment(serviceKeys, deploymentKeys)
+		c.experimentAbstractStore[eakey] = ea
+	}
+
+	for _, svc := range serviceKeys {
+		c.service2Experiment[svc] = eakey
+	}
+
+	for _, dep := range deploymentKeys {
+		c.deployment2Experiment[dep] = eakey
+	}
+	return nil
+}
+
+// unregisterTargets removes targets of the experiment from the maps
+func (c *Impl) unregisterTargets(ea *experiment) {
+	for _, key := range ea.serviceKeys {
+		delete(c.service2Experiment, key)
+	}
+
+	for _, key := range ea.deploymentKeys {
+		delete(c.deployment2Experiment, key)
+	}
+}
+
+// isRemoved tells whether the experiment read from the reader is done with its targets:
+// it is completed, being deleted or removed recently
+func (c *Impl) isRemoved(instance *iter8v1alpha2.Experiment) bool {
+	if instance.Status.Phase == iter8v1alpha2.PhaseCompleted || instance.DeletionTimestamp != nil {
+		return true
+	}
+	removed, ok := c.removed[instance.UID]
+	return ok && time.Since(removed) <= removedTTL
+}
+
+// lookup finds the experiment of a target missing in the maps from the index, and registers it
+// returns the experiment key; empty if not found
+func (c *Impl) lookup(kind, tKey string) string {
+	if c.reader == nil {
+		return ""
+	}
+
+	experiments := &iter8v1alpha2.ExperimentList{}
+	if err := c.reader.List(context.Background(), experiments,
+		client.MatchingFields{TargetIndex: indexKey(kind, tKey)}); err != nil {
+		c.logger.Error(err, "Fail to look up experiment of target", "kind", kind, "target", tKey)
+		return ""
+	}
+	sort.Slice(experiments.Items, func(i, j int) bool {
+		return experiments.Items[i].CreationTimestamp.Before(&experiments.Items[j].CreationTimestamp)
+	})
+
+	for i := range experiments.Items {
+		instance := &experiments.Items[i]
+		if !hasIndexKey(instance, indexKey(kind, tKey)) || c.isRemoved(instance) {
+			continue
+		}
+		if err := c.register(instance); err != nil {
+			continue
+		}
+		return experimentKey(instance)
+	}
+	return ""
+}
+
+// resolve returns the key of the experiment of the target, looking it up from the index if necessary
+func (c *Impl) resolve(target2Experiment map[string]string, kind, tKey string) (string, bool) {
+	if eaKey, ok := target2Experiment[tKey]; ok {
+		return eaKey, true
+	}
+	eaKey := c.lookup(kind, tKey)
+	return eaKey, eaKey != ""
+}
+
 // Inspect prints details of adapter into log
 func (c *Impl) Inspect() {
 	c.logger.Info("iter8Adapter", "deployment2Experiment", c.deployment2Experiment)
@@ -120,10 +255,11 @@ func (c *Impl) DeploymentToExperiment(targetName, targetNamespace string) (strin
 	defer c.m.Unlock()
 
 	tKey := targetKey(targetName, targetNamespace)
-	if _, ok := c.deployment2Experiment[tKey]; !ok {
+	eaKey, ok := c.resolve(c.deployment2Experiment, kindDeployment, tKey)
+	if !ok {
 		return "", "", false
 	}
-	namespace, name := resolveExperimentKey(c.deployment2Experiment[tKey])
+	namespace, name := resolveExperimentKey(eaKey)
 
 	return name, namespace, true
 }
@@ -134,7 +270,7 @@ func (c *Impl) MarkDeploymentDetected(targetName, targetNamespace string) bool {
 	defer c.m.Unlock()
 
 	tKey := targetKey(targetName, targetNamespace)
-	eaKey, ok := c.deployment2Experiment[tKey]
+	eaKey, ok := c.resolve(c.deployment2Experiment, kindDeployment, tKey)
 	if !ok {
 		return false
 	}
@@ -150,7 +286,7 @@ func (c *Impl) MarkDeploymentDeleted(targetName, targetNamespace string) bool {
 	defer c.m.Unlock()
 
 	tKey := targetKey(targetName, targetNamespace)
-	eaKey, ok := c.deployment2Experiment[tKey]
+	eaKey, ok := c.resolve(c.deployment2Experiment, kindDeployment, tKey)
 	if !ok {
 		return false
 	}
@@ -160,17 +296,33 @@ func (c *Impl) MarkDeploymentDeleted(targetName, targetNamespace string) bool {
 	return true
 }
 
+// MarkDeploymentChanged marks the event that a target deployment is changed
+func (c *Impl) MarkDeploymentChanged(targetName, targetNamespace, change string) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	tKey := targetKey(targetName, targetNamespace)
+	eaKey, ok := c.resolve(c.deployment2Experiment, kindDeployment, tKey)
+	if !ok {
+		return false
+	}
+
+	c.experimentAbstractStore[eaKey].MarkTargetChanged(targetName, "Deployment", change)
+
+	return true
+}
+
 // ServiceToExperiment returns the experiment key given name and namespace of target service
 func (c *Impl) ServiceToExperiment(targetName, targetNamespace string) (string, string, bool) {
 	c.m.Lock()
 	defer c.m.Unlock()
 
 	tKey := targetKey(targetName, targetNamespace)
-	if _, ok := c.service2Experiment[tKey]; !ok {
+	eaKey, ok := c.resolve(c.service2Experiment, kindService, tKey)
+	if !ok {
 		return "", "", false
 	}
-
-	namespace, name := resolveExperimentKey(c.service2Experiment[tKey])
+	namespace, name := resolveExperimentKey(eaKey)
 
 	return name, namespace, true
 }
@@ -181,7 +333,7 @@ func (c *Impl) MarkServiceDetected(targetName, targetNamespace string) bool {
 	defer c.m.Unlock()
 
 	tKey := targetKey(targetName, targetNamespace)
-	eaKey, ok := c.service2Experiment[tKey]
+	eaKey, ok := c.resolve(c.service2Experiment, kindService, tKey)
 	if !ok {
 		return false
 	}
@@ -197,7 +349,7 @@ func (c *Impl) MarkServiceDeleted(targetName, targetNamespace string) bool {
 	defer c.m.Unlock()
 
 	tKey := targetKey(targetName, targetNamespace)
-	eaKey, ok := c.service2Experiment[tKey]
+	eaKey, ok := c.resolve(c.service2Experiment, kindService, tKey)
 	if !ok {
 		return false
 	}
@@ -207,79 +359,60 @@ func (c *Impl) MarkServiceDeleted(targetName, targetNamespace string) bool {
 	return true
 }
 
+// MarkServiceChanged marks the event that a target service is changed
+func (c *Impl) MarkServiceChanged(targetName, targetNamespace, change string) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	tKey := targetKey(targetName, targetNamespace)
+	eaKey, ok := c.resolve(c.service2Experiment, kindService, tKey)
+	if !ok {
+		return false
+	}
+
+	c.experimentAbstractStore[eaKey].MarkTargetChanged(targetName, "Service", change)
+
+	return true
+}
+
 // RemoveExperiment removes the experiment abstract from the cache
 func (c *Impl) RemoveExperiment(instance *iter8v1alpha2.Experiment) {
 	c.m.Lock()
 	defer c.m.Unlock()
 
 	eakey := experimentKey(instance)
+	now := time.Now()
+	for uid, removed := range c.removed {
+		if now.Sub(removed) > removedTTL {
+			delete(c.removed, uid)
+		}
+	}
+	c.removed[instance.UID] = now
 	ea, ok := c.experimentAbstractStore[eakey]
 	if !ok {
 		return
 	}
 
-	for _, key := range ea.serviceKeys {
-		delete(c.service2Experiment, key)
-	}
-
-	for _, key := range ea.deploymentKeys {
-		delete(c.deployment2Experiment, key)
-	}
+	c.unregisterTargets(ea)
 	delete(c.experimentAbstractStore, eakey)
 }
 
 func (c *Impl) checkAndGetServices(instance *iter8v1alpha2.Experiment) ([]string, error) {
-	out := []string{}
-	service := instance.Spec.Service
-	ns := instance.ServiceNamespace()
-
-	if service.Name != "" {
-		key := targetKey(service.Name, ns)
-		if _, ok := c.service2Experiment[key]; ok {
-			return nil, fmt.Errorf("Service %s is being involved in other experiment", key)
+	out := serviceKeys(instance)
+	for _, key := range out {
+		if owner, ok := c.service2Experiment[key]; ok && owner != experimentKey(instance) {
+			return nil, util.NewConflictError("Service %s is being involved in other experiment", key)
 		}
-		out = append(out, key)
 	}
-
-	if service.Kind == "Service" {
-		baselineKey := targetKey(service.Baseline, ns)
-		if _, ok := c.service2Experiment[baselineKey]; ok {
-			return nil, fmt.Errorf("Baseline %s is being involved in other experiment", baselineKey)
-		}
-		out = append(out, baselineKey)
-
-		for _, candidate := range service.Candidates {
-			candidateKey := targetKey(candidate, ns)
-			if _, ok := c.service2Experiment[candidateKey]; ok {
-				return nil, fmt.Errorf("Candidate %s is being involved in other experiment", candidateKey)
-			}
-			out = append(out, candidateKey)
-		}
-	}
-
 	return out, nil
 }
 
 func (c *Impl) checkAndGetDeployments(instance *iter8v1alpha2.Experiment) ([]string, error) {
-	service := instance.Spec.Service
-	if service.Kind == "Deployment" || service.Kind == "" {
-		out := []string{}
-		ns := instance.ServiceNamespace()
-
-		baselineKey := targetKey(service.Baseline, ns)
-		if _, ok := c.deployment2Experiment[baselineKey]; ok {
-			return nil, fmt.Errorf("Baseline %s is being involved in other experiment", baselineKey)
-		}
-		out = append(out, baselineKey)
-
-		for _, candidate := range service.Candidates {
-			candidateKey := targetKey(candidate, ns)
-			if _, ok := c.service2Experiment[candidateKey]; ok {
-				return nil, fmt.Errorf("Candidate %s is being involved in other experiment", candidateKey)
-			}
-			out = append(out, candidateKey)
+	out := deploymentKeys(instance)
+	for _, key := range out {
+		if owner, ok := c.deployment2Experiment[key]; ok && owner != experimentKey(instance) {
+			return nil, util.NewConflictError("Deployment %s is being involved in other experiment", key)
 		}
-		return out, nil
 	}
-	return nil, nil
+	return out, nil
 }
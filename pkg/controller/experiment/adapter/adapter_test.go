@@ -0,0 +1,121 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+func newTestExperiment(name string, age time.Duration, candidates ...string) *iter8v1alpha2.Experiment {
+	return &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews"},
+				Baseline:        "reviews-v1",
+				Candidates:      candidates,
+			},
+		},
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = iter8v1alpha2.AddToScheme(scheme)
+
+	completed := newTestExperiment("completed", 3*time.Hour, "reviews-v2")
+	completed.Status.Phase = iter8v1alpha2.PhaseCompleted
+	objs := []runtime.Object{
+		completed,
+		newTestExperiment("first", 2*time.Hour, "reviews-v2"),
+		// shares service and baseline with the first experiment, which was created before
+		newTestExperiment("second", time.Hour, "reviews-v3"),
+	}
+	c := New(logf.Log, fake.NewFakeClientWithScheme(scheme, objs...))
+
+	g.Expect(c.Start(nil)).To(gomega.Succeed())
+	name, _, ok := c.DeploymentToExperiment("reviews-v2", "default")
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(name).To(gomega.Equal("first"))
+	name, _, ok = c.ServiceToExperiment("reviews", "default")
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(name).To(gomega.Equal("first"))
+	_, _, ok = c.DeploymentToExperiment("reviews-v3", "default")
+	g.Expect(ok).To(gomega.BeFalse())
+
+	// targets are replaced when they are changed in spec
+	changed := newTestExperiment("first", 2*time.Hour, "reviews-v4")
+	_, err := c.RegisterExperiment(context.Background(), changed)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	name, _, ok = c.DeploymentToExperiment("reviews-v4", "default")
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(name).To(gomega.Equal("first"))
+
+	// removed experiments are not looked up again; their targets are released to other experiments
+	c.RemoveExperiment(changed)
+	_, _, ok = c.DeploymentToExperiment("reviews-v2", "default")
+	g.Expect(ok).To(gomega.BeFalse())
+	g.Expect(c.MarkDeploymentDeleted("reviews-v1", "default")).To(gomega.BeTrue())
+	name, _, _ = c.DeploymentToExperiment("reviews-v1", "default")
+	g.Expect(name).To(gomega.Equal("second"))
+
+	// removed experiments are forgotten after a while
+	impl := c.(*Impl)
+	impl.removed[changed.UID] = time.Now().Add(-2 * removedTTL)
+	c.RemoveExperiment(newTestExperiment("other", 0))
+	g.Expect(impl.removed).To(gomega.HaveLen(1))
+	g.Expect(impl.removed).To(gomega.HaveKey(types.UID("other")))
+}
+
+func TestLookup(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = iter8v1alpha2.AddToScheme(scheme)
+
+	cl := fake.NewFakeClientWithScheme(scheme)
+	c := New(logf.Log, cl)
+	g.Expect(c.Start(nil)).To(gomega.Succeed())
+	_, _, ok := c.DeploymentToExperiment("reviews-v2", "default")
+	g.Expect(ok).To(gomega.BeFalse())
+
+	// experiments created after start are looked up by their targets
+	g.Expect(cl.Create(context.Background(), newTestExperiment("exp", 0, "reviews-v2"))).To(gomega.Succeed())
+	g.Expect(c.MarkDeploymentDetected("reviews-v2", "default")).To(gomega.BeTrue())
+	ctx, err := c.RegisterExperiment(context.Background(), newTestExperiment("exp", 0, "reviews-v2"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(ctx.Value(ActionKey).(Action).Resume()).To(gomega.BeTrue())
+}
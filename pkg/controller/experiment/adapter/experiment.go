@@ -30,12 +30,15 @@ const (
 type Catcher interface {
 	MarkTargetDetected(name string, kind string)
 	MarkTargetDeleted(name string, kind string)
+	MarkTargetChanged(name string, kind string, change string)
 }
 
 // Action specifies desired actions to be performed by controller to the experiment
 type Action interface {
 	Refresh() bool
 	Resume() bool
+	// Changes describes changes of targets which the experiment should react to
+	Changes() []string
 }
 
 var _ Catcher = &experiment{}
@@ -46,6 +49,7 @@ type experiment struct {
 	serviceKeys    []string
 	deploymentKeys []string
 	targetAction   targetAction
+	changes        []string
 }
 
 // NewExperiment returns an Experiment instance used in controlelr adapter
@@ -56,6 +60,23 @@ func newExperiment(services, deployments []string) *experiment {
 	}
 }
 
+// hasTargets tells whether the experiment is registered with exactly the targets
+func (e *experiment) hasTargets(services, deployments []string) bool {
+	return equalKeys(e.serviceKeys, services) && equalKeys(e.deploymentKeys, deployments)
+}
+
+func equalKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // Refresh indicates whether the controller should allow refresh workflows on the experiment
 func (e *experiment) Refresh() bool {
 	return e.targetAction == targetActionDeleted
@@ -66,8 +87,14 @@ func (e *experiment) Resume() bool {
 	return e.targetAction == targetActionDetected
 }
 
+// Changes returns descriptions of changes of targets since the last reconcile
+func (e *experiment) Changes() []string {
+	return e.changes
+}
+
 func (e *experiment) clearAction() {
 	e.targetAction = ""
+	e.changes = nil
 }
 
 // MarkTargetDetected captures a detection of a target
@@ -80,6 +107,11 @@ func (e *experiment) MarkTargetDeleted(name string, kind string) {
 	e.targetAction = targetActionDeleted
 }
 
+// MarkTargetChanged captures a change of a target
+func (e *experiment) MarkTargetChanged(name string, kind string, change string) {
+	e.changes = append(e.changes, kind+" "+name+" "+change)
+}
+
 // GetAction returns the action indicator of the experiment
 func (e *experiment) GetAction() Action {
 	out := &experiment{}
@@ -18,11 +18,19 @@ package adapter
 import (
 	"strings"
 
+	"k8s.io/apimachinery/pkg/runtime"
+
 	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
 )
 
 const (
 	keySeparator = "/"
+
+	// TargetIndex is the name of the cache index of experiments by their target deployments and services
+	TargetIndex = "iter8.targets"
+
+	kindDeployment = "Deployment"
+	kindService    = "Service"
 )
 
 func experimentKey(instance *iter8v1alpha2.Experiment) string {
@@ -41,3 +49,70 @@ func resolveExperimentKey(val string) (string, string) {
 	}
 	return out[0], out[1]
 }
+
+// serviceKeys returns keys of services targeted by the experiment
+func serviceKeys(instance *iter8v1alpha2.Experiment) []string {
+	out := []string{}
+	service := instance.Spec.Service
+	ns := instance.ServiceNamespace()
+
+	if service.Name != "" {
+		out = append(out, targetKey(service.Name, ns))
+	}
+
+	if service.Kind == kindService {
+		out = append(out, targetKey(service.Baseline, ns))
+		for _, candidate := range service.Candidates {
+			out = append(out, targetKey(candidate, ns))
+		}
+	}
+	return out
+}
+
+// deploymentKeys returns keys of deployments targeted by the experiment
+func deploymentKeys(instance *iter8v1alpha2.Experiment) []string {
+	service := instance.Spec.Service
+	if service.Kind != kindDeployment && service.Kind != "" {
+		return nil
+	}
+
+	ns := instance.ServiceNamespace()
+	out := []string{targetKey(service.Baseline, ns)}
+	for _, candidate := range service.Candidates {
+		out = append(out, targetKey(candidate, ns))
+	}
+	return out
+}
+
+func indexKey(kind, tKey string) string {
+	return kind + keySeparator + tKey
+}
+
+// IndexTargets indexes experiments which are not completed by kind and keys of their targets
+// It is used as the client.IndexerFunc of TargetIndex
+func IndexTargets(obj runtime.Object) []string {
+	instance, ok := obj.(*iter8v1alpha2.Experiment)
+	if !ok || instance.Status.Phase == iter8v1alpha2.PhaseCompleted {
+		return nil
+	}
+
+	out := []string{}
+	for _, key := range serviceKeys(instance) {
+		out = append(out, indexKey(kindService, key))
+	}
+	for _, key := range deploymentKeys(instance) {
+		out = append(out, indexKey(kindDeployment, key))
+	}
+	return out
+}
+
+// hasIndexKey tells whether the experiment is indexed by the key
+// Readers not supporting field selectors return experiments regardless of the index
+func hasIndexKey(instance *iter8v1alpha2.Experiment, key string) bool {
+	for _, k := range IndexTargets(instance) {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
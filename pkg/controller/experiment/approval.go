@@ -0,0 +1,130 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+// decisions recorded in status of approval gates
+const (
+	decidedByAnnotation     = "annotation"
+	decidedByManualOverride = "manualOverride"
+	decidedByTimeout        = "timeout"
+	decidedBySpec           = "spec"
+)
+
+// toAwaitApproval tells whether the experiment should wait for approval before proceeding
+// Gates at iteration and promotion milestones are reached here;
+// gates at weight milestones are reached when traffic is capped in processIteration.
+// The experiment is terminated if the pending gate is not approved in time.
+func (r *ReconcileExperiment) toAwaitApproval(context context.Context, instance *iter8v1alpha2.Experiment) bool {
+	if instance.Spec.Terminate() {
+		return false
+	}
+
+	if instance.Status.PendingApprovalGate() == nil {
+		current := *instance.Status.CurrentIteration
+		for _, gate := range instance.Spec.ApprovalGates {
+			if instance.Status.GetApprovalGate(gate.Name) != nil {
+				continue
+			}
+			if gate.Iteration != nil && current >= *gate.Iteration {
+				r.markAwaitingApproval(context, instance, gate.Name, "Gate %s reached at iteration %d", gate.Name, current)
+				break
+			}
+			if gate.IsPromotion() && current >= instance.Spec.GetMaxIterations() {
+				r.markAwaitingApproval(context, instance, gate.Name, "Gate %s reached before promotion", gate.Name)
+				break
+			}
+		}
+	}
+
+	pending := instance.Status.PendingApprovalGate()
+	if pending == nil {
+		return false
+	}
+
+	gate := instance.Spec.GetApprovalGate(pending.Name)
+	if gate == nil {
+		r.markApprovalGranted(context, instance, decidedBySpec, "Gate %s removed", pending.Name)
+		return r.toAwaitApproval(context, instance)
+	}
+
+	if instance.ApprovedByAnnotation(pending.Name) {
+		r.markApprovalGranted(context, instance, decidedByAnnotation, "Gate %s approved", pending.Name)
+		// more gates may be due at the same milestone
+		return r.toAwaitApproval(context, instance)
+	}
+
+	timeout, _ := gate.GetTimeout()
+	if timeout > 0 && time.Now().After(pending.ReachedTime.Add(timeout)) {
+		r.markApprovalRejected(context, instance, decidedByTimeout, "Gate %s not approved in %s", pending.Name, timeout)
+		instance.Spec.TerminateExperiment()
+		return false
+	}
+
+	// phase may have been changed while waiting, e.g. by pause and resume
+	r.markAwaitingApproval(context, instance, pending.Name, "Waiting for approval of gate %s", pending.Name)
+	return true
+}
+
+// awaitApproval checks approval of the pending gate again after an interval
+func (r *ReconcileExperiment) awaitApproval(context context.Context, instance *iter8v1alpha2.Experiment) (reconcile.Result, error) {
+	r.endRequest(context, instance)
+	interval, _ := instance.Spec.GetInterval()
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// capTrafficAtApprovalGates caps the weight of candidates at the lowest unapproved weight gate
+// Traffic over the cap is sent to baseline. Returns the gate if any candidate is capped; nil otherwise
+func capTrafficAtApprovalGates(instance *iter8v1alpha2.Experiment) *iter8v1alpha2.ApprovalGate {
+	var gate *iter8v1alpha2.ApprovalGate
+	for i := range instance.Spec.ApprovalGates {
+		g := &instance.Spec.ApprovalGates[i]
+		if g.Weight == nil {
+			continue
+		}
+		if status := instance.Status.GetApprovalGate(g.Name); status != nil && status.State == iter8v1alpha2.ApprovalApproved {
+			continue
+		}
+		if gate == nil || *g.Weight < *gate.Weight {
+			gate = g
+		}
+	}
+	if gate == nil {
+		return nil
+	}
+
+	capped := false
+	assessment := instance.Status.Assessment
+	for i := range assessment.Candidates {
+		if excess := assessment.Candidates[i].Weight - *gate.Weight; excess > 0 {
+			assessment.Candidates[i].Weight = *gate.Weight
+			assessment.Baseline.Weight += excess
+			capped = true
+		}
+	}
+	if !capped {
+		return nil
+	}
+	return gate
+}
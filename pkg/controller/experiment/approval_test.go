@@ -0,0 +1,128 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+	iter8notifier "github.com/iter8-tools/iter8-istio/pkg/notifier"
+)
+
+func newApprovalTestReconciler() *ReconcileExperiment {
+	return &ReconcileExperiment{
+		eventRecorder:      record.NewFakeRecorder(100),
+		notificationCenter: iter8notifier.NewNotificationCenter(logf.Log),
+	}
+}
+
+func newApprovalTestExperiment(gates ...iter8v1alpha2.ApprovalGate) *iter8v1alpha2.Experiment {
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			ApprovalGates: gates,
+		},
+	}
+	instance.InitStatus()
+	instance.Status.Assessment = &iter8v1alpha2.Assessment{
+		Baseline:   iter8v1alpha2.VersionAssessment{Name: "reviews-v1", Weight: 60},
+		Candidates: []iter8v1alpha2.VersionAssessment{{Name: "reviews-v2", Weight: 40}},
+	}
+	return instance
+}
+
+func TestCapTrafficAtApprovalGates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	w25, w50 := int32(25), int32(50)
+	instance := newApprovalTestExperiment(
+		iter8v1alpha2.ApprovalGate{Name: "half", Weight: &w50},
+		iter8v1alpha2.ApprovalGate{Name: "quarter", Weight: &w25},
+	)
+
+	gate := capTrafficAtApprovalGates(instance)
+	g.Expect(gate).NotTo(gomega.BeNil())
+	g.Expect(gate.Name).To(gomega.Equal("quarter"))
+	g.Expect(instance.Status.Assessment.Candidates[0].Weight).To(gomega.Equal(int32(25)))
+	g.Expect(instance.Status.Assessment.Baseline.Weight).To(gomega.Equal(int32(75)))
+
+	// approved gates no longer cap traffic
+	instance.Status.ApprovalGates = []iter8v1alpha2.ApprovalGateStatus{{Name: "quarter", State: iter8v1alpha2.ApprovalApproved}}
+	instance.Status.Assessment.Candidates[0].Weight = 40
+	instance.Status.Assessment.Baseline.Weight = 60
+	g.Expect(capTrafficAtApprovalGates(instance)).To(gomega.BeNil())
+}
+
+func TestAwaitApproval(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+	r := newApprovalTestReconciler()
+	iteration, promotion, timeout := int32(2), true, "1m"
+	instance := newApprovalTestExperiment(
+		iter8v1alpha2.ApprovalGate{Name: "smoke", Iteration: &iteration},
+		iter8v1alpha2.ApprovalGate{Name: "promote", Promotion: &promotion, Timeout: &timeout},
+	)
+
+	g.Expect(r.toAwaitApproval(ctx, instance)).To(gomega.BeFalse())
+
+	*instance.Status.CurrentIteration = 2
+	g.Expect(r.toAwaitApproval(ctx, instance)).To(gomega.BeTrue())
+	g.Expect(instance.Status.Phase).To(gomega.Equal(iter8v1alpha2.PhaseAwaitingApproval))
+	g.Expect(instance.Status.PendingApprovalGate().Name).To(gomega.Equal("smoke"))
+
+	instance.SetAnnotations(map[string]string{iter8v1alpha2.AnnotationApprove: "smoke"})
+	g.Expect(r.toAwaitApproval(ctx, instance)).To(gomega.BeFalse())
+	g.Expect(instance.Status.GetApprovalGate("smoke").State).To(gomega.Equal(iter8v1alpha2.ApprovalApproved))
+	g.Expect(*instance.Status.GetApprovalGate("smoke").DecidedBy).To(gomega.Equal(decidedByAnnotation))
+	g.Expect(instance.Status.Phase).To(gomega.Equal(iter8v1alpha2.PhaseProgressing))
+
+	// promotion gate is rejected after timeout
+	*instance.Status.CurrentIteration = instance.Spec.GetMaxIterations()
+	g.Expect(r.toAwaitApproval(ctx, instance)).To(gomega.BeTrue())
+	reached := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	instance.Status.PendingApprovalGate().ReachedTime = &reached
+	g.Expect(r.toAwaitApproval(ctx, instance)).To(gomega.BeFalse())
+	g.Expect(instance.Status.GetApprovalGate("promote").State).To(gomega.Equal(iter8v1alpha2.ApprovalRejected))
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeTrue())
+}
+
+func TestValidateApprovalGates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	w, iteration, timeout := int32(25), int32(3), "soon"
+
+	spec := &iter8v1alpha2.ExperimentSpec{
+		Service:       iter8v1alpha2.Service{ObjectReference: &corev1.ObjectReference{Name: "reviews"}},
+		ApprovalGates: []iter8v1alpha2.ApprovalGate{{Name: "gate", Weight: &w, Iteration: &iteration}},
+	}
+	g.Expect(spec.Validate()).NotTo(gomega.Succeed())
+
+	spec.ApprovalGates = []iter8v1alpha2.ApprovalGate{{Name: "gate", Weight: &w, Timeout: &timeout}}
+	g.Expect(spec.Validate()).NotTo(gomega.Succeed())
+
+	spec.ApprovalGates = []iter8v1alpha2.ApprovalGate{{Name: "gate", Weight: &w}, {Name: "gate", Iteration: &iteration}}
+	g.Expect(spec.Validate()).NotTo(gomega.Succeed())
+
+	spec.ApprovalGates = []iter8v1alpha2.ApprovalGate{{Name: "gate", Weight: &w}, {Name: "smoke", Iteration: &iteration}}
+	g.Expect(spec.Validate()).To(gomega.Succeed())
+}
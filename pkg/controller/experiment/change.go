@@ -0,0 +1,51 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"strings"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+// checkTargetChanges applies the onTargetChange policy of the experiment
+// if its targets are changed since the last reconcile
+// Changes before the targets are detected are ignored.
+func (r *ReconcileExperiment) checkTargetChanges(context context.Context, instance *iter8v1alpha2.Experiment) {
+	eas := experimentAction(context)
+	if eas == nil || len(eas.Changes()) == 0 {
+		return
+	}
+	if !instance.Status.TargetsFound() || instance.Spec.Terminate() {
+		return
+	}
+
+	changes := strings.Join(eas.Changes(), ", ")
+	switch instance.Spec.GetOnTargetChange() {
+	case iter8v1alpha2.OnTargetChangeRestart:
+		r.markTargetsChanged(context, instance, "%s, assessment restarted", changes)
+		instance.Status.RestartAssessment()
+		r.markTrafficReset()
+		r.markStatusUpdate()
+	case iter8v1alpha2.OnTargetChangeAbort:
+		r.markTargetsChanged(context, instance, "%s, experiment aborted", changes)
+		instance.Spec.TerminateExperiment()
+	default:
+		r.markTargetsChanged(context, instance, "%s, experiment paused", changes)
+		r.markActionPause(context, instance, "")
+	}
+}
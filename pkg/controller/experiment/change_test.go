@@ -0,0 +1,75 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/adapter"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+type testAction struct {
+	changes []string
+}
+
+func (a testAction) Refresh() bool     { return false }
+func (a testAction) Resume() bool      { return false }
+func (a testAction) Changes() []string { return a.changes }
+
+func TestCheckTargetChanges(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+	ctx = context.WithValue(ctx, adapter.ActionKey, adapter.Action(testAction{changes: []string{"Deployment reviews-v2 pod template changed"}}))
+	r := newApprovalTestReconciler()
+
+	// changes before targets are detected are ignored
+	instance := newApprovalTestExperiment()
+	r.checkTargetChanges(ctx, instance)
+	g.Expect(instance.Status.Phase).To(gomega.Equal(iter8v1alpha2.PhaseProgressing))
+
+	// pause by default
+	instance.Status.MarkTargetsFound("")
+	r.checkTargetChanges(ctx, instance)
+	g.Expect(instance.Status.Phase).To(gomega.Equal(iter8v1alpha2.PhasePause))
+	cond := instance.Status.GetCondition(iter8v1alpha2.ExperimentConditionTargetsUnchanged)
+	g.Expect(cond.IsFalse()).To(gomega.BeTrue())
+	g.Expect(*cond.Message).To(gomega.Equal("Deployment reviews-v2 pod template changed, experiment paused"))
+
+	restart := iter8v1alpha2.OnTargetChangeRestart
+	instance = newApprovalTestExperiment()
+	instance.Status.MarkTargetsFound("")
+	instance.Spec.OnTargetChange = &restart
+	r.initState()
+	r.checkTargetChanges(ctx, instance)
+	g.Expect(r.needTrafficReset()).To(gomega.BeTrue())
+	g.Expect(instance.Status.Assessment.Baseline.Weight).To(gomega.Equal(int32(100)))
+	g.Expect(instance.Status.Assessment.Candidates[0].Weight).To(gomega.Equal(int32(0)))
+	g.Expect(string(instance.Status.AnalysisState.Raw)).To(gomega.Equal("{}"))
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeFalse())
+
+	abort := iter8v1alpha2.OnTargetChangeAbort
+	instance = newApprovalTestExperiment()
+	instance.Status.MarkTargetsFound("")
+	instance.Spec.OnTargetChange = &abort
+	r.checkTargetChanges(ctx, instance)
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeTrue())
+}
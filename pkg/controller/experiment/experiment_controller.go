@@ -39,9 +39,12 @@ import (
 
 	metricsv1alpha2 "github.com/iter8-tools/iter8-istio/pkg/analytics/metrics/v1alpha2"
 	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/cloudevents"
+	iter8config "github.com/iter8-tools/iter8-istio/pkg/config"
 	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/adapter"
 	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/routing"
 	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/routing/router"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/targets"
 	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
 	iter8notifier "github.com/iter8-tools/iter8-istio/pkg/notifier"
 )
@@ -55,23 +58,23 @@ const (
 
 // Add creates a new Experiment Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
-func Add(mgr manager.Manager) error {
-	r, err := newReconciler(mgr)
+func Add(mgr manager.Manager, cfg *iter8config.Config) error {
+	r, err := newReconciler(mgr, cfg)
 	if err != nil {
 		return err
 	}
-	return add(mgr, r)
+	return add(mgr, r, cfg.MaxConcurrentReconciles)
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) (*ReconcileExperiment, error) {
-	cfg, err := config.GetConfig()
+func newReconciler(mgr manager.Manager, cfg *iter8config.Config) (*ReconcileExperiment, error) {
+	restConfig, err := config.GetConfig()
 	if err != nil {
 		log.Error(err, "unable to get client config")
 		return nil, err
 	}
 
-	ic, err := istioclient.NewForConfig(cfg)
+	ic, err := istioclient.NewForConfig(restConfig)
 	if err != nil {
 		log.Error(err, "Failed to create istio client")
 		return nil, err
@@ -81,28 +84,64 @@ func newReconciler(mgr manager.Manager) (*ReconcileExperiment, error) {
 
 	// Set up notifier configmap handler
 	nc := iter8notifier.NewNotificationCenter(log)
+	// secrets of subscriptions are read from the api server when notifying, rather than caching all secrets of the cluster
+	nc.SetSecretReader(mgr.GetAPIReader())
+	nc.SetNamespace(cfg.Iter8Namespace)
 	err = nc.RegisterHandler(context.Background(), k8sCache)
 	if err != nil {
 		log.Error(err, "Failed to register notifier config handlers")
 		return nil, err
 	}
 
-	iter8Adapter := adapter.New(log)
+	// Set up CloudEvents emitter
+	ceConfig, err := cloudevents.ConfigFromEnv()
+	if err != nil {
+		log.Error(err, "Invalid CloudEvents config")
+		return nil, err
+	}
+	emitter := cloudevents.NewEmitter(ceConfig, log.WithName("cloudevents"))
+	if emitter.Enabled() {
+		if err = mgr.Add(emitter); err != nil {
+			log.Error(err, "Failed to add CloudEvents emitter")
+			return nil, err
+		}
+	}
+
+	// Index experiments by their targets, so that events of targets are mapped to experiments
+	// which are not yet registered in the adapter, e.g. after restart of the controller
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &iter8v1alpha2.Experiment{}, adapter.TargetIndex, adapter.IndexTargets)
+	if err != nil {
+		log.Error(err, "Failed to index experiments by targets")
+		return nil, err
+	}
+	iter8Adapter := adapter.New(log, mgr.GetCache())
+	if err = mgr.Add(iter8Adapter); err != nil {
+		log.Error(err, "Failed to add adapter")
+		return nil, err
+	}
 
 	return &ReconcileExperiment{
 		Client:             mgr.GetClient(),
+		apiReader:          mgr.GetAPIReader(),
 		istioClient:        ic,
 		scheme:             mgr.GetScheme(),
 		eventRecorder:      mgr.GetEventRecorderFor(Iter8Controller),
 		notificationCenter: nc,
+		eventEmitter:       emitter,
 		iter8Adapter:       iter8Adapter,
+		iter8Namespace:     cfg.Iter8Namespace,
+		analyticsEndpoint:  cfg.AnalyticsEndpoint,
+		prometheusURL:      cfg.PrometheusURL,
 	}, nil
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
-func add(mgr manager.Manager, r *ReconcileExperiment) error {
+func add(mgr manager.Manager, r *ReconcileExperiment, maxConcurrentReconciles int) error {
 	// Create a new controller
-	c, err := controller.New("experiment-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("experiment-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+	})
 	if err != nil {
 		return err
 	}
@@ -119,8 +158,35 @@ func add(mgr manager.Manager, r *ReconcileExperiment) error {
 
 			return true
 		},
-		UpdateFunc: func(event.UpdateEvent) bool {
-			return false
+		// Reconcile experiments when their deployments are changed or their availability changes
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDeploy, ok := e.ObjectOld.(*appsv1.Deployment)
+			if !ok {
+				return false
+			}
+			newDeploy, ok := e.ObjectNew.(*appsv1.Deployment)
+			if !ok {
+				return false
+			}
+			name, namespace := e.MetaNew.GetName(), e.MetaNew.GetNamespace()
+			if _, _, ok := r.iter8Adapter.DeploymentToExperiment(name, namespace); !ok {
+				return false
+			}
+
+			if change := targets.DeploymentChange(oldDeploy, newDeploy); change != "" {
+				r.iter8Adapter.MarkDeploymentChanged(name, namespace, change)
+				log.Info("DeploymentChanged", "", name+"."+namespace, "change", change)
+				return true
+			}
+
+			oldAvailable, _ := targets.DeploymentAvailable(oldDeploy)
+			newAvailable, _ := targets.DeploymentAvailable(newDeploy)
+			if oldAvailable == newAvailable {
+				return false
+			}
+
+			log.Info("DeploymentAvailabilityChanged", "", name+"."+namespace, "available", newAvailable)
+			return true
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			name, namespace := e.Meta.GetName(), e.Meta.GetNamespace()
@@ -169,8 +235,28 @@ func add(mgr manager.Manager, r *ReconcileExperiment) error {
 
 			return true
 		},
-		UpdateFunc: func(event.UpdateEvent) bool {
-			return false
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldService, ok := e.ObjectOld.(*corev1.Service)
+			if !ok {
+				return false
+			}
+			newService, ok := e.ObjectNew.(*corev1.Service)
+			if !ok {
+				return false
+			}
+
+			change := targets.ServiceChange(oldService, newService)
+			if change == "" {
+				return false
+			}
+			name, namespace := e.MetaNew.GetName(), e.MetaNew.GetNamespace()
+			ok = r.iter8Adapter.MarkServiceChanged(name, namespace, change)
+			if !ok {
+				return false
+			}
+
+			log.Info("ServiceChanged", "", name+"."+namespace, "change", change)
+			return true
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			name, namespace := e.Meta.GetName(), e.Meta.GetNamespace()
@@ -247,12 +333,22 @@ var _ reconcile.Reconciler = &ReconcileExperiment{}
 // ReconcileExperiment reconciles a Experiment object
 type ReconcileExperiment struct {
 	client.Client
+	// apiReader reads objects not cached by the manager, such as pods, from the api server
+	apiReader          client.Reader
 	scheme             *runtime.Scheme
 	eventRecorder      record.EventRecorder
 	notificationCenter *iter8notifier.NotificationCenter
+	eventEmitter       *cloudevents.Emitter
 	istioClient        istioclient.Interface
 	iter8Adapter       adapter.Interface
 
+	// namespace of configmaps of metrics and notifiers
+	iter8Namespace string
+	// analytics endpoint of experiments not specifying one
+	analyticsEndpoint string
+	// prometheus evaluating guardrails of experiments
+	prometheusURL string
+
 	router router.Interface
 	interState
 }
@@ -265,7 +361,16 @@ type ReconcileExperiment struct {
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 func (r *ReconcileExperiment) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	// The state of a request is kept in interState and router of the reconciler, so each request is served
+	// by a copy of the reconciler, which allows experiments to be reconciled concurrently
+	rr := *r
+	return rr.reconcileRequest(request)
+}
+
+func (r *ReconcileExperiment) reconcileRequest(request reconcile.Request) (reconcile.Result, error) {
 	ctx := context.Background()
 
 	// Fetch the Experiment instance
@@ -284,6 +389,9 @@ func (r *ReconcileExperiment) Reconcile(request reconcile.Request) (reconcile.Re
 	log := log.WithValues("namespace", instance.Namespace, "name", instance.Name)
 	ctx = context.WithValue(ctx, util.LoggerKey, log)
 
+	// conditions set from now on reflect the current spec of the experiment
+	generationChanged := instance.Status.ObserveGeneration(instance.Generation)
+
 	// Init metadata of experiment instance
 	if instance.Status.InitTimestamp == nil {
 		instance.InitStatus()
@@ -309,21 +417,43 @@ func (r *ReconcileExperiment) Reconcile(request reconcile.Request) (reconcile.Re
 	}
 
 	if instance.Status.ExperimentCompleted() {
+		// retry writing the report failed when the experiment completed
+		if !r.writeReport(ctx, instance) {
+			// the failed attempt is recorded before writing the report is retried with backoff
+			r.endRequest(ctx, instance)
+			return reconcile.Result{}, fmt.Errorf("Fail to write report of experiment %s", instance.Name)
+		}
+		if r.needStatusUpdate() {
+			return r.endRequest(ctx, instance)
+		}
 		log.Info("NotToProceed", "phase", instance.Status.Phase)
 		return reconcile.Result{}, nil
 	}
 
 	ctx, err = r.iter8Adapter.RegisterExperiment(ctx, instance)
 	if err != nil {
+		if r.toQueue(instance, err) {
+			return r.queueRequest(ctx, instance, err)
+		}
 		r.markTargetsError(ctx, instance, "%v", err)
 		return r.endRequest(ctx, instance)
 	}
 	ctx = r.syncExperiment(ctx, instance)
+	if generationChanged {
+		r.markStatusUpdate()
+	}
+
+	// react to changes of targets since the last reconcile
+	r.checkTargetChanges(ctx, instance)
 
 	if err := r.proceed(ctx, instance); err != nil {
 		log.Info("NotToProceed", "status", err.Error())
 		return r.endRequest(ctx, instance)
 	}
+	if generationChanged {
+		// targets and routing rules are checked again for the new spec, which sets the conditions Ready summarizes
+		r.markRefresh()
+	}
 
 	if err := r.syncMetrics(ctx, instance); err != nil {
 		return r.endRequest(ctx, instance)
@@ -338,7 +468,7 @@ func (r *ReconcileExperiment) syncMetrics(ctx context.Context, instance *iter8v1
 	}
 	// Sync metric definitions from the config map
 	if !instance.Status.MetricsSynced() {
-		if err := metricsv1alpha2.Read(ctx, r, instance); err != nil && !validUpdateErr(err) {
+		if err := metricsv1alpha2.Read(ctx, r, r.iter8Namespace, instance); err != nil && !validUpdateErr(err) {
 			r.markSyncMetricsError(ctx, instance, "Fail to read metrics: %v", err)
 
 			if err := r.Status().Update(ctx, instance); err != nil && !validUpdateErr(err) {
@@ -405,7 +535,7 @@ func (r *ReconcileExperiment) syncExperiment(context context.Context, instance *
 }
 
 // proceed determines whether reconciliation of experiment should continue or not
-// refresh/terminate-command > pause-action >resume-action
+// refresh/terminate-command > pause-action >resume-action > approve-action
 func (r *ReconcileExperiment) proceed(context context.Context, instance *iter8v1alpha2.Experiment) (err error) {
 	// proceed
 	if r.needRefresh() || instance.Spec.Terminate() {
@@ -436,6 +566,21 @@ func (r *ReconcileExperiment) proceed(context context.Context, instance *iter8v1
 		return
 	}
 
+	// approve pending approval gate
+	if instance.Spec.Approve() {
+		instance.Spec.ManualOverride = nil
+		if err := r.Update(context, instance); err != nil && !validUpdateErr(err) {
+			log.Error(err, "fail to update instance")
+			return err
+		}
+		if pending := instance.Status.PendingApprovalGate(); pending != nil {
+			r.markApprovalGranted(context, instance, decidedByManualOverride, "Gate %s approved", pending.Name)
+		} else {
+			util.Logger(context).Info("NoPendingApprovalGate")
+		}
+		return
+	}
+
 	if instance.Status.Phase == iter8v1alpha2.PhasePause {
 		err = fmt.Errorf("experiment paused")
 	}
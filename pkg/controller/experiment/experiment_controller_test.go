@@ -20,6 +20,7 @@ import (
 	"time"
 
 	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	iter8config "github.com/iter8-tools/iter8-istio/pkg/config"
 	"github.com/onsi/gomega"
 	"golang.org/x/net/context"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -48,10 +49,10 @@ func TestReconcile(t *testing.T) {
 
 	g.Expect(err).NotTo(gomega.HaveOccurred())
 
-	r, err := newReconciler(mgr)
+	r, err := newReconciler(mgr, iter8config.Default())
 	g.Expect(err).NotTo(gomega.HaveOccurred())
 	_, requests := SetupTestReconcile(r)
-	g.Expect(add(mgr, r)).NotTo(gomega.HaveOccurred())
+	g.Expect(add(mgr, r, 1)).NotTo(gomega.HaveOccurred())
 
 	stopMgr, mgrStopped := StartTestManager(mgr, g)
 
@@ -0,0 +1,60 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/guardrails"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+// checkGuardrails evaluates guardrails of the experiment over the time elapsed since it started
+// returns false if any guardrail is breached, in which case the experiment is rolled back to the baseline,
+// even if other guardrails fail to be evaluated.
+// Failure to query prometheus doesn't stop the experiment; guardrails are evaluated again at the next iteration.
+func (r *ReconcileExperiment) checkGuardrails(context context.Context, instance *iter8v1alpha2.Experiment) bool {
+	if len(instance.Spec.Guardrails) == 0 {
+		return true
+	}
+
+	interval, _ := instance.Spec.GetInterval()
+	if elapsed := time.Since(instance.Status.StartTimestamp.Time); elapsed > interval {
+		interval = elapsed
+	}
+	breaches, err := guardrails.Evaluate(context, r.prometheusURL, instance, interval)
+	if len(breaches) == 0 {
+		if err != nil {
+			r.markGuardrailError(context, instance, "%s", err.Error())
+		}
+		return true
+	}
+	if err != nil {
+		util.Logger(context).Error(err, "Fail to evaluate guardrails")
+	}
+
+	messages := make([]string, len(breaches))
+	for i, b := range breaches {
+		messages[i] = b.String()
+	}
+	r.markGuardrailBreached(context, instance, "%s", strings.Join(messages, "; "))
+	util.Logger(context).Info("AbortExperiment", "Guardrail breached", "")
+	instance.Spec.TerminateExperiment()
+	return false
+}
@@ -0,0 +1,130 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+func newGuardrailTestExperiment(max string) *iter8v1alpha2.Experiment {
+	interval, quantity := "30s", resource.MustParse(max)
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews"},
+				Baseline:        "reviews-v1",
+				Candidates:      []string{"reviews-v2"},
+			},
+			Duration: &iter8v1alpha2.Duration{Interval: &interval},
+			Guardrails: []iter8v1alpha2.Guardrail{{
+				Name:  "error-rate",
+				Query: "sum(rate(istio_requests_total{response_code=~'5..'}[$interval])) by ($version_labels)",
+				Max:   &quantity,
+			}},
+		},
+	}
+	instance.InitStatus()
+	instance.Status.Assessment = &iter8v1alpha2.Assessment{
+		Baseline:   iter8v1alpha2.VersionAssessment{Name: "reviews-v1", Weight: 100},
+		Candidates: []iter8v1alpha2.VersionAssessment{{Name: "reviews-v2"}},
+	}
+	startTime := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	instance.Status.StartTimestamp = &startTime
+	return instance
+}
+
+func TestCheckGuardrails(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "up{" {
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+			return
+		}
+		query = r.URL.Query().Get("query")
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[`+
+			`{"metric":{"destination_workload":"reviews-v2","destination_workload_namespace":"default"},"value":[1600000000,"0.2"]}]}}`)
+	}))
+	defer ts.Close()
+	r := newApprovalTestReconciler()
+	r.prometheusURL = ts.URL
+
+	// the query covers the time elapsed since the experiment started
+	instance := newGuardrailTestExperiment("500m")
+	g.Expect(r.checkGuardrails(ctx, instance)).To(gomega.BeTrue())
+	g.Expect(query).To(gomega.MatchRegexp(`\[12\ds\]\)\) by \(destination_workload, destination_workload_namespace\)$`))
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeFalse())
+
+	// a breach rolls back the experiment
+	instance = newGuardrailTestExperiment("100m")
+	g.Expect(r.checkGuardrails(ctx, instance)).To(gomega.BeFalse())
+	g.Expect(*instance.Status.Message).To(gomega.ContainSubstring("guardrail error-rate breached by reviews-v2"))
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeTrue())
+
+	// a breach rolls back the experiment even if other guardrails fail to be evaluated
+	instance = newGuardrailTestExperiment("100m")
+	instance.Spec.Guardrails = append([]iter8v1alpha2.Guardrail{{Name: "invalid", Query: "up{", Max: instance.Spec.Guardrails[0].Max}},
+		instance.Spec.Guardrails...)
+	g.Expect(r.checkGuardrails(ctx, instance)).To(gomega.BeFalse())
+	g.Expect(*instance.Status.Message).To(gomega.HavePrefix(iter8v1alpha2.ReasonGuardrailBreached))
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeTrue())
+
+	// prometheus errors don't stop the experiment
+	ts.Close()
+	instance = newGuardrailTestExperiment("100m")
+	g.Expect(r.checkGuardrails(ctx, instance)).To(gomega.BeTrue())
+	g.Expect(*instance.Status.Message).To(gomega.HavePrefix(iter8v1alpha2.ReasonGuardrailError))
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeFalse())
+}
+
+func TestValidateGuardrails(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newGuardrailTestExperiment("500m")
+	g.Expect(instance.Spec.Validate()).To(gomega.Succeed())
+
+	min := resource.MustParse("1")
+	instance.Spec.Guardrails[0].Min = &min
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+
+	instance = newGuardrailTestExperiment("500m")
+	instance.Spec.Guardrails = append(instance.Spec.Guardrails, instance.Spec.Guardrails[0])
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+
+	instance = newGuardrailTestExperiment("500m")
+	instance.Spec.Guardrails[0].Max = nil
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+
+	instance = newGuardrailTestExperiment("500m")
+	instance.Spec.Guardrails[0].Query = ""
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+}
@@ -0,0 +1,223 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+// This file contains functions used for evaluating guardrails of an iter8 experiment,
+// which are prometheus queries checked by the controller independently of analytics.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/iter8-tools/iter8-istio/pkg/analytics"
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	queryPath    = "/api/v1/query"
+	queryTimeout = 10 * time.Second
+
+	intervalVariable      = "$interval"
+	versionLabelsVariable = "$version_labels"
+)
+
+// Sample is an element of the instant vector returned by prometheus
+type Sample struct {
+	Metric map[string]string
+	Value  float64
+}
+
+// Breach is a guardrail breached by a candidate
+type Breach struct {
+	Guardrail string
+	Candidate string
+	Value     float64
+	Bound     string
+}
+
+func (b Breach) String() string {
+	return fmt.Sprintf("guardrail %s breached by %s: value %s, %s", b.Guardrail, b.Candidate,
+		strconv.FormatFloat(b.Value, 'g', -1, 64), b.Bound)
+}
+
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Query evaluates the instant query against the prometheus at endpoint
+// Samples whose value is not a number are skipped
+func Query(ctx context.Context, endpoint, query string) ([]Sample, error) {
+	u, err := url.Parse(strings.TrimRight(endpoint, "/") + queryPath)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = url.Values{"query": []string{query}}.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	out := &queryResponse{}
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if out.Status != "success" {
+		return nil, fmt.Errorf("%s: %s", out.ErrorType, out.Error)
+	}
+	if out.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("Unsupported result type: %s", out.Data.ResultType)
+	}
+
+	samples := make([]Sample, 0, len(out.Data.Result))
+	for _, r := range out.Data.Result {
+		if len(r.Value) != 2 {
+			return nil, fmt.Errorf("Invalid sample: %v", r.Value)
+		}
+		str, ok := r.Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("Invalid sample: %v", r.Value)
+		}
+		value, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid sample: %v", err)
+		}
+		if math.IsNaN(value) {
+			continue
+		}
+		samples = append(samples, Sample{Metric: r.Metric, Value: value})
+	}
+	return samples, nil
+}
+
+// Render replaces the variables in the query template
+// interval is rounded to seconds; labels are sorted
+func Render(template string, interval time.Duration, labels []string) string {
+	sorted := append([]string{}, labels...)
+	sort.Strings(sorted)
+	seconds := int64(interval / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strings.NewReplacer(
+		intervalVariable, fmt.Sprintf("%ds", seconds),
+		versionLabelsVariable, strings.Join(sorted, ", "),
+	).Replace(template)
+}
+
+// Evaluate checks the guardrails of the experiment for each candidate over the interval
+// returns the guardrails breached, along with the errors of guardrails which fail to be evaluated;
+// every guardrail is evaluated whatever the others give. Candidates without samples are not checked
+func Evaluate(ctx context.Context, endpoint string, instance *iter8v1alpha2.Experiment, interval time.Duration) ([]Breach, error) {
+	labels := make([]string, 0)
+	for key := range analytics.VersionLabels(instance, "") {
+		labels = append(labels, key)
+	}
+
+	var breaches []Breach
+	var errs []error
+	for _, g := range instance.Spec.Guardrails {
+		max, err := bound(g.Max)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Invalid max of guardrail %s: %v", g.Name, err))
+			continue
+		}
+		min, err := bound(g.Min)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Invalid min of guardrail %s: %v", g.Name, err))
+			continue
+		}
+
+		samples, err := Query(ctx, endpoint, Render(g.Query, interval, labels))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Fail to query guardrail %s: %v", g.Name, err))
+			continue
+		}
+
+		for _, candidate := range instance.Spec.Candidates {
+			versionLabels := analytics.VersionLabels(instance, candidate)
+			for _, s := range samples {
+				if !matches(s.Metric, versionLabels) {
+					continue
+				}
+				if max != nil && s.Value > *max {
+					breaches = append(breaches, Breach{Guardrail: g.Name, Candidate: candidate, Value: s.Value,
+						Bound: "max " + g.Max.String()})
+				} else if min != nil && s.Value < *min {
+					breaches = append(breaches, Breach{Guardrail: g.Name, Candidate: candidate, Value: s.Value,
+						Bound: "min " + g.Min.String()})
+				}
+			}
+		}
+	}
+	return breaches, utilerrors.NewAggregate(errs)
+}
+
+// bound converts the quantity into a float; returns nil if not specified
+func bound(q *resource.Quantity) (*float64, error) {
+	if q == nil {
+		return nil, nil
+	}
+	// AsDec changes the internal representation of quantity, which is shared with the spec
+	quantity := q.DeepCopy()
+	value, err := strconv.ParseFloat(quantity.AsDec().String(), 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// matches tells whether the metric has all the labels
+func matches(metric, labels map[string]string) bool {
+	for k, v := range labels {
+		if metric[k] != v {
+			return false
+		}
+	}
+	return true
+}
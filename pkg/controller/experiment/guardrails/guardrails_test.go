@@ -0,0 +1,163 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package guardrails
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const testVector = `{"status":"success","data":{"resultType":"vector","result":[
+{"metric":{"destination_workload":"reviews-v1","destination_workload_namespace":"default"},"value":[1600000000,"120"]},
+{"metric":{"destination_workload":"reviews-v2","destination_workload_namespace":"default"},"value":[1600000000,"650.5"]},
+{"metric":{"destination_workload":"reviews-v3","destination_workload_namespace":"default"},"value":[1600000000,"NaN"]}]}}`
+
+func newTestExperiment() *iter8v1alpha2.Experiment {
+	max := resource.MustParse("500")
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews"},
+				Baseline:        "reviews-v1",
+				Candidates:      []string{"reviews-v2", "reviews-v3"},
+			},
+			Guardrails: []iter8v1alpha2.Guardrail{{
+				Name:  "p99-latency",
+				Query: "histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket[$interval])) by (le, $version_labels))",
+				Max:   &max,
+			}},
+		},
+	}
+	instance.InitStatus()
+	return instance
+}
+
+func newTestPrometheus(queries *[]string, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != queryPath {
+			http.NotFound(w, r)
+			return
+		}
+		*queries = append(*queries, r.URL.Query().Get("query"))
+		fmt.Fprint(w, response)
+	}))
+}
+
+func TestRender(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(Render("sum(rate(m[$interval])) by ($version_labels)", 90*time.Second, []string{"b", "a"})).
+		To(gomega.Equal("sum(rate(m[90s])) by (a, b)"))
+	g.Expect(Render("rate(m[$interval])", 100*time.Millisecond, nil)).To(gomega.Equal("rate(m[1s])"))
+}
+
+func TestQuery(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var queries []string
+	ts := newTestPrometheus(&queries, testVector)
+	defer ts.Close()
+
+	samples, err := Query(context.Background(), ts.URL+"/", "up")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(queries).To(gomega.Equal([]string{"up"}))
+	g.Expect(samples).To(gomega.HaveLen(2))
+	g.Expect(samples[1].Value).To(gomega.Equal(650.5))
+
+	failing := newTestPrometheus(&queries, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+	defer failing.Close()
+	_, err = Query(context.Background(), failing.URL, "up{")
+	g.Expect(err).To(gomega.MatchError("bad_data: parse error"))
+}
+
+func TestEvaluate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var queries []string
+	ts := newTestPrometheus(&queries, testVector)
+	defer ts.Close()
+	instance := newTestExperiment()
+
+	breaches, err := Evaluate(context.Background(), ts.URL, instance, time.Minute)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(queries).To(gomega.Equal([]string{
+		"histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket[60s])) by (le, destination_workload, destination_workload_namespace))",
+	}))
+	g.Expect(breaches).To(gomega.Equal([]Breach{{Guardrail: "p99-latency", Candidate: "reviews-v2", Value: 650.5, Bound: "max 500"}}))
+	g.Expect(breaches[0].String()).To(gomega.Equal("guardrail p99-latency breached by reviews-v2: value 650.5, max 500"))
+
+	// the baseline is not checked
+	min := resource.MustParse("200m")
+	instance.Spec.Guardrails[0].Max = nil
+	instance.Spec.Guardrails[0].Min = &min
+	breaches, err = Evaluate(context.Background(), ts.URL, instance, time.Minute)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(breaches).To(gomega.BeEmpty())
+
+	min = resource.MustParse("1k")
+	breaches, err = Evaluate(context.Background(), ts.URL, instance, time.Minute)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(breaches).To(gomega.Equal([]Breach{{Guardrail: "p99-latency", Candidate: "reviews-v2", Value: 650.5, Bound: "min 1k"}}))
+
+	// a guardrail failing to be evaluated doesn't hide breaches of the others
+	partial := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "up{" {
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+			return
+		}
+		fmt.Fprint(w, testVector)
+	}))
+	defer partial.Close()
+	instance.Spec.Guardrails = append([]iter8v1alpha2.Guardrail{{Name: "invalid", Query: "up{", Max: &min}},
+		instance.Spec.Guardrails...)
+	breaches, err = Evaluate(context.Background(), partial.URL, instance, time.Minute)
+	g.Expect(err).To(gomega.MatchError("Fail to query guardrail invalid: bad_data: parse error"))
+	g.Expect(breaches).To(gomega.Equal([]Breach{{Guardrail: "p99-latency", Candidate: "reviews-v2", Value: 650.5, Bound: "min 1k"}}))
+}
+
+func TestEvaluateVersionLabels(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var queries []string
+	ts := newTestPrometheus(&queries, `{"status":"success","data":{"resultType":"vector","result":[
+{"metric":{"destination_app":"reviews","destination_version":"v2"},"value":[1600000000,"650.5"]},
+{"metric":{"destination_app":"reviews","destination_version":"reviews-v3"},"value":[1600000000,"120"]}]}}`)
+	defer ts.Close()
+	instance := newTestExperiment()
+	instance.Spec.VersionLabels = &iter8v1alpha2.VersionLabels{
+		Template: map[string]string{"destination_app": "reviews", "destination_version": "$name"},
+		Versions: []iter8v1alpha2.VersionLabelValues{
+			{Name: "reviews-v2", Labels: map[string]string{"destination_version": "v2"}},
+		},
+	}
+
+	// the query is grouped by the labels of the template, and samples are matched with the values of each version
+	breaches, err := Evaluate(context.Background(), ts.URL, instance, time.Minute)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(queries).To(gomega.Equal([]string{
+		"histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket[60s])) by (le, destination_app, destination_version))",
+	}))
+	g.Expect(breaches).To(gomega.Equal([]Breach{{Guardrail: "p99-latency", Candidate: "reviews-v2", Value: 650.5, Bound: "max 500"}}))
+}
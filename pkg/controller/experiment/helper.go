@@ -28,6 +28,10 @@ type interState struct {
 	statusUpdate bool
 	refresh      bool
 	progress     bool
+	trafficReset bool
+
+	// candidates whose traffic is held as they are not ready
+	unready map[string]bool
 }
 
 func (r *ReconcileExperiment) initState() {
@@ -58,8 +62,17 @@ func (r *ReconcileExperiment) hasProgress() bool {
 	return r.interState.progress
 }
 
+func (r *ReconcileExperiment) markTrafficReset() {
+	r.interState.trafficReset = true
+}
+
+func (r *ReconcileExperiment) needTrafficReset() bool {
+	return r.interState.trafficReset
+}
+
 func (r *ReconcileExperiment) injectClients(ctx context.Context) context.Context {
 	ctx = context.WithValue(ctx, util.IstioClientKey, r.istioClient)
+	ctx = context.WithValue(ctx, util.KubeClientKey, r.Client)
 	return ctx
 }
 
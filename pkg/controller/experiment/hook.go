@@ -0,0 +1,152 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/hooks"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+// hookPollInterval is how often running job hooks are checked
+var hookPollInterval = 5 * time.Second
+
+// toAwaitHooks runs the hooks due before the current iteration and tells whether the experiment should wait for them
+// preTraffic hooks run before the first iteration and preCompletion hooks after the last one;
+// postTrafficUpdate hooks are started in processIteration.
+func (r *ReconcileExperiment) toAwaitHooks(context context.Context, instance *iter8v1alpha2.Experiment) bool {
+	if instance.Spec.Terminate() {
+		return false
+	}
+
+	current := *instance.Status.CurrentIteration
+	if current == 0 {
+		r.startHooks(context, instance, iter8v1alpha2.HookPreTraffic)
+	}
+	if current >= instance.Spec.GetMaxIterations() {
+		r.startHooks(context, instance, iter8v1alpha2.HookPreCompletion)
+	}
+
+	running := r.checkHooks(context, instance)
+	if instance.Spec.Terminate() {
+		return false
+	}
+	return running || instance.Status.Phase == iter8v1alpha2.PhasePause
+}
+
+// awaitHooks checks running hooks again after an interval; a paused experiment waits for resume instead
+func (r *ReconcileExperiment) awaitHooks(context context.Context, instance *iter8v1alpha2.Experiment) (reconcile.Result, error) {
+	r.endRequest(context, instance)
+	if instance.Status.Phase == iter8v1alpha2.PhasePause {
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{RequeueAfter: hookPollInterval}, nil
+}
+
+// startHooks runs the hooks of the lifecycle point which have not run at the current iteration
+// Http hooks complete right away; job hooks are checked by checkHooks
+func (r *ReconcileExperiment) startHooks(context context.Context, instance *iter8v1alpha2.Experiment, phase iter8v1alpha2.HookPhase) {
+	for _, hook := range instance.Spec.GetHooks(phase) {
+		hook := hook
+		if instance.Spec.Terminate() || instance.Status.Phase == iter8v1alpha2.PhasePause {
+			return
+		}
+		if hs := instance.Status.GetHookStatus(hook.Name); hs != nil && hs.Phase == phase &&
+			hs.Iteration == *instance.Status.CurrentIteration {
+			continue
+		}
+
+		hs := instance.Status.StartHook(hook.Name, phase)
+		r.markStatusUpdate()
+		if hook.HTTP != nil {
+			if err := hooks.CallHTTP(context, instance, &hook); err != nil {
+				r.markHookFailed(context, instance, &hook, "Hook %s failed: %v", hook.Name, err)
+			} else {
+				r.markHookSucceeded(context, instance, hook.Name, "Hook %s succeeded", hook.Name)
+			}
+			continue
+		}
+
+		job, err := hooks.StartJob(context, r.Client, r.scheme, instance, &hook)
+		if err != nil {
+			r.markHookFailed(context, instance, &hook, "Fail to start job of hook %s: %v", hook.Name, err)
+			continue
+		}
+		hs.Job = &job
+		util.Logger(context).Info("HookJobStarted", "hook", hook.Name, "job", job)
+	}
+}
+
+// checkHooks updates the state of running job hooks
+// returns true if any of them is still running
+func (r *ReconcileExperiment) checkHooks(context context.Context, instance *iter8v1alpha2.Experiment) bool {
+	running := false
+	for i := range instance.Status.Hooks {
+		hs := &instance.Status.Hooks[i]
+		if hs.State != iter8v1alpha2.HookRunning || hs.Job == nil {
+			continue
+		}
+
+		hook := instance.Spec.GetHook(hs.Name)
+		if hook == nil {
+			// hook removed from spec while running
+			hooks.DeleteJob(context, r.Client, instance.Namespace, *hs.Job)
+			r.markHookSucceeded(context, instance, hs.Name, "Hook %s removed", hs.Name)
+			continue
+		}
+
+		state, message, err := hooks.JobState(context, r.Client, instance.Namespace, *hs.Job)
+		if err != nil {
+			util.Logger(context).Error(err, "Fail to get job of hook", "hook", hs.Name, "job", *hs.Job)
+			running = true
+			continue
+		}
+
+		switch state {
+		case iter8v1alpha2.HookSucceeded:
+			r.markHookSucceeded(context, instance, hs.Name, "Hook %s succeeded", hs.Name)
+		case iter8v1alpha2.HookFailed:
+			r.markHookFailed(context, instance, hook, "Hook %s failed: %s", hs.Name, message)
+		default:
+			timeout, _ := hook.GetTimeout()
+			if time.Now().After(hs.StartTime.Add(timeout)) {
+				if err := hooks.DeleteJob(context, r.Client, instance.Namespace, *hs.Job); err != nil {
+					util.Logger(context).Error(err, "Fail to delete job of hook", "hook", hs.Name, "job", *hs.Job)
+				}
+				r.markHookFailed(context, instance, hook, "Hook %s timed out after %s", hs.Name, timeout)
+			} else {
+				running = true
+			}
+		}
+	}
+	return running
+}
+
+// onHookFailure pauses or rolls back the experiment according to the failure policy of the hook
+func (r *ReconcileExperiment) onHookFailure(context context.Context, instance *iter8v1alpha2.Experiment, hook *iter8v1alpha2.Hook) {
+	switch hook.GetOnFailure() {
+	case iter8v1alpha2.HookFailurePause:
+		r.markActionPause(context, instance, "Hook %s failed", hook.Name)
+	default:
+		util.Logger(context).Info("AbortExperiment", "Hook failed", hook.Name)
+		instance.Spec.TerminateExperiment()
+	}
+}
@@ -0,0 +1,71 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+func TestHTTPHooks(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+	r := newApprovalTestReconciler()
+
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ok.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "checks failed", http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	pause := iter8v1alpha2.HookFailurePause
+	instance := newApprovalTestExperiment()
+	instance.Spec.Hooks = []iter8v1alpha2.Hook{
+		{Name: "smoke", Phase: iter8v1alpha2.HookPreTraffic, HTTP: &iter8v1alpha2.HTTPHook{URL: ok.URL}},
+		{Name: "integration", Phase: iter8v1alpha2.HookPostTrafficUpdate, HTTP: &iter8v1alpha2.HTTPHook{URL: failing.URL}, OnFailure: &pause},
+		{Name: "final", Phase: iter8v1alpha2.HookPreCompletion, HTTP: &iter8v1alpha2.HTTPHook{URL: failing.URL}},
+	}
+
+	// preTraffic hook runs once before the first iteration
+	g.Expect(r.toAwaitHooks(ctx, instance)).To(gomega.BeFalse())
+	g.Expect(instance.Status.GetHookStatus("smoke").State).To(gomega.Equal(iter8v1alpha2.HookSucceeded))
+	g.Expect(instance.Status.GetHookStatus("integration")).To(gomega.BeNil())
+
+	// failure of postTrafficUpdate hook pauses the experiment
+	*instance.Status.CurrentIteration = 1
+	r.startHooks(ctx, instance, iter8v1alpha2.HookPostTrafficUpdate)
+	hs := instance.Status.GetHookStatus("integration")
+	g.Expect(hs.State).To(gomega.Equal(iter8v1alpha2.HookFailed))
+	g.Expect(*hs.Message).To(gomega.ContainSubstring("checks failed"))
+	g.Expect(instance.Status.Phase).To(gomega.Equal(iter8v1alpha2.PhasePause))
+	g.Expect(r.toAwaitHooks(ctx, instance)).To(gomega.BeTrue())
+
+	// failure of preCompletion hook rolls back the experiment
+	instance.Status.Phase = iter8v1alpha2.PhaseProgressing
+	*instance.Status.CurrentIteration = instance.Spec.GetMaxIterations()
+	g.Expect(r.toAwaitHooks(ctx, instance)).To(gomega.BeFalse())
+	g.Expect(instance.Status.GetHookStatus("final").State).To(gomega.Equal(iter8v1alpha2.HookFailed))
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeTrue())
+}
@@ -0,0 +1,192 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+// This file contains functions used for running hooks of an iter8 experiment,
+// either as Jobs in the namespace of the experiment or as calls to HTTP endpoints.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	// ExperimentLabel is the label of Jobs pointing to the experiment running them
+	ExperimentLabel = "iter8-tools/experiment"
+	// HookLabel is the label of Jobs pointing to the hook running them
+	HookLabel = "iter8-tools/hook"
+
+	maxNameLength = 63
+)
+
+// Request is the payload posted to the endpoint of http hooks
+type Request struct {
+	Hook       string                  `json:"hook"`
+	Phase      iter8v1alpha2.HookPhase `json:"phase"`
+	Experiment string                  `json:"experiment"`
+	Namespace  string                  `json:"namespace"`
+	Iteration  int32                   `json:"iteration"`
+	Baseline   string                  `json:"baseline"`
+	Candidates []string                `json:"candidates"`
+	Traffic    map[string]int32        `json:"traffic,omitempty"`
+}
+
+// CallHTTP posts the state of the experiment to the endpoint of the hook
+// returns non-nil error if the endpoint can not be reached or does not respond with a 2xx status code
+func CallHTTP(ctx context.Context, instance *iter8v1alpha2.Experiment, hook *iter8v1alpha2.Hook) error {
+	timeout, err := hook.GetTimeout()
+	if err != nil {
+		return err
+	}
+
+	payload := Request{
+		Hook:       hook.Name,
+		Phase:      hook.Phase,
+		Experiment: instance.Name,
+		Namespace:  instance.Namespace,
+		Iteration:  *instance.Status.CurrentIteration,
+		Baseline:   instance.Spec.Baseline,
+		Candidates: instance.Spec.Candidates,
+	}
+	if instance.Status.Assessment != nil {
+		payload.Traffic = map[string]int32{
+			instance.Status.Assessment.Baseline.Name: instance.Status.Assessment.Baseline.Weight,
+		}
+		for _, c := range instance.Status.Assessment.Candidates {
+			payload.Traffic[c.Name] = c.Weight
+		}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, hook.HTTP.URL, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range hook.HTTP.Headers {
+		req.Header.Set(k, v)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// JobName returns the name of the Job run by the hook at the iteration
+func JobName(instance *iter8v1alpha2.Experiment, hook *iter8v1alpha2.Hook) string {
+	name := fmt.Sprintf("%s-%s-%d", instance.Name, hook.Name, *instance.Status.CurrentIteration)
+	if len(name) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength], "-.")
+	}
+	return strings.ToLower(name)
+}
+
+// StartJob creates the Job of the hook in the namespace of the experiment, owned by the experiment
+// returns the name of the Job; an existing Job of the same name is reused
+func StartJob(ctx context.Context, c client.Client, scheme *runtime.Scheme,
+	instance *iter8v1alpha2.Experiment, hook *iter8v1alpha2.Hook) (string, error) {
+	spec, err := hook.GetJobSpec()
+	if err != nil {
+		return "", err
+	}
+	if spec.Template.Spec.RestartPolicy == "" {
+		spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(instance, hook),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				ExperimentLabel: instance.Name,
+				HookLabel:       hook.Name,
+			},
+		},
+		Spec: *spec,
+	}
+	if err := controllerutil.SetControllerReference(instance, job, scheme); err != nil {
+		return "", err
+	}
+
+	if err := c.Create(ctx, job); err != nil && !errors.IsAlreadyExists(err) {
+		return "", err
+	}
+	return job.Name, nil
+}
+
+// JobState returns the state of the Job run by a hook, with a message if the Job failed
+func JobState(ctx context.Context, c client.Client, namespace, name string) (iter8v1alpha2.HookState, string, error) {
+	job := &batchv1.Job{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, job); err != nil {
+		if errors.IsNotFound(err) {
+			return iter8v1alpha2.HookFailed, "Job deleted", nil
+		}
+		return "", "", err
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return iter8v1alpha2.HookSucceeded, "", nil
+		case batchv1.JobFailed:
+			return iter8v1alpha2.HookFailed, fmt.Sprintf("Job failed: %s", cond.Message), nil
+		}
+	}
+	return iter8v1alpha2.HookRunning, "", nil
+}
+
+// DeleteJob deletes the Job run by a hook along with its pods
+func DeleteJob(ctx context.Context, c client.Client, namespace, name string) error {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	return client.IgnoreNotFound(c.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+}
@@ -0,0 +1,147 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+func newTestExperiment() *iter8v1alpha2.Experiment {
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default", UID: "1234"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews"},
+				Baseline:        "reviews-v1",
+				Candidates:      []string{"reviews-v2"},
+			},
+		},
+	}
+	instance.InitStatus()
+	return instance
+}
+
+func TestCallHTTP(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newTestExperiment()
+
+	var received Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "denied", http.StatusForbidden)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		if received.Phase == iter8v1alpha2.HookPreCompletion {
+			http.Error(w, "integration checks failed", http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	hook := &iter8v1alpha2.Hook{
+		Name:  "smoke",
+		Phase: iter8v1alpha2.HookPreTraffic,
+		HTTP: &iter8v1alpha2.HTTPHook{
+			URL:     ts.URL,
+			Headers: map[string]string{"Authorization": "Bearer token"},
+		},
+	}
+	g.Expect(CallHTTP(context.Background(), instance, hook)).To(gomega.Succeed())
+	g.Expect(received.Experiment).To(gomega.Equal("exp"))
+	g.Expect(received.Baseline).To(gomega.Equal("reviews-v1"))
+	g.Expect(received.Candidates).To(gomega.Equal([]string{"reviews-v2"}))
+
+	hook.Phase = iter8v1alpha2.HookPreCompletion
+	err := CallHTTP(context.Background(), instance, hook)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring("integration checks failed"))
+
+	hook.HTTP.Headers = nil
+	g.Expect(CallHTTP(context.Background(), instance, hook)).NotTo(gomega.Succeed())
+}
+
+func TestJobHook(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = iter8v1alpha2.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme)
+
+	instance := newTestExperiment()
+	hook := &iter8v1alpha2.Hook{
+		Name:  "smoke",
+		Phase: iter8v1alpha2.HookPreTraffic,
+		Job: &runtime.RawExtension{
+			Raw: []byte(`{"template":{"spec":{"containers":[{"name":"test","image":"busybox"}]}}}`),
+		},
+	}
+
+	name, err := StartJob(ctx, c, scheme, instance, hook)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(name).To(gomega.Equal("exp-smoke-0"))
+
+	job := &batchv1.Job{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, job)).To(gomega.Succeed())
+	g.Expect(job.Spec.Template.Spec.RestartPolicy).To(gomega.Equal(corev1.RestartPolicyNever))
+	g.Expect(job.GetLabels()[HookLabel]).To(gomega.Equal("smoke"))
+	g.Expect(job.GetOwnerReferences()).To(gomega.HaveLen(1))
+
+	// starting again reuses the job
+	_, err = StartJob(ctx, c, scheme, instance, hook)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	state, _, err := JobState(ctx, c, "default", name)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(state).To(gomega.Equal(iter8v1alpha2.HookRunning))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	g.Expect(c.Update(ctx, job)).To(gomega.Succeed())
+	state, message, err := JobState(ctx, c, "default", name)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(state).To(gomega.Equal(iter8v1alpha2.HookFailed))
+	g.Expect(message).To(gomega.ContainSubstring("BackoffLimitExceeded"))
+
+	g.Expect(DeleteJob(ctx, c, "default", name)).To(gomega.Succeed())
+	state, _, err = JobState(ctx, c, "default", name)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(state).To(gomega.Equal(iter8v1alpha2.HookFailed))
+}
+
+func TestJobName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newTestExperiment()
+	instance.Name = strings.Repeat("a", 60)
+	name := JobName(instance, &iter8v1alpha2.Hook{Name: "Smoke"})
+	g.Expect(len(name)).To(gomega.BeNumerically("<=", maxNameLength))
+	g.Expect(name).NotTo(gomega.HaveSuffix("-"))
+}
@@ -33,6 +33,9 @@ func (r *ReconcileExperiment) syncKubernetes(context context.Context, instance *
 	// TODO: consider renaming the method checkOrInitRules
 	err := r.checkOrInitRules(context, instance)
 	if err != nil {
+		if r.toQueue(instance, err) {
+			return r.queueRequest(context, instance, err)
+		}
 		return r.endRequest(context, instance)
 	}
 
@@ -44,6 +47,33 @@ func (r *ReconcileExperiment) syncKubernetes(context context.Context, instance *
 		}
 	}
 
+	// send traffic back to baseline after assessment is restarted
+	if r.needTrafficReset() {
+		if err := r.router.UpdateRouteWithTrafficUpdate(context, instance); err != nil {
+			r.markRoutingRulesError(context, instance, "%v", err)
+			return r.endRequest(context, instance)
+		}
+		r.markTrafficUpdate(context, instance, "Traffic: %s", instance.Status.TrafficToString())
+	}
+
+	// wait at approval gates
+	if r.toAwaitApproval(context, instance) {
+		return r.awaitApproval(context, instance)
+	}
+
+	// run hooks due at the current lifecycle point and wait for them
+	if r.toAwaitHooks(context, instance) {
+		return r.awaitHooks(context, instance)
+	}
+
+	// generate load on the service if specified
+	r.startLoad(context, instance)
+
+	// hold traffic to candidates which are not ready
+	if r.toAwaitReadiness(context, instance) {
+		return r.awaitReadiness(context, instance)
+	}
+
 	if r.toProcessIteration(context, instance) {
 		err := r.processIteration(context, instance)
 		if err != nil {
@@ -86,6 +116,8 @@ func (r *ReconcileExperiment) finalize(context context.Context, instance *iter8v
 			util.Logger(context).Error(err, "Fail to execute finalize sync process")
 		}
 	}
+	r.notificationCenter.RemoveSubscriptions(instance)
+	r.notificationCenter.RemoveExperiment(instance)
 
 	return reconcile.Result{}, removeFinalizer(context, r, instance, Finalizer)
 }
@@ -114,6 +146,20 @@ func (r *ReconcileExperiment) toComplete(context context.Context, instance *iter
 		instance.Spec.Terminate()
 }
 
+// toQueue tells whether the experiment should wait for resources held by other experiments instead of failing
+func (r *ReconcileExperiment) toQueue(instance *iter8v1alpha2.Experiment, err error) bool {
+	return util.IsConflict(err) && instance.Spec.GetOnConflict() == iter8v1alpha2.OnConflictQueue &&
+		!instance.Spec.Terminate()
+}
+
+// queueRequest marks the experiment as queued and retries it after an interval
+func (r *ReconcileExperiment) queueRequest(context context.Context, instance *iter8v1alpha2.Experiment, err error) (reconcile.Result, error) {
+	r.markExperimentQueued(context, instance, "%v", err)
+	r.endRequest(context, instance)
+	interval, _ := instance.Spec.GetInterval()
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
 func (r *ReconcileExperiment) endRequest(context context.Context, instance *iter8v1alpha2.Experiment) (reconcile.Result, error) {
 	if r.needStatusUpdate() {
 		if err := r.Status().Update(context, instance); err != nil && !validUpdateErr(err) {
@@ -0,0 +1,57 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/hooks"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/load"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+// startLoad creates the Job generating load once targets are found, before the first iteration is processed
+// Failure to create the Job is retried at the next reconcile; the experiment proceeds meanwhile.
+func (r *ReconcileExperiment) startLoad(context context.Context, instance *iter8v1alpha2.Experiment) {
+	if instance.Spec.Load == nil || instance.Status.Load != nil || instance.Spec.Terminate() {
+		return
+	}
+
+	job, err := load.StartJob(context, r.Client, r.scheme, instance)
+	if err != nil {
+		r.markLoadError(context, instance, "Fail to start load: %v", err)
+		return
+	}
+	duration, _ := instance.Spec.GetLoadDuration()
+	r.markLoadStarted(context, instance, job, "Load of %d qps sent to %s for %s",
+		instance.Spec.Load.GetQPS(), instance.GetLoadURL(), duration)
+}
+
+// stopLoad deletes the Job generating load as the experiment completes
+func (r *ReconcileExperiment) stopLoad(context context.Context, instance *iter8v1alpha2.Experiment) {
+	if instance.Status.Load == nil || instance.Status.Load.StopTime != nil {
+		return
+	}
+	if err := hooks.DeleteJob(context, r.Client, instance.Namespace, instance.Status.Load.Job); err != nil {
+		util.Logger(context).Error(err, "Fail to delete job of load", "job", instance.Status.Load.Job)
+		return
+	}
+	if instance.Status.MarkLoadStopped() {
+		util.Logger(context).Info("LoadStopped", "job", instance.Status.Load.Job)
+		r.markStatusUpdate()
+	}
+}
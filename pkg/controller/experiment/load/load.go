@@ -0,0 +1,158 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package load
+
+// This file contains functions used for generating load on the service of an iter8 experiment
+// by a Job running fortio in the namespace of the experiment.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/hooks"
+)
+
+const (
+	// LoadLabel is the label of Jobs generating load
+	LoadLabel = "iter8-tools/load"
+
+	// jobDeadlineMargin is the time allowed for the Job to start and stop on top of the duration of load
+	jobDeadlineMargin = 5 * time.Minute
+
+	// shellImage provides the shell running fortio and stopping the sidecar afterwards, since the image of fortio has none
+	shellImage = "busybox:1.32-musl"
+	shellDir   = "/iter8"
+	shell      = shellDir + "/busybox"
+
+	// quitURL stops the istio sidecar, which otherwise keeps the pod of the Job running once fortio exits
+	quitURL = "http://127.0.0.1:15020/quitquitquit"
+
+	maxNameLength = 63
+	suffix        = "-load"
+)
+
+// script runs fortio with the arguments of the container, then stops the istio sidecar, exiting with the status of fortio
+var script = fmt.Sprintf(`fortio "$@"; status=$?; %s wget -q -T 5 -O /dev/null --post-data "" %s; exit $status`, shell, quitURL)
+
+// JobName returns the name of the Job generating load for the experiment
+func JobName(instance *iter8v1alpha2.Experiment) string {
+	name := instance.Name
+	if len(name)+len(suffix) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength-len(suffix)], "-.")
+	}
+	return strings.ToLower(name + suffix)
+}
+
+// Args returns the arguments of fortio sending requests of load for the duration
+func Args(instance *iter8v1alpha2.Experiment, duration time.Duration) []string {
+	load := instance.Spec.Load
+	args := []string{
+		"load",
+		"-qps", fmt.Sprintf("%d", load.GetQPS()),
+		"-t", duration.String(),
+		// targets may not be ready to serve when the load starts
+		"-allow-initial-errors",
+	}
+
+	keys := make([]string, 0, len(load.Headers))
+	for k := range load.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-H", fmt.Sprintf("%s: %s", k, load.Headers[k]))
+	}
+
+	if load.Payload != nil {
+		args = append(args, "-payload", *load.Payload)
+	}
+	return append(args, instance.GetLoadURL())
+}
+
+// StartJob creates the Job generating load in the namespace of the experiment, owned by the experiment
+// Pods of the Job have an istio sidecar, so that the requests are reported in metrics used by criteria of the experiment.
+// The sidecar is stopped once fortio exits, so that the Job completes.
+// returns the name of the Job; an existing Job of the same name is reused
+func StartJob(ctx context.Context, c client.Client, scheme *runtime.Scheme, instance *iter8v1alpha2.Experiment) (string, error) {
+	duration, err := instance.Spec.GetLoadDuration()
+	if err != nil {
+		return "", err
+	}
+
+	labels := map[string]string{
+		hooks.ExperimentLabel: instance.Name,
+		LoadLabel:             "true",
+	}
+	backoffLimit := int32(0)
+	deadline := int64((duration + jobDeadlineMargin) / time.Second)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(instance),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadline,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					InitContainers: []corev1.Container{{
+						Name:         "shell",
+						Image:        shellImage,
+						Command:      []string{"cp", "/bin/busybox", shell},
+						VolumeMounts: []corev1.VolumeMount{{Name: "shell", MountPath: shellDir}},
+					}},
+					Containers: []corev1.Container{{
+						Name:         "fortio",
+						Image:        instance.Spec.Load.GetImage(),
+						Command:      []string{shell, "sh", "-c", script, "fortio"},
+						Args:         Args(instance, duration),
+						VolumeMounts: []corev1.VolumeMount{{Name: "shell", MountPath: shellDir}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         "shell",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(instance, job, scheme); err != nil {
+		return "", err
+	}
+
+	if err := c.Create(ctx, job); err != nil && !errors.IsAlreadyExists(err) {
+		return "", err
+	}
+	return job.Name, nil
+}
@@ -0,0 +1,129 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package load
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/hooks"
+)
+
+func newTestExperiment(load *iter8v1alpha2.Load) *iter8v1alpha2.Experiment {
+	port := int32(9080)
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default", UID: "1234"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews", Namespace: "bookinfo"},
+				Baseline:        "reviews-v1",
+				Port:            &port,
+			},
+			Load: load,
+		},
+	}
+	instance.InitStatus()
+	return instance
+}
+
+func TestArgs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	instance := newTestExperiment(&iter8v1alpha2.Load{})
+	g.Expect(Args(instance, time.Minute)).To(gomega.Equal([]string{
+		"load", "-qps", "8", "-t", "1m0s", "-allow-initial-errors",
+		"http://reviews.bookinfo.svc.cluster.local:9080/",
+	}))
+
+	qps, payload, host := int32(20), `{"user":"jason"}`, "bookinfo.example.com"
+	instance = newTestExperiment(&iter8v1alpha2.Load{
+		Host:    &host,
+		QPS:     &qps,
+		Headers: map[string]string{"X-User": "jason", "Content-Type": "application/json"},
+		Payload: &payload,
+	})
+	g.Expect(Args(instance, time.Minute)).To(gomega.Equal([]string{
+		"load", "-qps", "20", "-t", "1m0s", "-allow-initial-errors",
+		"-H", "Content-Type: application/json", "-H", "X-User: jason",
+		"-payload", payload,
+		"http://bookinfo.example.com/",
+	}))
+}
+
+func TestStartJob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = iter8v1alpha2.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme)
+
+	url, duration := "http://productpage.bookinfo:9080/productpage", "10m"
+	instance := newTestExperiment(&iter8v1alpha2.Load{URL: &url, Duration: &duration})
+
+	name, err := StartJob(ctx, c, scheme, instance)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(name).To(gomega.Equal("exp-load"))
+
+	job := &batchv1.Job{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, job)).To(gomega.Succeed())
+	g.Expect(job.GetLabels()[hooks.ExperimentLabel]).To(gomega.Equal("exp"))
+	g.Expect(job.GetOwnerReferences()).To(gomega.HaveLen(1))
+	g.Expect(*job.Spec.ActiveDeadlineSeconds).To(gomega.Equal(int64(15 * 60)))
+	g.Expect(job.Spec.Template.GetAnnotations()).To(gomega.HaveKeyWithValue("sidecar.istio.io/inject", "true"))
+
+	container := job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Image).To(gomega.Equal(iter8v1alpha2.DefaultLoadImage))
+	g.Expect(container.Args).To(gomega.ContainElement("10m0s"))
+	g.Expect(container.Args[len(container.Args)-1]).To(gomega.Equal(url))
+
+	// fortio is run by a shell stopping the sidecar once it exits
+	g.Expect(container.Command).To(gomega.Equal([]string{shell, "sh", "-c", script, "fortio"}))
+	g.Expect(script).To(gomega.ContainSubstring(quitURL))
+	g.Expect(job.Spec.Template.Spec.InitContainers).To(gomega.HaveLen(1))
+	g.Expect(job.Spec.Template.Spec.InitContainers[0].Command).To(gomega.ContainElement(shell))
+	g.Expect(container.VolumeMounts).To(gomega.Equal(job.Spec.Template.Spec.InitContainers[0].VolumeMounts))
+
+	// starting again reuses the job
+	_, err = StartJob(ctx, c, scheme, instance)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	invalid := "often"
+	instance.Spec.Load.Duration = &invalid
+	_, err = StartJob(ctx, c, scheme, instance)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestJobName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newTestExperiment(&iter8v1alpha2.Load{})
+	instance.Name = strings.Repeat("a", 70)
+	name := JobName(instance)
+	g.Expect(len(name)).To(gomega.BeNumerically("<=", 63))
+	g.Expect(name).To(gomega.HaveSuffix("-load"))
+}
@@ -0,0 +1,90 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+func TestLoad(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = iter8v1alpha2.AddToScheme(scheme)
+
+	r := newApprovalTestReconciler()
+	r.Client = fake.NewFakeClientWithScheme(scheme)
+	r.scheme = scheme
+
+	// no load unless specified
+	instance := newReadinessTestExperiment()
+	r.startLoad(ctx, instance)
+	g.Expect(instance.Status.Load).To(gomega.BeNil())
+
+	instance.Spec.Load = &iter8v1alpha2.Load{}
+	r.startLoad(ctx, instance)
+	g.Expect(instance.Status.Load).NotTo(gomega.BeNil())
+	g.Expect(instance.Status.Load.Job).To(gomega.Equal("exp-load"))
+	g.Expect(*instance.Status.Message).To(gomega.ContainSubstring("http://reviews.default.svc.cluster.local/"))
+
+	key := types.NamespacedName{Namespace: "default", Name: "exp-load"}
+	g.Expect(r.Get(ctx, key, &batchv1.Job{})).To(gomega.Succeed())
+
+	r.stopLoad(ctx, instance)
+	g.Expect(instance.Status.Load.StopTime).NotTo(gomega.BeNil())
+	g.Expect(errors.IsNotFound(r.Get(ctx, key, &batchv1.Job{}))).To(gomega.BeTrue())
+
+	// load is not started again after it stops
+	r.startLoad(ctx, instance)
+	g.Expect(errors.IsNotFound(r.Get(ctx, key, &batchv1.Job{}))).To(gomega.BeTrue())
+}
+
+func TestValidateLoad(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	qps, duration, host := int32(0), "0s", "bookinfo.example.com"
+
+	spec := &iter8v1alpha2.ExperimentSpec{
+		Service:    iter8v1alpha2.Service{ObjectReference: &corev1.ObjectReference{}},
+		Networking: &iter8v1alpha2.Networking{Hosts: []iter8v1alpha2.Host{{Name: host, Gateway: "bookinfo-gateway"}}},
+		Load:       &iter8v1alpha2.Load{},
+	}
+	g.Expect(spec.Validate()).NotTo(gomega.Succeed())
+
+	spec.Load.Host = &host
+	g.Expect(spec.Validate()).To(gomega.Succeed())
+
+	spec.Load.QPS = &qps
+	g.Expect(spec.Validate()).NotTo(gomega.Succeed())
+
+	spec.Load.QPS = nil
+	spec.Load.Duration = &duration
+	g.Expect(spec.Validate()).NotTo(gomega.Succeed())
+}
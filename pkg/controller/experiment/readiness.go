@@ -0,0 +1,100 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/targets"
+)
+
+// toAwaitReadiness checks readiness of candidates and tells whether the experiment should wait for them
+// Traffic to candidates which are not ready is held at zero; the experiment waits only if no candidate is ready.
+// A candidate crashlooping after it has received traffic rolls the experiment back to baseline.
+func (r *ReconcileExperiment) toAwaitReadiness(context context.Context, instance *iter8v1alpha2.Experiment) bool {
+	if instance.Spec.Terminate() || len(instance.Spec.Candidates) == 0 {
+		return false
+	}
+
+	r.interState.unready = make(map[string]bool)
+	notReady := make([]string, 0)
+	for _, readiness := range targets.Init(instance, r.Client).WithPodReader(r.apiReader).CheckCandidates(context) {
+		if readiness.Ready {
+			continue
+		}
+		if readiness.CrashLooping && candidateWeight(instance, readiness.Name) > 0 {
+			r.markCandidateCrashLoop(context, instance, "Candidate %s is crashlooping after receiving traffic, rolling back", readiness.Name)
+			instance.Spec.TerminateExperiment()
+			return false
+		}
+		r.interState.unready[readiness.Name] = true
+		notReady = append(notReady, fmt.Sprintf("%s (%s)", readiness.Name, readiness.Reason))
+	}
+
+	if len(notReady) == 0 {
+		r.markTargetsReady(context, instance, "")
+		return false
+	}
+	r.markTargetsNotReady(context, instance, "Traffic held for candidates not ready: %s", strings.Join(notReady, ", "))
+
+	return len(notReady) == len(instance.Spec.Candidates)
+}
+
+// awaitReadiness takes traffic away from candidates which are not ready
+// and checks readiness of candidates again after an interval
+func (r *ReconcileExperiment) awaitReadiness(context context.Context, instance *iter8v1alpha2.Experiment) (reconcile.Result, error) {
+	if r.holdTrafficOfUnreadyCandidates(instance) {
+		if err := r.router.UpdateRouteWithTrafficUpdate(context, instance); err != nil {
+			r.markRoutingRulesError(context, instance, "%v", err)
+			return r.endRequest(context, instance)
+		}
+		r.markTrafficUpdate(context, instance, "Traffic: %s", instance.Status.TrafficToString())
+	}
+
+	r.endRequest(context, instance)
+	interval, _ := instance.Spec.GetInterval()
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// holdTrafficOfUnreadyCandidates sends traffic of candidates which are not ready to baseline
+// returns true if traffic is changed
+func (r *ReconcileExperiment) holdTrafficOfUnreadyCandidates(instance *iter8v1alpha2.Experiment) bool {
+	held := false
+	assessment := instance.Status.Assessment
+	for i := range assessment.Candidates {
+		if r.interState.unready[assessment.Candidates[i].Name] && assessment.Candidates[i].Weight > 0 {
+			assessment.Baseline.Weight += assessment.Candidates[i].Weight
+			assessment.Candidates[i].Weight = 0
+			held = true
+		}
+	}
+	return held
+}
+
+// candidateWeight returns the weight of traffic currently sent to the candidate
+func candidateWeight(instance *iter8v1alpha2.Experiment, name string) int32 {
+	for _, candidate := range instance.Status.Assessment.Candidates {
+		if candidate.Name == name {
+			return candidate.Weight
+		}
+	}
+	return 0
+}
@@ -0,0 +1,123 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+func newReadinessTestDeployment(name string, available int32, restarts int32) []runtime.Object {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"version": name}},
+		},
+		Status: appsv1.DeploymentStatus{AvailableReplicas: available},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-pod", Namespace: "default", Labels: map[string]string{"version": name}},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{RestartCount: restarts}},
+		},
+	}
+	if restarts > 0 {
+		pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+	}
+	return []runtime.Object{deploy, pod}
+}
+
+func newReadinessTestExperiment(candidates ...string) *iter8v1alpha2.Experiment {
+	instance := newApprovalTestExperiment()
+	instance.Spec.Service = iter8v1alpha2.Service{
+		ObjectReference: &corev1.ObjectReference{Name: "reviews"},
+		Baseline:        "reviews-v1",
+		Candidates:      candidates,
+	}
+	instance.Status.Assessment.Candidates = make([]iter8v1alpha2.VersionAssessment, len(candidates))
+	for i, name := range candidates {
+		instance.Status.Assessment.Candidates[i] = iter8v1alpha2.VersionAssessment{Name: name}
+	}
+	instance.Status.Assessment.Baseline.Weight = 100
+	return instance
+}
+
+func TestAwaitReadiness(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	objs := append(newReadinessTestDeployment("reviews-v2", 1, 0), newReadinessTestDeployment("reviews-v3", 0, 0)...)
+	r := newApprovalTestReconciler()
+	r.Client = fake.NewFakeClientWithScheme(scheme, objs...)
+	r.apiReader = r.Client
+
+	// waits while no candidate is ready
+	instance := newReadinessTestExperiment("reviews-v3")
+	g.Expect(r.toAwaitReadiness(ctx, instance)).To(gomega.BeTrue())
+	g.Expect(instance.Status.TargetsReady()).To(gomega.BeFalse())
+
+	// traffic of candidates not ready is held at zero
+	instance = newReadinessTestExperiment("reviews-v2", "reviews-v3")
+	g.Expect(r.toAwaitReadiness(ctx, instance)).To(gomega.BeFalse())
+	instance.Status.Assessment.Baseline.Weight = 50
+	instance.Status.Assessment.Candidates[0].Weight = 25
+	instance.Status.Assessment.Candidates[1].Weight = 25
+	g.Expect(r.holdTrafficOfUnreadyCandidates(instance)).To(gomega.BeTrue())
+	g.Expect(instance.Status.Assessment.Baseline.Weight).To(gomega.Equal(int32(75)))
+	g.Expect(instance.Status.Assessment.Candidates[0].Weight).To(gomega.Equal(int32(25)))
+	g.Expect(instance.Status.Assessment.Candidates[1].Weight).To(gomega.Equal(int32(0)))
+
+	instance = newReadinessTestExperiment("reviews-v2")
+	g.Expect(r.toAwaitReadiness(ctx, instance)).To(gomega.BeFalse())
+	g.Expect(instance.Status.TargetsReady()).To(gomega.BeTrue())
+}
+
+func TestCrashLoopRollback(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	r := newApprovalTestReconciler()
+	r.Client = fake.NewFakeClientWithScheme(scheme, newReadinessTestDeployment("reviews-v2", 1, 5)...)
+	r.apiReader = r.Client
+
+	// crashlooping candidate is held before receiving traffic
+	instance := newReadinessTestExperiment("reviews-v2")
+	g.Expect(r.toAwaitReadiness(ctx, instance)).To(gomega.BeTrue())
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeFalse())
+
+	// and rolled back after
+	instance.Status.Assessment.Candidates[0].Weight = 20
+	g.Expect(r.toAwaitReadiness(ctx, instance)).To(gomega.BeFalse())
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeTrue())
+	g.Expect(*instance.Status.GetCondition(iter8v1alpha2.ExperimentConditionTargetsReady).Reason).
+		To(gomega.Equal(iter8v1alpha2.ReasonCandidateCrashLoop))
+}
@@ -40,7 +40,10 @@ func (r *ReconcileExperiment) completeExperiment(context context.Context, instan
 		return err
 	}
 
+	r.stopLoad(context, instance)
+	r.recordTraffic(instance)
 	r.markExperimentCompleted(context, instance, "%s", completeStatusMessage(instance))
+	r.writeReport(context, instance)
 	return nil
 }
 
@@ -77,11 +80,16 @@ func completeStatusMessage(instance *iter8v1alpha2.Experiment) string {
 func (r *ReconcileExperiment) checkOrInitRules(context context.Context, instance *iter8v1alpha2.Experiment) error {
 	err := r.router.Fetch(context, instance)
 	if err != nil {
+		if r.toQueue(instance, err) {
+			return err
+		}
 		r.markRoutingRulesError(context, instance, "Error in getting routing rules: %s, Experiment Ended.", err.Error())
 		r.completeExperiment(context, instance)
+		return err
 	}
 
-	return err
+	r.markExperimentDequeued(context, instance, "")
+	return nil
 }
 
 // return true if instance status should be updated
@@ -147,8 +155,21 @@ func (r *ReconcileExperiment) processIteration(context context.Context, instance
 		instance.Status.StartTimestamp = &startTime
 		r.markStatusUpdate()
 	}
+	r.recordTraffic(instance)
+
+	if !r.checkGuardrails(context, instance) {
+		// experiment is rolled back
+		return nil
+	}
 
-	if len(instance.Spec.Criteria) == 0 {
+	if len(instance.Spec.GetSteps()) > 0 {
+		updated, healthy := r.applyStep(context, instance)
+		if !healthy {
+			// experiment is paused or rolled back
+			return nil
+		}
+		trafficUpdated = updated
+	} else if len(instance.Spec.Criteria) == 0 {
 		// each candidate gets maxincrement traffic at each interval
 		// until no more traffic can be deducted from baseline
 		basetraffic := instance.Status.Assessment.Baseline.Weight
@@ -168,7 +189,7 @@ func (r *ReconcileExperiment) processIteration(context context.Context, instance
 			return err
 		}
 
-		response, err := analytics.Invoke(log, instance.Spec.GetAnalyticsEndpoint(), payload)
+		response, err := analytics.Invoke(log, r.getAnalyticsEndpoint(instance), payload)
 		if err != nil {
 			r.markAnalyticsServiceError(context, instance, "%s", err.Error())
 			return err
@@ -257,15 +278,33 @@ func (r *ReconcileExperiment) processIteration(context context.Context, instance
 		r.markAnalyticsServiceRunning(context, instance, "")
 	}
 
+	gate := capTrafficAtApprovalGates(instance)
+	if gate != nil {
+		trafficUpdated = true
+	}
+
+	if r.holdTrafficOfUnreadyCandidates(instance) {
+		trafficUpdated = true
+	}
+
 	if trafficUpdated {
 		if err := r.router.UpdateRouteWithTrafficUpdate(context, instance); err != nil {
 			r.markRoutingRulesError(context, instance, "%v", err)
 			return err
 		}
 		r.markTrafficUpdate(context, instance, "Traffic: %s", instance.Status.TrafficToString())
+		r.recordTraffic(instance)
 	}
 
 	r.markIterationUpdate(context, instance, "Iteration %d/%d completed", *instance.Status.CurrentIteration, instance.Spec.GetMaxIterations())
+
+	if gate != nil && instance.Status.GetApprovalGate(gate.Name) == nil {
+		r.markAwaitingApproval(context, instance, gate.Name, "Traffic to candidates capped at %d%% by gate %s", *gate.Weight, gate.Name)
+	}
+
+	if trafficUpdated {
+		r.startHooks(context, instance, iter8v1alpha2.HookPostTrafficUpdate)
+	}
 	return nil
 }
 
@@ -273,3 +312,11 @@ func (r *ReconcileExperiment) updateIteration(instance *iter8v1alpha2.Experiment
 	*instance.Status.CurrentIteration++
 	r.markStatusUpdate()
 }
+
+// getAnalyticsEndpoint returns the analytics endpoint of the experiment, or the one configured for the controller
+func (r *ReconcileExperiment) getAnalyticsEndpoint(instance *iter8v1alpha2.Experiment) string {
+	if instance.Spec.AnalyticsEndpoint == nil && r.analyticsEndpoint != "" {
+		return r.analyticsEndpoint
+	}
+	return instance.Spec.GetAnalyticsEndpoint()
+}
@@ -32,6 +32,7 @@ func (r *ReconcileExperiment) markTargetsError(context context.Context, instance
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 	}
 }
@@ -42,6 +43,7 @@ func (r *ReconcileExperiment) markTargetsFound(context context.Context, instance
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 	}
 }
@@ -52,6 +54,7 @@ func (r *ReconcileExperiment) markAnalyticsServiceError(context context.Context,
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 	}
 }
@@ -62,6 +65,7 @@ func (r *ReconcileExperiment) markAnalyticsServiceRunning(context context.Contex
 		util.Logger(context).Info(reason)
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, "")
 		r.notificationCenter.Notify(instance, reason, "")
+		r.eventEmitter.Emit(instance, reason, "")
 		r.markStatusUpdate()
 	}
 }
@@ -72,6 +76,7 @@ func (r *ReconcileExperiment) markIterationUpdate(context context.Context, insta
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 		r.markProgress()
 	}
@@ -83,6 +88,7 @@ func (r *ReconcileExperiment) markAssessmentUpdate(context context.Context, inst
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 	}
 }
@@ -93,6 +99,7 @@ func (r *ReconcileExperiment) markTrafficUpdate(context context.Context, instanc
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 	}
 }
@@ -103,6 +110,9 @@ func (r *ReconcileExperiment) markExperimentCompleted(context context.Context, i
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		// channels subscribed by the experiment are no longer needed
+		r.notificationCenter.RemoveSubscriptions(instance)
 		// Clear analysis state
 		instance.Status.AnalysisState.Raw = []byte("{}")
 		now := metav1.Now()
@@ -117,6 +127,7 @@ func (r *ReconcileExperiment) markSyncMetricsError(context context.Context, inst
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 	}
 }
@@ -127,6 +138,7 @@ func (r *ReconcileExperiment) markSyncMetrics(context context.Context, instance
 		util.Logger(context).Info(reason)
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, "")
 		r.notificationCenter.Notify(instance, reason, "")
+		r.eventEmitter.Emit(instance, reason, "")
 		r.markStatusUpdate()
 	}
 }
@@ -137,6 +149,7 @@ func (r *ReconcileExperiment) markRoutingRulesError(context context.Context, ins
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 	}
 }
@@ -147,6 +160,7 @@ func (r *ReconcileExperiment) markRoutingRulesReady(context context.Context, ins
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 	}
 }
@@ -157,6 +171,7 @@ func (r *ReconcileExperiment) markActionPause(context context.Context, instance
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, "")
 		r.notificationCenter.Notify(instance, reason, "")
+		r.eventEmitter.Emit(instance, reason, "")
 		r.markStatusUpdate()
 	}
 }
@@ -167,8 +182,225 @@ func (r *ReconcileExperiment) markActionResume(context context.Context, instance
 		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
 		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
 		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
 		r.markStatusUpdate()
 		// need to refresh the whole flow
 		r.markRefresh()
 	}
 }
+
+func (r *ReconcileExperiment) markExperimentQueued(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkExperimentQueued(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markExperimentDequeued(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkExperimentDequeued(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markAwaitingApproval(context context.Context, instance *iter8v1alpha2.Experiment, gate string,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkAwaitingApproval(gate, messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markApprovalGranted(context context.Context, instance *iter8v1alpha2.Experiment, by string,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkApprovalGranted(by, messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markApprovalRejected(context context.Context, instance *iter8v1alpha2.Experiment, by string,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkApprovalRejected(by, messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markHookSucceeded(context context.Context, instance *iter8v1alpha2.Experiment, hook string,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkHookSucceeded(hook, messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+// markHookFailed also applies the failure policy of the hook
+func (r *ReconcileExperiment) markHookFailed(context context.Context, instance *iter8v1alpha2.Experiment, hook *iter8v1alpha2.Hook,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkHookFailed(hook.Name, messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+		r.onHookFailure(context, instance, hook)
+	}
+}
+
+func (r *ReconcileExperiment) markTargetsChanged(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkTargetsChanged(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markTargetsReady(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkTargetsReady(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markTargetsNotReady(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkTargetsNotReady(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markCandidateCrashLoop(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkCandidateCrashLoop(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markLoadStarted(context context.Context, instance *iter8v1alpha2.Experiment, job string,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkLoadStarted(job, messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markLoadError(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkLoadError(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markGuardrailBreached(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkGuardrailBreached(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markGuardrailError(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkGuardrailError(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+func (r *ReconcileExperiment) markStepUpdate(context context.Context, instance *iter8v1alpha2.Experiment, index int32, restarts map[string]int32,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkStepUpdate(index, restarts, messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+// markHealthCheckFailed also applies the failure policy of the health check
+func (r *ReconcileExperiment) markHealthCheckFailed(context context.Context, instance *iter8v1alpha2.Experiment, check *iter8v1alpha2.HealthCheck,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkHealthCheckFailed(messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+		r.onHealthCheckFailure(context, instance, check)
+	}
+}
+
+func (r *ReconcileExperiment) markReportWritten(context context.Context, instance *iter8v1alpha2.Experiment, ref iter8v1alpha2.ReportRef,
+	messageFormat string, messageA ...interface{}) {
+	if updated, reason := instance.Status.MarkReportWritten(ref, messageFormat, messageA...); updated {
+		util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+		r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, reason, messageFormat, messageA...)
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+		r.markStatusUpdate()
+	}
+}
+
+// markReportError doesn't change the result of the completed experiment; writing the report is retried
+// until no attempt is left, when the failure is notified
+func (r *ReconcileExperiment) markReportError(context context.Context, instance *iter8v1alpha2.Experiment,
+	messageFormat string, messageA ...interface{}) {
+	givenUp, reason := instance.Status.MarkReportError(messageFormat, messageA...)
+	util.Logger(context).Info(reason + ", " + fmt.Sprintf(messageFormat, messageA...))
+	r.eventRecorder.Eventf(instance, corev1.EventTypeWarning, reason, messageFormat, messageA...)
+	if givenUp {
+		r.notificationCenter.Notify(instance, reason, messageFormat, messageA...)
+		r.eventEmitter.Emit(instance, reason, messageFormat, messageA...)
+	}
+	r.markStatusUpdate()
+}
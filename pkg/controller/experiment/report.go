@@ -0,0 +1,61 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/report"
+)
+
+// recordTraffic appends the current traffic split to the traffic history of experiments writing a report
+func (r *ReconcileExperiment) recordTraffic(instance *iter8v1alpha2.Experiment) {
+	if instance.Spec.Report == nil {
+		return
+	}
+	if instance.Status.RecordTrafficSplit() {
+		r.markStatusUpdate()
+	}
+}
+
+// writeReport writes the report of the completed experiment, unless it is already written or given up
+// returns false if the report fails to be written, in which case it is retried at the next reconcile
+// until iter8v1alpha2.MaxReportAttempts attempts fail
+func (r *ReconcileExperiment) writeReport(context context.Context, instance *iter8v1alpha2.Experiment) bool {
+	if instance.Spec.Report == nil || instance.Status.ReportRef != nil || instance.Status.ReportGivenUp() {
+		return true
+	}
+
+	ref, err := report.Write(context, r.Client, r.apiReader, instance)
+	if err != nil {
+		if instance.Status.ReportAttempts+1 >= iter8v1alpha2.MaxReportAttempts {
+			r.markReportError(context, instance, "%v, giving up after %d attempts", err, iter8v1alpha2.MaxReportAttempts)
+		} else {
+			r.markReportError(context, instance, "%v", err)
+		}
+		return instance.Status.ReportGivenUp()
+	}
+
+	location := ""
+	if ref.URL != nil {
+		location = *ref.URL
+	} else if ref.ConfigMap != nil {
+		location = "configmap " + *ref.ConfigMap
+	}
+	r.markReportWritten(context, instance, *ref, "Report written to %s", location)
+	return true
+}
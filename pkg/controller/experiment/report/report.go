@@ -0,0 +1,296 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+// This file contains functions used for rendering the report of a completed iter8 experiment
+// and writing it to a ConfigMap or an object store.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/hooks"
+)
+
+const (
+	// JSONKey is the key of the JSON report in the ConfigMap and the name of the uploaded object
+	JSONKey = "report.json"
+
+	// MarkdownKey is the key of the Markdown report in the ConfigMap and the name of the uploaded object
+	MarkdownKey = "report.md"
+
+	uploadTimeout = 10 * time.Second
+)
+
+// Report is the record of a completed experiment
+type Report struct {
+	Name           string                       `json:"name"`
+	Namespace      string                       `json:"namespace"`
+	Phase          iter8v1alpha2.PhaseType      `json:"phase"`
+	Message        string                       `json:"message,omitempty"`
+	StartTime      *metav1.Time                 `json:"startTime,omitempty"`
+	EndTime        *metav1.Time                 `json:"endTime,omitempty"`
+	Iterations     int32                        `json:"iterations"`
+	Winner         *Winner                      `json:"winner,omitempty"`
+	Spec           iter8v1alpha2.ExperimentSpec `json:"spec"`
+	Assessment     *iter8v1alpha2.Assessment    `json:"assessment,omitempty"`
+	TrafficHistory []iter8v1alpha2.TrafficSplit `json:"trafficHistory,omitempty"`
+}
+
+// Winner is the winner assessment of analytics at the end of the experiment
+type Winner struct {
+	Name        string  `json:"name"`
+	Found       bool    `json:"found"`
+	Probability float32 `json:"probability"`
+}
+
+// New renders the report of the experiment
+func New(instance *iter8v1alpha2.Experiment) *Report {
+	status := instance.Status
+	r := &Report{
+		Name:           instance.Name,
+		Namespace:      instance.Namespace,
+		Phase:          status.Phase,
+		StartTime:      status.StartTimestamp,
+		EndTime:        status.EndTimestamp,
+		Spec:           *sanitize(instance.Spec.DeepCopy()),
+		Assessment:     status.Assessment,
+		TrafficHistory: status.TrafficHistory,
+	}
+	if status.Message != nil {
+		r.Message = *status.Message
+	}
+	if status.CurrentIteration != nil {
+		r.Iterations = *status.CurrentIteration
+	}
+	if status.IsWinnerAssessmentAvailable() && status.Assessment.Winner.Name != nil {
+		r.Winner = &Winner{
+			Name:        *status.Assessment.Winner.Name,
+			Found:       status.Assessment.Winner.WinnerFound,
+			Probability: status.Assessment.Winner.Probability,
+		}
+	}
+	return r
+}
+
+// sanitize removes headers from the spec, which may hold credentials
+func sanitize(spec *iter8v1alpha2.ExperimentSpec) *iter8v1alpha2.ExperimentSpec {
+	for i := range spec.Hooks {
+		if spec.Hooks[i].HTTP != nil {
+			spec.Hooks[i].HTTP.Headers = nil
+		}
+	}
+	if spec.Load != nil {
+		spec.Load.Headers = nil
+	}
+	return spec
+}
+
+// JSON returns the report as indented JSON
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Markdown returns the report as a Markdown document
+func (r *Report) Markdown() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# Experiment %s/%s\n\n", r.Namespace, r.Name)
+	fmt.Fprintf(b, "| | |\n|---|---|\n")
+	if r.Spec.Service.ObjectReference != nil {
+		fmt.Fprintf(b, "| Service | %s |\n", r.Spec.Service.Name)
+	}
+	fmt.Fprintf(b, "| Baseline | %s |\n", r.Spec.Service.Baseline)
+	fmt.Fprintf(b, "| Candidates | %s |\n", strings.Join(r.Spec.Service.Candidates, ", "))
+	fmt.Fprintf(b, "| Start | %s |\n", formatTime(r.StartTime))
+	fmt.Fprintf(b, "| End | %s |\n", formatTime(r.EndTime))
+	fmt.Fprintf(b, "| Iterations | %d/%d |\n", r.Iterations, r.Spec.GetMaxIterations())
+	fmt.Fprintf(b, "| Result | %s |\n", r.Message)
+	if r.Winner != nil && r.Winner.Found {
+		fmt.Fprintf(b, "| Winner | %s (probability %.2f) |\n", r.Winner.Name, r.Winner.Probability)
+	} else {
+		fmt.Fprintf(b, "| Winner | not found |\n")
+	}
+
+	if r.Assessment != nil {
+		versions := append([]iter8v1alpha2.VersionAssessment{r.Assessment.Baseline}, r.Assessment.Candidates...)
+		fmt.Fprintf(b, "\n## Assessment\n\n")
+		fmt.Fprintf(b, "| Version | Traffic | Win probability | Requests | Rollback |\n|---|---|---|---|---|\n")
+		for _, v := range versions {
+			fmt.Fprintf(b, "| %s | %d%% | %.2f | %d | %t |\n", v.Name, v.Weight, v.WinProbability, v.RequestCount, v.Rollback)
+		}
+
+		criteria := &strings.Builder{}
+		for _, v := range versions {
+			for _, ca := range v.CriterionAssessments {
+				value, beating := "", ""
+				if ca.Statistics != nil && ca.Statistics.Value != nil {
+					value = fmt.Sprintf("%g", *ca.Statistics.Value)
+				}
+				if ca.Statistics != nil && ca.Statistics.RatioStatistics != nil {
+					beating = fmt.Sprintf("%.2f", ca.Statistics.RatioStatistics.ProbabilityOfBeatingBaseline)
+				}
+				breached := ""
+				if ca.ThresholdAssessment != nil {
+					breached = fmt.Sprintf("%t", ca.ThresholdAssessment.ThresholdBreached)
+				}
+				fmt.Fprintf(criteria, "| %s | %s | %s | %s | %s |\n", v.Name, ca.MetricID, value, beating, breached)
+			}
+		}
+		if criteria.Len() > 0 {
+			fmt.Fprintf(b, "\n### Criteria\n\n")
+			fmt.Fprintf(b, "| Version | Metric | Value | Probability of beating baseline | Threshold breached |\n|---|---|---|---|---|\n")
+			b.WriteString(criteria.String())
+		}
+	}
+
+	if len(r.TrafficHistory) > 0 {
+		names := make([]string, 0)
+		for name := range r.TrafficHistory[len(r.TrafficHistory)-1].Weights {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(b, "\n## Traffic history\n\n")
+		fmt.Fprintf(b, "| Iteration | Time | %s |\n|---|---|%s\n", strings.Join(names, " | "), strings.Repeat("---|", len(names)))
+		for _, split := range r.TrafficHistory {
+			weights := make([]string, len(names))
+			for i, name := range names {
+				weights[i] = fmt.Sprintf("%d%%", split.Weights[name])
+			}
+			fmt.Fprintf(b, "| %d | %s | %s |\n", split.Iteration, formatTime(&split.Time), strings.Join(weights, " | "))
+		}
+	}
+	return b.String()
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Write writes the report of the experiment to the sink given by the report of its spec
+// An existing ConfigMap is only overwritten if it is labeled with the name of the experiment.
+// The secret holding headers of uploads is read by reader, so that secrets are not cached.
+// returns the reference to the report written
+func Write(ctx context.Context, c client.Client, reader client.Reader, instance *iter8v1alpha2.Experiment) (*iter8v1alpha2.ReportRef, error) {
+	r := New(instance)
+	data, err := r.JSON()
+	if err != nil {
+		return nil, err
+	}
+	markdown := r.Markdown()
+	ref := &iter8v1alpha2.ReportRef{Time: metav1.Now()}
+
+	if instance.Spec.Report != nil && instance.Spec.Report.URL != nil {
+		base := strings.TrimRight(*instance.Spec.Report.URL, "/") + "/" + instance.Namespace + "/" + instance.Name + "/"
+		headers, err := readHeaders(ctx, reader, instance)
+		if err != nil {
+			return nil, err
+		}
+		if err := upload(ctx, base+JSONKey, "application/json", headers, data); err != nil {
+			return nil, fmt.Errorf("Fail to upload %s: %v", JSONKey, err)
+		}
+		if err := upload(ctx, base+MarkdownKey, "text/markdown", headers, []byte(markdown)); err != nil {
+			return nil, fmt.Errorf("Fail to upload %s: %v", MarkdownKey, err)
+		}
+		url := base + JSONKey
+		ref.URL = &url
+		return ref, nil
+	}
+
+	// the ConfigMap is not owned by the experiment, so that the report outlives it
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: instance.GetReportConfigMap(), Namespace: instance.Namespace},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, cm, func() error {
+		// an existing ConfigMap not written for the experiment is left untouched
+		if cm.ResourceVersion != "" && cm.Labels[hooks.ExperimentLabel] != instance.Name {
+			return fmt.Errorf("configmap is not labeled with %s=%s", hooks.ExperimentLabel, instance.Name)
+		}
+		if cm.Labels == nil {
+			cm.Labels = make(map[string]string)
+		}
+		cm.Labels[hooks.ExperimentLabel] = instance.Name
+		cm.Data = map[string]string{
+			JSONKey:     string(data),
+			MarkdownKey: markdown,
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("Fail to write configmap %s: %v", cm.Name, err)
+	}
+	ref.ConfigMap = &cm.Name
+	return ref, nil
+}
+
+// readHeaders returns the headers of upload requests, held by the secret referenced by the report
+func readHeaders(ctx context.Context, reader client.Reader, instance *iter8v1alpha2.Experiment) (map[string]string, error) {
+	ref := instance.Spec.Report.SecretRef
+	if ref == nil {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: ref.Name}
+	if err := reader.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("Fail to get secret %s: %v", key, err)
+	}
+	headers := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		headers[k] = string(v)
+	}
+	return headers, nil
+}
+
+// upload puts the object at url
+func upload(ctx context.Context, url, contentType string, headers map[string]string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
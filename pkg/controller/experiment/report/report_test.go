@@ -0,0 +1,181 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	analyticsv1alpha2 "github.com/iter8-tools/iter8-istio/pkg/analytics/api/v1alpha2"
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/hooks"
+)
+
+func newTestExperiment() *iter8v1alpha2.Experiment {
+	winner, value := "reviews-v2", float32(0.02)
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews-experiment", Namespace: "bookinfo"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews"},
+				Baseline:        "reviews-v1",
+				Candidates:      []string{"reviews-v2"},
+			},
+			Load:   &iter8v1alpha2.Load{Headers: map[string]string{"Authorization": "Bearer secret"}},
+			Report: &iter8v1alpha2.Report{},
+		},
+	}
+	instance.InitStatus()
+	instance.Status.Phase = iter8v1alpha2.PhaseCompleted
+	instance.Status.Assessment = &iter8v1alpha2.Assessment{
+		Baseline: iter8v1alpha2.VersionAssessment{Name: "reviews-v1"},
+		Candidates: []iter8v1alpha2.VersionAssessment{{
+			Name:   "reviews-v2",
+			Weight: 100,
+			VersionAssessment: analyticsv1alpha2.VersionAssessment{
+				CriterionAssessments: []analyticsv1alpha2.CriterionAssessment{{
+					MetricID:   "iter8_error_rate",
+					Statistics: &analyticsv1alpha2.Statistics{Value: &value},
+				}},
+			},
+		}},
+		Winner: &iter8v1alpha2.WinnerAssessment{
+			Name:             &winner,
+			WinnerAssessment: &analyticsv1alpha2.WinnerAssessment{WinnerFound: true, Winner: "reviews-v2", Probability: 0.97},
+		},
+	}
+	instance.Status.RecordTrafficSplit()
+	return instance
+}
+
+func TestNew(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newTestExperiment()
+
+	r := New(instance)
+	g.Expect(r.Winner).To(gomega.Equal(&Winner{Name: "reviews-v2", Found: true, Probability: 0.97}))
+	g.Expect(r.TrafficHistory).To(gomega.HaveLen(1))
+	// headers may hold credentials
+	g.Expect(r.Spec.Load.Headers).To(gomega.BeNil())
+	g.Expect(instance.Spec.Load.Headers).NotTo(gomega.BeNil())
+
+	data, err := r.JSON()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(data)).NotTo(gomega.ContainSubstring("secret"))
+
+	md := r.Markdown()
+	g.Expect(md).To(gomega.HavePrefix("# Experiment bookinfo/reviews-experiment\n"))
+	g.Expect(md).To(gomega.ContainSubstring("| Winner | reviews-v2 (probability 0.97) |"))
+	g.Expect(md).To(gomega.ContainSubstring("| reviews-v2 | iter8_error_rate | 0.02 |  |  |"))
+	g.Expect(md).To(gomega.ContainSubstring("| 0 | "))
+}
+
+func TestWriteConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme)
+	instance := newTestExperiment()
+
+	ref, err := Write(context.Background(), c, c, instance)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(*ref.ConfigMap).To(gomega.Equal("reviews-experiment-report"))
+	g.Expect(ref.URL).To(gomega.BeNil())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "bookinfo", Name: *ref.ConfigMap}, cm)).To(gomega.Succeed())
+	g.Expect(cm.Labels).To(gomega.HaveKeyWithValue(hooks.ExperimentLabel, "reviews-experiment"))
+	g.Expect(cm.OwnerReferences).To(gomega.BeEmpty())
+	g.Expect(cm.Data).To(gomega.HaveKey(MarkdownKey))
+
+	r := &Report{}
+	g.Expect(json.Unmarshal([]byte(cm.Data[JSONKey]), r)).To(gomega.Succeed())
+	g.Expect(r.Name).To(gomega.Equal("reviews-experiment"))
+
+	// the report is overwritten when written again
+	_, err = Write(context.Background(), c, c, instance)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// a ConfigMap not written for the experiment is left untouched
+	configMap := "reviews-config"
+	g.Expect(c.Create(context.Background(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: configMap, Namespace: "bookinfo"},
+		Data:       map[string]string{"key": "value"},
+	})).To(gomega.Succeed())
+	instance.Spec.Report.ConfigMap = &configMap
+	_, err = Write(context.Background(), c, c, instance)
+	g.Expect(err).To(gomega.HaveOccurred())
+	existing := &corev1.ConfigMap{}
+	g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "bookinfo", Name: configMap}, existing)).To(gomega.Succeed())
+	g.Expect(existing.Data).To(gomega.Equal(map[string]string{"key": "value"}))
+	g.Expect(existing.Labels).NotTo(gomega.HaveKey(hooks.ExperimentLabel))
+}
+
+func TestWriteURL(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	uploads := make(map[string]string)
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(gomega.Equal(http.MethodPut))
+		g.Expect(r.Header.Get("Authorization")).To(gomega.Equal("Bearer secret"))
+		body, _ := ioutil.ReadAll(r.Body)
+		uploads[r.URL.Path] = string(body)
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "report-credentials", Namespace: "bookinfo"},
+		Data:       map[string][]byte{"Authorization": []byte("Bearer secret")},
+	})
+
+	instance := newTestExperiment()
+	url := ts.URL + "/reports/"
+	instance.Spec.Report.URL = &url
+	instance.Spec.Report.SecretRef = &corev1.LocalObjectReference{Name: "report-credentials"}
+
+	ref, err := Write(context.Background(), c, c, instance)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(*ref.URL).To(gomega.Equal(ts.URL + "/reports/bookinfo/reviews-experiment/report.json"))
+	g.Expect(uploads).To(gomega.HaveKey("/reports/bookinfo/reviews-experiment/report.json"))
+	g.Expect(uploads["/reports/bookinfo/reviews-experiment/report.md"]).To(gomega.HavePrefix("# Experiment"))
+
+	status = http.StatusForbidden
+	_, err = Write(context.Background(), c, c, instance)
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	// the report isn't uploaded without the headers of the secret
+	status = http.StatusOK
+	uploads = make(map[string]string)
+	instance.Spec.Report.SecretRef.Name = "missing"
+	_, err = Write(context.Background(), c, c, instance)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(uploads).To(gomega.BeEmpty())
+}
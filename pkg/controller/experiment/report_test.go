@@ -0,0 +1,122 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+func TestRecordTraffic(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	r := newApprovalTestReconciler()
+
+	// traffic is recorded only for experiments writing a report
+	instance := newApprovalTestExperiment()
+	r.recordTraffic(instance)
+	g.Expect(instance.Status.TrafficHistory).To(gomega.BeEmpty())
+
+	instance.Spec.Report = &iter8v1alpha2.Report{}
+	r.recordTraffic(instance)
+	r.recordTraffic(instance)
+	g.Expect(instance.Status.TrafficHistory).To(gomega.HaveLen(1))
+	g.Expect(instance.Status.TrafficHistory[0].Weights).To(gomega.Equal(map[string]int32{"reviews-v1": 60, "reviews-v2": 40}))
+
+	instance.Status.Assessment.Baseline.Weight = 0
+	instance.Status.Assessment.Candidates[0].Weight = 100
+	r.recordTraffic(instance)
+	g.Expect(instance.Status.TrafficHistory).To(gomega.HaveLen(2))
+}
+
+func TestWriteReport(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	r := newApprovalTestReconciler()
+	r.Client = fake.NewFakeClientWithScheme(scheme)
+	r.apiReader = r.Client
+
+	instance := newApprovalTestExperiment()
+	g.Expect(r.writeReport(ctx, instance)).To(gomega.BeTrue())
+	g.Expect(instance.Status.ReportRef).To(gomega.BeNil())
+
+	instance.Spec.Report = &iter8v1alpha2.Report{}
+	g.Expect(r.writeReport(ctx, instance)).To(gomega.BeTrue())
+	g.Expect(*instance.Status.ReportRef.ConfigMap).To(gomega.Equal("exp-report"))
+
+	// failure to upload the report is retried without changing the result of the experiment
+	uploads := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploads++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	instance = newApprovalTestExperiment()
+	instance.Spec.Report = &iter8v1alpha2.Report{URL: &ts.URL}
+	g.Expect(r.writeReport(ctx, instance)).To(gomega.BeFalse())
+	g.Expect(instance.Status.ReportRef).To(gomega.BeNil())
+	g.Expect(instance.Status.ReportAttempts).To(gomega.Equal(int32(1)))
+	g.Expect(instance.Status.Message).To(gomega.BeNil())
+
+	// writing the report is given up after the last attempt fails
+	for i := int32(2); i < iter8v1alpha2.MaxReportAttempts; i++ {
+		g.Expect(r.writeReport(ctx, instance)).To(gomega.BeFalse())
+	}
+	g.Expect(r.writeReport(ctx, instance)).To(gomega.BeTrue())
+	g.Expect(instance.Status.ReportGivenUp()).To(gomega.BeTrue())
+	g.Expect(r.writeReport(ctx, instance)).To(gomega.BeTrue())
+	g.Expect(uploads).To(gomega.Equal(int(iter8v1alpha2.MaxReportAttempts)))
+	g.Expect(instance.Status.ReportRef).To(gomega.BeNil())
+}
+
+func TestValidateReport(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	configMap, url := "reviews-report", "https://reports.example.com/iter8"
+
+	instance := newReadinessTestExperiment("reviews-v2")
+	instance.Spec.Report = &iter8v1alpha2.Report{ConfigMap: &configMap}
+	g.Expect(instance.Spec.Validate()).To(gomega.Succeed())
+	g.Expect(instance.GetReportConfigMap()).To(gomega.Equal(configMap))
+
+	instance.Spec.Report.URL = &url
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+
+	url = "s3://reports/iter8"
+	instance.Spec.Report = &iter8v1alpha2.Report{URL: &url}
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+
+	// headers are read from a secret only for uploads
+	url = "https://reports.example.com/iter8"
+	instance.Spec.Report = &iter8v1alpha2.Report{URL: &url, SecretRef: &corev1.LocalObjectReference{Name: "report-credentials"}}
+	g.Expect(instance.Spec.Validate()).To(gomega.Succeed())
+	instance.Spec.Report.SecretRef.Name = ""
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+	instance.Spec.Report = &iter8v1alpha2.Report{SecretRef: &corev1.LocalObjectReference{Name: "report-credentials"}}
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+}
@@ -0,0 +1,133 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istio
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	fuzz "github.com/google/gofuzz"
+	"github.com/onsi/gomega"
+	networkingv1alpha3 "istio.io/api/networking/v1alpha3"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+// newFuzzer returns a fuzzer generating valid string matches, i.e., with exactly one of exact, prefix and regex set
+func newFuzzer() *fuzz.Fuzzer {
+	return fuzz.New().NilChance(0.2).Funcs(
+		func(s *iter8v1alpha2.StringMatch, c fuzz.Continue) {
+			*s = iter8v1alpha2.StringMatch{}
+			v := c.RandString()
+			switch c.Intn(3) {
+			case 0:
+				s.Exact = &v
+			case 1:
+				s.Prefix = &v
+			default:
+				s.Regex = &v
+			}
+		},
+		func(s *networkingv1alpha3.StringMatch, c fuzz.Continue) {
+			*s = networkingv1alpha3.StringMatch{}
+			v := c.RandString()
+			switch c.Intn(3) {
+			case 0:
+				s.MatchType = &networkingv1alpha3.StringMatch_Exact{Exact: v}
+			case 1:
+				s.MatchType = &networkingv1alpha3.StringMatch_Prefix{Prefix: v}
+			default:
+				s.MatchType = &networkingv1alpha3.StringMatch_Regex{Regex: v}
+			}
+		},
+		// fields internal to protobuf are not part of the conversion
+		func(s *networkingv1alpha3.HTTPMatchRequest, c fuzz.Continue) {
+			c.FuzzNoCustom(s)
+			s.XXX_unrecognized, s.XXX_sizecache = nil, 0
+		},
+		func(s *networkingv1alpha3.L4MatchAttributes, c fuzz.Continue) {
+			c.FuzzNoCustom(s)
+			s.XXX_unrecognized, s.XXX_sizecache = nil, 0
+		},
+		func(s *networkingv1alpha3.TLSMatchAttributes, c fuzz.Continue) {
+			c.FuzzNoCustom(s)
+			s.XXX_unrecognized, s.XXX_sizecache = nil, 0
+		},
+	)
+}
+
+func TestHTTPMatchRequestRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	f := newFuzzer()
+
+	for i := 0; i < 200; i++ {
+		in := &iter8v1alpha2.HTTPMatchRequest{}
+		f.Fuzz(in)
+		g.Expect(in.Validate()).To(gomega.Succeed())
+		g.Expect(convertHTTPMatchRequestFromIstio(convertHTTPMatchRequestToIstio(in))).To(gomega.Equal(in))
+
+		istioIn := &networkingv1alpha3.HTTPMatchRequest{}
+		f.Fuzz(istioIn)
+		istioOut := convertHTTPMatchRequestToIstio(convertHTTPMatchRequestFromIstio(istioIn))
+		g.Expect(proto.Equal(istioOut, istioIn)).To(gomega.BeTrue(), "%v != %v", istioOut, istioIn)
+	}
+}
+
+func TestL4MatchAttributesRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	f := newFuzzer()
+
+	for i := 0; i < 200; i++ {
+		in := &iter8v1alpha2.L4MatchAttributes{}
+		f.Fuzz(in)
+		g.Expect(convertL4MatchAttributesFromIstio(convertL4MatchAttributesToIstio(in))).To(gomega.Equal(in))
+
+		istioIn := &networkingv1alpha3.L4MatchAttributes{}
+		f.Fuzz(istioIn)
+		istioOut := convertL4MatchAttributesToIstio(convertL4MatchAttributesFromIstio(istioIn))
+		g.Expect(proto.Equal(istioOut, istioIn)).To(gomega.BeTrue(), "%v != %v", istioOut, istioIn)
+	}
+}
+
+func TestTLSMatchAttributesRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	f := newFuzzer()
+
+	for i := 0; i < 200; i++ {
+		in := &iter8v1alpha2.TLSMatchAttributes{}
+		f.Fuzz(in)
+		g.Expect(convertTLSMatchAttributesFromIstio(convertTLSMatchAttributesToIstio(in))).To(gomega.Equal(in))
+
+		istioIn := &networkingv1alpha3.TLSMatchAttributes{}
+		f.Fuzz(istioIn)
+		istioOut := convertTLSMatchAttributesToIstio(convertTLSMatchAttributesFromIstio(istioIn))
+		g.Expect(proto.Equal(istioOut, istioIn)).To(gomega.BeTrue(), "%v != %v", istioOut, istioIn)
+	}
+}
+
+func TestStringMatchValidation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	a, b := "a", "b"
+	g.Expect((&iter8v1alpha2.StringMatch{}).IsValid()).To(gomega.BeFalse())
+	g.Expect((&iter8v1alpha2.StringMatch{Exact: &a}).IsValid()).To(gomega.BeTrue())
+	g.Expect((&iter8v1alpha2.StringMatch{Exact: &a, Prefix: &b}).IsValid()).To(gomega.BeFalse())
+
+	m := &iter8v1alpha2.HTTPMatchRequest{
+		WithoutHeaders: map[string]iter8v1alpha2.StringMatch{"x-test": {Prefix: &a, Regex: &b}},
+	}
+	g.Expect(m.Validate()).NotTo(gomega.Succeed())
+}
@@ -61,7 +61,7 @@ func (h deploymentHandler) validateAndInit(drl *v1alpha3.DestinationRuleList, vs
 						out.destinationRule = drl.Items[0].DeepCopy()
 						out.virtualService = vsl.Items[0].DeepCopy()
 					} else {
-						return nil, fmt.Errorf("Progressing rules being involved in other experiments")
+						return nil, util.NewConflictError("Progressing rules being involved in other experiments")
 					}
 				} else {
 					return nil, fmt.Errorf("Experiment label missing in dr or vs")
@@ -82,6 +82,18 @@ func (h deploymentHandler) validateAndInit(drl *v1alpha3.DestinationRuleList, vs
 		} else {
 			return nil, fmt.Errorf("0 dr and 1 unstable vs found")
 		}
+	} else if len(drl.Items) == 1 && len(vsl.Items) == 0 {
+		drrole, drok := drl.Items[0].GetLabels()[experimentRole]
+		if drok && drrole == roleStable {
+			// Valid stable rules detected
+			name := GetRoutingRuleName(getRouterID(instance))
+			out.destinationRule = drl.Items[0].DeepCopy()
+			out.virtualService = NewVirtualService(name, expFullName, svcNamespace).
+				WithInitLabel().
+				Build()
+		} else {
+			return nil, fmt.Errorf("1 unstable dr and 0 vs found")
+		}
 	} else {
 		return nil, fmt.Errorf("%d dr and %d vs detected", len(drl.Items), len(vsl.Items))
 	}
@@ -16,7 +16,11 @@ package istio
 
 // This file contains helper functions for composing istio routing rules
 
+//go:generate go run ../../../../../../hack/conversion-gen -o zz_generated.conversion.go -h ../../../../../../hack/boilerplate.go.txt
+
 import (
+	"strconv"
+
 	networkingv1alpha3 "istio.io/api/networking/v1alpha3"
 	"istio.io/client-go/pkg/apis/networking/v1alpha3"
 	appsv1 "k8s.io/api/apps/v1"
@@ -147,13 +151,75 @@ func (b *DestinationRuleBuilder) InitSubsets() *DestinationRuleBuilder {
 	return b
 }
 
+// RemoveIter8Subsets removes subsets managed by iter8 while keeping user-defined ones
+func (b *DestinationRuleBuilder) RemoveIter8Subsets() *DestinationRuleBuilder {
+	subsets := make([]*networkingv1alpha3.Subset, 0, len(b.Spec.Subsets))
+	for _, subset := range b.Spec.Subsets {
+		if !isIter8Subset(subset.Name) {
+			subsets = append(subsets, subset)
+		}
+	}
+	b.Spec.Subsets = subsets
+	return b
+}
+
 // WithSubset converts stable dr to progressing dr
+// subset with the same name is replaced if already exists
 func (b *DestinationRuleBuilder) WithSubset(d *appsv1.Deployment, subsetName string) *DestinationRuleBuilder {
-	b.Spec.Subsets = append(b.Spec.Subsets, &networkingv1alpha3.Subset{
-		Name:   subsetName,
-		Labels: d.Spec.Template.ObjectMeta.Labels,
-	})
+	return b.WithSubsetPolicy(d, subsetName, nil)
+}
+
+// WithSubsetPolicy adds subset of the deployment with trafficPolicy applied to the subset
+// subset with the same name is replaced if already exists
+func (b *DestinationRuleBuilder) WithSubsetPolicy(d *appsv1.Deployment, subsetName string, policy *networkingv1alpha3.TrafficPolicy) *DestinationRuleBuilder {
+	subset := &networkingv1alpha3.Subset{
+		Name:          subsetName,
+		Labels:        d.Spec.Template.ObjectMeta.Labels,
+		TrafficPolicy: policy,
+	}
+
+	for i := range b.Spec.Subsets {
+		if b.Spec.Subsets[i].Name == subsetName {
+			b.Spec.Subsets[i] = subset
+			return b
+		}
+	}
+	b.Spec.Subsets = append(b.Spec.Subsets, subset)
+	return b
+}
+
+func (b *DestinationRuleBuilder) WithAdoptedLabel() *DestinationRuleBuilder {
+	if b.ObjectMeta.GetLabels() == nil {
+		b.ObjectMeta.SetLabels(map[string]string{})
+	}
+	b.ObjectMeta.Labels[experimentAdopted] = "True"
+	return b
+}
+
+// WithOriginalSpec saves the serialized spec of the destinationrule before adoption in annotation
+func (b *DestinationRuleBuilder) WithOriginalSpec(spec string) *DestinationRuleBuilder {
+	if b.ObjectMeta.GetAnnotations() == nil {
+		b.ObjectMeta.SetAnnotations(map[string]string{})
+	}
+	b.ObjectMeta.Annotations[originalSpecAnnotation] = spec
+	return b
+}
+
+// RemoveAdoptedLabel hands the destinationrule over to iter8, so that it is no longer restored
+func (b *DestinationRuleBuilder) RemoveAdoptedLabel() *DestinationRuleBuilder {
+	delete(b.ObjectMeta.Labels, experimentAdopted)
+	return b
+}
+
+// RemoveRouterLabels removes all the labels put by iter8 router
+func (b *DestinationRuleBuilder) RemoveRouterLabels() *DestinationRuleBuilder {
+	if b.ObjectMeta.Labels == nil {
+		return b
+	}
 
+	for _, key := range []string{routerID, experimentRole, experimentLabel, experimentInit, experimentAdopted} {
+		delete(b.ObjectMeta.Labels, key)
+	}
 	return b
 }
 
@@ -255,12 +321,56 @@ func (b *VirtualServiceBuilder) RemoveExperimentLabel() *VirtualServiceBuilder {
 	return b
 }
 
+func (b *VirtualServiceBuilder) WithAdoptedLabel() *VirtualServiceBuilder {
+	if b.ObjectMeta.GetLabels() == nil {
+		b.ObjectMeta.SetLabels(map[string]string{})
+	}
+	b.ObjectMeta.Labels[experimentAdopted] = "True"
+	return b
+}
+
+// WithOriginalSpec saves the serialized spec of the virtualservice before adoption in annotation
+func (b *VirtualServiceBuilder) WithOriginalSpec(spec string) *VirtualServiceBuilder {
+	if b.ObjectMeta.GetAnnotations() == nil {
+		b.ObjectMeta.SetAnnotations(map[string]string{})
+	}
+	b.ObjectMeta.Annotations[originalSpecAnnotation] = spec
+	return b
+}
+
+// RemoveRouterLabels removes all the labels put by iter8 router
+func (b *VirtualServiceBuilder) RemoveRouterLabels() *VirtualServiceBuilder {
+	if b.ObjectMeta.Labels == nil {
+		return b
+	}
+
+	for _, key := range []string{routerID, experimentRole, experimentLabel, experimentInit, experimentAdopted} {
+		delete(b.ObjectMeta.Labels, key)
+	}
+	return b
+}
+
 // WithHTTPRoute adds route to http route list
 func (b *VirtualServiceBuilder) WithHTTPRoute(route *networkingv1alpha3.HTTPRoute) *VirtualServiceBuilder {
 	b.Spec.Http = append(b.Spec.Http, route)
 	return b
 }
 
+// WithHTTPRouteAt inserts route in front of the http route at index idx
+// route is appended to the list if idx is out of range
+func (b *VirtualServiceBuilder) WithHTTPRouteAt(route *networkingv1alpha3.HTTPRoute, idx int) *VirtualServiceBuilder {
+	if idx < 0 || idx >= len(b.Spec.Http) {
+		return b.WithHTTPRoute(route)
+	}
+
+	routes := make([]*networkingv1alpha3.HTTPRoute, 0, len(b.Spec.Http)+1)
+	routes = append(routes, b.Spec.Http[:idx]...)
+	routes = append(routes, route)
+	routes = append(routes, b.Spec.Http[idx:]...)
+	b.Spec.Http = routes
+	return b
+}
+
 func (b *VirtualServiceBuilder) InitGateways() *VirtualServiceBuilder {
 	b.Spec.Gateways = []string{}
 	return b
@@ -281,6 +391,76 @@ func (b *VirtualServiceBuilder) InitHTTPRoutes() *VirtualServiceBuilder {
 	return b
 }
 
+func (b *VirtualServiceBuilder) InitTCPRoutes() *VirtualServiceBuilder {
+	b.Spec.Tcp = []*networkingv1alpha3.TCPRoute{}
+	return b
+}
+
+func (b *VirtualServiceBuilder) InitTLSRoutes() *VirtualServiceBuilder {
+	b.Spec.Tls = []*networkingv1alpha3.TLSRoute{}
+	return b
+}
+
+// WithTCPRoute adds route to tcp route list
+func (b *VirtualServiceBuilder) WithTCPRoute(route *networkingv1alpha3.TCPRoute) *VirtualServiceBuilder {
+	b.Spec.Tcp = append(b.Spec.Tcp, route)
+	return b
+}
+
+// WithTCPRouteAt inserts route in front of the tcp route at index idx and records it as the experiment route
+// route is appended to the list if idx is out of range
+func (b *VirtualServiceBuilder) WithTCPRouteAt(route *networkingv1alpha3.TCPRoute, idx int) *VirtualServiceBuilder {
+	if idx < 0 || idx >= len(b.Spec.Tcp) {
+		idx = len(b.Spec.Tcp)
+	}
+
+	routes := make([]*networkingv1alpha3.TCPRoute, 0, len(b.Spec.Tcp)+1)
+	routes = append(routes, b.Spec.Tcp[:idx]...)
+	routes = append(routes, route)
+	routes = append(routes, b.Spec.Tcp[idx:]...)
+	b.Spec.Tcp = routes
+	return b.WithExperimentRouteIndex(idx)
+}
+
+// WithTLSRoute adds route to tls route list
+func (b *VirtualServiceBuilder) WithTLSRoute(route *networkingv1alpha3.TLSRoute) *VirtualServiceBuilder {
+	b.Spec.Tls = append(b.Spec.Tls, route)
+	return b
+}
+
+// WithTLSRouteAt inserts route in front of the tls route at index idx and records it as the experiment route
+// route is appended to the list if idx is out of range
+func (b *VirtualServiceBuilder) WithTLSRouteAt(route *networkingv1alpha3.TLSRoute, idx int) *VirtualServiceBuilder {
+	if idx < 0 || idx >= len(b.Spec.Tls) {
+		idx = len(b.Spec.Tls)
+	}
+
+	routes := make([]*networkingv1alpha3.TLSRoute, 0, len(b.Spec.Tls)+1)
+	routes = append(routes, b.Spec.Tls[:idx]...)
+	routes = append(routes, route)
+	routes = append(routes, b.Spec.Tls[idx:]...)
+	b.Spec.Tls = routes
+	return b.WithExperimentRouteIndex(idx)
+}
+
+// WithExperimentRouteIndex records index of the experiment route in annotation
+// tcp and tls routes have no name to identify the experiment route
+func (b *VirtualServiceBuilder) WithExperimentRouteIndex(idx int) *VirtualServiceBuilder {
+	if b.ObjectMeta.GetAnnotations() == nil {
+		b.ObjectMeta.SetAnnotations(map[string]string{})
+	}
+	b.ObjectMeta.Annotations[experimentRouteAnnotation] = strconv.Itoa(idx)
+	return b
+}
+
+// RemoveExperimentRouteIndex removes the annotation recording index of the experiment route
+func (b *VirtualServiceBuilder) RemoveExperimentRouteIndex() *VirtualServiceBuilder {
+	if b.ObjectMeta.Annotations != nil {
+		delete(b.ObjectMeta.Annotations, experimentRouteAnnotation)
+	}
+	return b
+}
+
 func (b *VirtualServiceBuilder) WithGateways(gws []string) *VirtualServiceBuilder {
 	b.Spec.Gateways = append(b.Spec.Gateways, gws...)
 	return b
@@ -303,28 +483,42 @@ func (b *VirtualServiceBuilder) Build() *v1alpha3.VirtualService {
 	return (*v1alpha3.VirtualService)(b)
 }
 
-func convertMatchToIstio(m *iter8v1alpha2.HTTPMatchRequest) *networkingv1alpha3.HTTPMatchRequest {
+// convertGRPCMatchToIstio converts grpc match into http match on the path and content-type of grpc requests
+func convertGRPCMatchToIstio(m *iter8v1alpha2.GRPCMatchRequest) *networkingv1alpha3.HTTPMatchRequest {
 	out := &networkingv1alpha3.HTTPMatchRequest{
-		Name:          m.Name,
-		Port:          m.Port,
-		IgnoreUriCase: m.IgnoreURICase,
+		Method: &networkingv1alpha3.StringMatch{
+			MatchType: &networkingv1alpha3.StringMatch_Exact{Exact: "POST"},
+		},
+		Headers: map[string]*networkingv1alpha3.StringMatch{
+			"content-type": {
+				MatchType: &networkingv1alpha3.StringMatch_Prefix{Prefix: "application/grpc"},
+			},
+		},
 	}
 
-	if m.URI != nil && m.URI.IsValid() {
-		out.Uri = toStringMatch(m.URI)
+	if m.Method != "" {
+		out.Uri = &networkingv1alpha3.StringMatch{
+			MatchType: &networkingv1alpha3.StringMatch_Exact{Exact: "/" + m.Service + "/" + m.Method},
+		}
+	} else {
+		out.Uri = &networkingv1alpha3.StringMatch{
+			MatchType: &networkingv1alpha3.StringMatch_Prefix{Prefix: "/" + m.Service + "/"},
+		}
 	}
 
-	if m.Headers != nil {
-		out.Headers = make(map[string]*networkingv1alpha3.StringMatch)
-		for key, header := range m.Headers {
-			out.Headers[key] = toStringMatch(&header)
-		}
+	for key, header := range m.Headers {
+		out.Headers[key] = toStringMatch(&header)
 	}
 
 	return out
 }
 
+// toStringMatch converts string match into istio string match
+// nil is returned if none of exact, prefix and regex is set
 func toStringMatch(s *iter8v1alpha2.StringMatch) *networkingv1alpha3.StringMatch {
+	if s == nil {
+		return nil
+	}
 	if s.Exact != nil {
 		return &networkingv1alpha3.StringMatch{
 			MatchType: &networkingv1alpha3.StringMatch_Exact{
@@ -337,11 +531,29 @@ func toStringMatch(s *iter8v1alpha2.StringMatch) *networkingv1alpha3.StringMatch
 				Prefix: *(s.Prefix)},
 		}
 	}
+	if s.Regex != nil {
+		return &networkingv1alpha3.StringMatch{
+			MatchType: &networkingv1alpha3.StringMatch_Regex{
+				Regex: *(s.Regex)},
+		}
+	}
+	return nil
+}
 
-	return &networkingv1alpha3.StringMatch{
-		MatchType: &networkingv1alpha3.StringMatch_Regex{
-			Regex: *(s.Regex)},
+// fromStringMatch converts istio string match into string match
+func fromStringMatch(s *networkingv1alpha3.StringMatch) *iter8v1alpha2.StringMatch {
+	if s == nil {
+		return nil
+	}
+	switch m := s.MatchType.(type) {
+	case *networkingv1alpha3.StringMatch_Exact:
+		return &iter8v1alpha2.StringMatch{Exact: &m.Exact}
+	case *networkingv1alpha3.StringMatch_Prefix:
+		return &iter8v1alpha2.StringMatch{Prefix: &m.Prefix}
+	case *networkingv1alpha3.StringMatch_Regex:
+		return &iter8v1alpha2.StringMatch{Regex: &m.Regex}
 	}
+	return nil
 }
 
 type HTTPRouteBuilder networkingv1alpha3.HTTPRoute
@@ -361,6 +573,20 @@ func (b *HTTPRouteBuilder) WithDestination(d *networkingv1alpha3.HTTPRouteDestin
 	return b
 }
 
+// WithPoliciesFrom copies rewrite, timeout, retries, fault injection, mirroring, cors and header manipulation from route
+func (b *HTTPRouteBuilder) WithPoliciesFrom(route *networkingv1alpha3.HTTPRoute) *HTTPRouteBuilder {
+	src := route.DeepCopy()
+	b.Rewrite = src.Rewrite
+	b.Timeout = src.Timeout
+	b.Retries = src.Retries
+	b.Fault = src.Fault
+	b.Mirror = src.Mirror
+	b.MirrorPercentage = src.MirrorPercentage
+	b.CorsPolicy = src.CorsPolicy
+	b.Headers = src.Headers
+	return b
+}
+
 func (b *HTTPRouteBuilder) ClearRoute() *HTTPRouteBuilder {
 	b.Route = make([]*networkingv1alpha3.HTTPRouteDestination, 0)
 	return b
@@ -368,7 +594,14 @@ func (b *HTTPRouteBuilder) ClearRoute() *HTTPRouteBuilder {
 
 func (b *HTTPRouteBuilder) WithHTTPMatch(httpMatch []*iter8v1alpha2.HTTPMatchRequest) *HTTPRouteBuilder {
 	for _, match := range httpMatch {
-		b.Match = append(b.Match, convertMatchToIstio(match))
+		b.Match = append(b.Match, convertHTTPMatchRequestToIstio(match))
+	}
+	return b
+}
+
+func (b *HTTPRouteBuilder) WithGRPCMatch(grpcMatch []*iter8v1alpha2.GRPCMatchRequest) *HTTPRouteBuilder {
+	for _, match := range grpcMatch {
+		b.Match = append(b.Match, convertGRPCMatchToIstio(match))
 	}
 	return b
 }
@@ -377,6 +610,58 @@ func (b *HTTPRouteBuilder) Build() *networkingv1alpha3.HTTPRoute {
 	return (*networkingv1alpha3.HTTPRoute)(b)
 }
 
+type TCPRouteBuilder networkingv1alpha3.TCPRoute
+
+func NewEmptyTCPRoute() *TCPRouteBuilder {
+	return (*TCPRouteBuilder)(&networkingv1alpha3.TCPRoute{})
+}
+
+func (b *TCPRouteBuilder) WithDestination(d *networkingv1alpha3.HTTPRouteDestination) *TCPRouteBuilder {
+	b.Route = append(b.Route, toRouteDestination(d))
+	return b
+}
+
+func (b *TCPRouteBuilder) WithTCPMatch(tcpMatch []*iter8v1alpha2.L4MatchAttributes) *TCPRouteBuilder {
+	for _, match := range tcpMatch {
+		b.Match = append(b.Match, convertL4MatchAttributesToIstio(match))
+	}
+	return b
+}
+
+func (b *TCPRouteBuilder) Build() *networkingv1alpha3.TCPRoute {
+	return (*networkingv1alpha3.TCPRoute)(b)
+}
+
+type TLSRouteBuilder networkingv1alpha3.TLSRoute
+
+func NewEmptyTLSRoute() *TLSRouteBuilder {
+	return (*TLSRouteBuilder)(&networkingv1alpha3.TLSRoute{})
+}
+
+func (b *TLSRouteBuilder) WithDestination(d *networkingv1alpha3.HTTPRouteDestination) *TLSRouteBuilder {
+	b.Route = append(b.Route, toRouteDestination(d))
+	return b
+}
+
+func (b *TLSRouteBuilder) WithTLSMatch(tlsMatch []*iter8v1alpha2.TLSMatchAttributes) *TLSRouteBuilder {
+	for _, match := range tlsMatch {
+		b.Match = append(b.Match, convertTLSMatchAttributesToIstio(match))
+	}
+	return b
+}
+
+func (b *TLSRouteBuilder) Build() *networkingv1alpha3.TLSRoute {
+	return (*networkingv1alpha3.TLSRoute)(b)
+}
+
+// toRouteDestination converts http route destination into the destination of tcp/tls routes
+func toRouteDestination(d *networkingv1alpha3.HTTPRouteDestination) *networkingv1alpha3.RouteDestination {
+	return &networkingv1alpha3.RouteDestination{
+		Destination: d.Destination,
+		Weight:      d.Weight,
+	}
+}
+
 type HTTPRouteDestinationBuilder networkingv1alpha3.HTTPRouteDestination
 
 func NewHTTPRouteDestination() *HTTPRouteDestinationBuilder {
@@ -0,0 +1,203 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istio
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+// leaseHolder returns the identity of the experiment used as holder of the router lease
+func leaseHolder(instance *iter8v1alpha2.Experiment) string {
+	return instance.Namespace + "/" + instance.Name
+}
+
+// leaseKey returns the namespaced name of the lease guarding the router of the experiment
+func leaseKey(instance *iter8v1alpha2.Experiment) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: instance.ServiceNamespace(),
+		Name:      GetRoutingRuleName(getRouterID(instance)),
+	}
+}
+
+// acquireLease makes the experiment the only owner of its router
+// Returns true if the lease is taken over from an experiment which is completed or deleted,
+// in which case the routing rules may still be labeled by the previous holder.
+// A ConflictError is returned if the lease is held by another running experiment.
+func (r *Router) acquireLease(ctx context.Context, instance *iter8v1alpha2.Experiment) (bool, error) {
+	holder := leaseHolder(instance)
+	now := metav1.NewMicroTime(time.Now())
+
+	lease := &coordinationv1.Lease{}
+	err := r.kubeClient.Get(ctx, leaseKey(instance), lease)
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      leaseKey(instance).Name,
+				Namespace: leaseKey(instance).Namespace,
+				Labels: map[string]string{
+					routerID: getRouterID(instance),
+				},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity: &holder,
+				AcquireTime:    &now,
+				RenewTime:      &now,
+			},
+		}
+		if err = r.kubeClient.Create(ctx, lease); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return false, util.NewConflictError("Router %s is being acquired by other experiment", lease.Name)
+			}
+			return false, err
+		}
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == holder {
+		lease.Spec.RenewTime = &now
+		if err = r.kubeClient.Update(ctx, lease); err != nil && !errors.IsConflict(err) {
+			return false, err
+		}
+		return false, nil
+	}
+
+	if lease.Spec.HolderIdentity != nil {
+		active, err := r.isActiveHolder(ctx, *lease.Spec.HolderIdentity)
+		if err != nil {
+			return false, err
+		}
+		if active {
+			return false, util.NewConflictError("Router %s is held by experiment %s", lease.Name, *lease.Spec.HolderIdentity)
+		}
+	}
+
+	// previous holder is gone; the update is conditioned on resourceVersion
+	// so that only one of the competing experiments can take over the lease
+	transitions := int32(1)
+	if lease.Spec.LeaseTransitions != nil {
+		transitions = *lease.Spec.LeaseTransitions + 1
+	}
+	lease.Spec.HolderIdentity = &holder
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+	lease.Spec.LeaseTransitions = &transitions
+	if err = r.kubeClient.Update(ctx, lease); err != nil {
+		if errors.IsConflict(err) {
+			return false, util.NewConflictError("Router %s is being acquired by other experiment", lease.Name)
+		}
+		return false, err
+	}
+	r.logger.Info("RouterLeaseTakenOver", "lease", lease.Name, "holder", holder)
+	return true, nil
+}
+
+// isActiveHolder tells whether the experiment holding the lease is still running
+func (r *Router) isActiveHolder(ctx context.Context, holder string) (bool, error) {
+	parts := strings.SplitN(holder, "/", 2)
+	if len(parts) != 2 {
+		return false, nil
+	}
+
+	exp := &iter8v1alpha2.Experiment{}
+	err := r.kubeClient.Get(ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, exp)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return !exp.Status.ExperimentCompleted() && exp.DeletionTimestamp == nil, nil
+}
+
+// releaseLease deletes the lease of the router if it is held by the experiment
+func (r *Router) releaseLease(ctx context.Context, instance *iter8v1alpha2.Experiment) error {
+	lease := &coordinationv1.Lease{}
+	if err := r.kubeClient.Get(ctx, leaseKey(instance), lease); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != leaseHolder(instance) {
+		return nil
+	}
+
+	err := r.kubeClient.Delete(ctx, lease, &client.DeleteOptions{
+		Preconditions: &metav1.Preconditions{
+			UID:             &lease.UID,
+			ResourceVersion: &lease.ResourceVersion,
+		},
+	})
+	return client.IgnoreNotFound(err)
+}
+
+// resetStaleRules clears the state left in routing rules by the previous holder of the router
+// Adopted rules are reverted to the user-defined spec and adopted again by the experiment;
+// other rules are set back to stable.
+func resetStaleRules(drl *v1alpha3.DestinationRuleList, vsl *v1alpha3.VirtualServiceList, instance *iter8v1alpha2.Experiment) error {
+	for i := range vsl.Items {
+		vs := &vsl.Items[i]
+		if isAdoptedRule(vs.GetLabels()) {
+			restored, err := restoreVirtualService(vs)
+			if err != nil {
+				return err
+			}
+			adopted, err := adoptVirtualService(restored, instance)
+			if err != nil {
+				return err
+			}
+			vsl.Items[i] = *adopted
+		} else {
+			vsl.Items[i] = *NewVirtualServiceBuilder(vs.DeepCopy()).
+				WithStableLabel().
+				RemoveExperimentLabel().
+				Build()
+		}
+	}
+
+	for i := range drl.Items {
+		dr := &drl.Items[i]
+		if isAdoptedRule(dr.GetLabels()) {
+			restored, err := restoreDestinationRule(dr)
+			if err != nil {
+				return err
+			}
+			adopted, err := adoptDestinationRule(restored, instance)
+			if err != nil {
+				return err
+			}
+			drl.Items[i] = *adopted
+		} else {
+			drl.Items[i] = *NewDestinationRuleBuilder(dr.DeepCopy()).
+				WithStableLabel().
+				RemoveExperimentLabel().
+				Build()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+func newLeaseTestRouter(objs ...runtime.Object) *Router {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = iter8v1alpha2.AddToScheme(scheme)
+	return &Router{
+		kubeClient: fake.NewFakeClientWithScheme(scheme, objs...),
+		logger:     logf.Log,
+	}
+}
+
+func TestAcquireAndReleaseLease(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+
+	first := newTestExperiment()
+	second := newTestExperiment()
+	second.Name = "exp-2"
+	r := newLeaseTestRouter(first, second)
+
+	takeover, err := r.acquireLease(ctx, first)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(takeover).To(gomega.BeFalse())
+
+	// renewal by the holder
+	_, err = r.acquireLease(ctx, first)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// router is held by a running experiment
+	_, err = r.acquireLease(ctx, second)
+	g.Expect(util.IsConflict(err)).To(gomega.BeTrue())
+
+	// only the holder can release the lease
+	g.Expect(r.releaseLease(ctx, second)).To(gomega.Succeed())
+	lease := &coordinationv1.Lease{}
+	g.Expect(r.kubeClient.Get(ctx, leaseKey(first), lease)).To(gomega.Succeed())
+
+	g.Expect(r.releaseLease(ctx, first)).To(gomega.Succeed())
+	_, err = r.acquireLease(ctx, second)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(r.kubeClient.Get(ctx, leaseKey(second), lease)).To(gomega.Succeed())
+	g.Expect(*lease.Spec.HolderIdentity).To(gomega.Equal(leaseHolder(second)))
+}
+
+func TestTakeOverLeaseOfCompletedExperiment(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+
+	first := newTestExperiment()
+	second := newTestExperiment()
+	second.Name = "exp-2"
+	r := newLeaseTestRouter(first, second)
+
+	_, err := r.acquireLease(ctx, first)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	first.InitStatus()
+	first.Status.MarkExperimentCompleted("")
+	g.Expect(r.kubeClient.Update(ctx, first)).To(gomega.Succeed())
+
+	takeover, err := r.acquireLease(ctx, second)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(takeover).To(gomega.BeTrue())
+
+	lease := &coordinationv1.Lease{}
+	g.Expect(r.kubeClient.Get(ctx, leaseKey(second), lease)).To(gomega.Succeed())
+	g.Expect(*lease.Spec.HolderIdentity).To(gomega.Equal(leaseHolder(second)))
+	g.Expect(*lease.Spec.LeaseTransitions).To(gomega.Equal(int32(1)))
+}
+
+func TestResetStaleRules(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newTestExperiment()
+
+	vs := NewVirtualService("reviews", "other.default", "default").
+		WithProgressingLabel().
+		Build()
+	vsl := &v1alpha3.VirtualServiceList{Items: []v1alpha3.VirtualService{*vs}}
+	dr := NewDestinationRule("reviews", "reviews", "other.default", "default").
+		WithProgressingLabel().
+		Build()
+	drl := &v1alpha3.DestinationRuleList{Items: []v1alpha3.DestinationRule{*dr}}
+
+	g.Expect(resetStaleRules(drl, vsl, instance)).To(gomega.Succeed())
+	g.Expect(vsl.Items[0].GetLabels()[experimentRole]).To(gomega.Equal(roleStable))
+	g.Expect(vsl.Items[0].GetLabels()).NotTo(gomega.HaveKey(experimentLabel))
+	g.Expect(drl.Items[0].GetLabels()[experimentRole]).To(gomega.Equal(roleStable))
+	g.Expect(drl.Items[0].GetLabels()).NotTo(gomega.HaveKey(experimentLabel))
+}
@@ -17,8 +17,10 @@ package istio
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	networkingv1alpha3 "istio.io/api/networking/v1alpha3"
@@ -28,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
 	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/routing/router"
@@ -51,6 +54,13 @@ const (
 	experimentInit  = "iter8-tools/init"
 	experimentRole  = "iter8-tools/role"
 	experimentLabel = "iter8-tools/experiment"
+	// label marking routing rules adopted from an existing user configuration
+	experimentAdopted = "iter8-tools/adopted"
+
+	// annotation holding the spec of an adopted routing rule before iter8 took it over
+	originalSpecAnnotation = "iter8-tools/original-spec"
+	// annotation holding the index of experiment route in tcp/tls routes
+	experimentRouteAnnotation = "iter8-tools/experiment-route"
 
 	// Kiali labels
 	kialiWizard          = "kiali_wizard"
@@ -91,6 +101,14 @@ func (r *istioRoutingRules) isInitializing() bool {
 	})
 }
 
+// isAdopted tells whether the virtualservice is an existing user-defined one adopted by iter8
+func (r *istioRoutingRules) isAdopted() bool {
+	if r.virtualService == nil {
+		return false
+	}
+	return isAdoptedRule(r.virtualService.GetLabels())
+}
+
 // check if labels are in routing rules or not
 func (r *istioRoutingRules) haveLabels(labels map[string]string) bool {
 	for key, val := range labels {
@@ -134,17 +152,19 @@ func (r *istioRoutingRules) print() string {
 
 // Router is a router using istio routing rules
 type Router struct {
-	client  istioclient.Interface
-	rules   *istioRoutingRules
-	handler destinationHandler
-	logger  logr.Logger
+	client     istioclient.Interface
+	kubeClient client.Client
+	rules      *istioRoutingRules
+	handler    destinationHandler
+	logger     logr.Logger
 }
 
 // GetRouter returns an instance of istio router
 func GetRouter(ctx context.Context, instance *iter8v1alpha2.Experiment) router.Interface {
 	out := Router{
-		client: ctx.Value(util.IstioClientKey).(istioclient.Interface),
-		logger: util.Logger(ctx),
+		client:     ctx.Value(util.IstioClientKey).(istioclient.Interface),
+		kubeClient: ctx.Value(util.KubeClientKey).(client.Client),
+		logger:     util.Logger(ctx),
 	}
 
 	switch instance.Spec.Service.Kind {
@@ -164,6 +184,12 @@ func (r *Router) Print() string {
 
 // Fetch routing rules from cluster
 func (r *Router) Fetch(ctx context.Context, instance *iter8v1alpha2.Experiment) error {
+	// the router is shared by experiments with the same router id; only the holder of its lease may proceed
+	takeover, err := r.acquireLease(ctx, instance)
+	if err != nil {
+		return err
+	}
+
 	selector := map[string]string{
 		routerID: getRouterID(instance)}
 
@@ -173,12 +199,40 @@ func (r *Router) Fetch(ctx context.Context, instance *iter8v1alpha2.Experiment)
 		return err
 	}
 
+	if len(drl.Items) == 0 && r.handler.requireDestinationRule() {
+		// no destinationrule registered to the router; adopt an existing one of the host if any
+		dr, err := r.findDestinationRuleToAdopt(ctx, instance)
+		if err != nil {
+			return err
+		}
+		if dr != nil {
+			drl.Items = append(drl.Items, *dr)
+		}
+	}
+
 	vsl, err := r.client.NetworkingV1alpha3().VirtualServices(instance.ServiceNamespace()).
 		List(ctx, metav1.ListOptions{LabelSelector: labels.Set(selector).String()})
 	if err != nil {
 		return err
 	}
 
+	if len(vsl.Items) == 0 {
+		// no virtualservice registered to the router; adopt an existing one of the host if any
+		vs, err := r.findVirtualServiceToAdopt(ctx, instance)
+		if err != nil {
+			return err
+		}
+		if vs != nil {
+			vsl.Items = append(vsl.Items, *vs)
+		}
+	}
+
+	if takeover {
+		if err = resetStaleRules(drl, vsl, instance); err != nil {
+			return err
+		}
+	}
+
 	rules, err := r.handler.validateAndInit(drl, vsl, instance)
 	if err != nil {
 		return err
@@ -198,61 +252,60 @@ func (r *Router) UpdateRouteWithBaseline(ctx context.Context, instance *iter8v1a
 		WithExperimentRegistered(util.FullExperimentName(instance)).
 		WithRouterRegistered(getRouterID(instance)).
 		WithInitializingLabel().
-		RemoveKialiLabel().
-		InitGateways().
-		InitHosts().
-		InitHTTPRoutes()
-
-	// inject internal host
-	if service.Name != "" {
-		vsb = vsb.
-			WithHosts([]string{util.ServiceToFullHostName(service.Name, instance.ServiceNamespace())}).
-			WithMeshGateway()
-	}
+		RemoveKialiLabel()
 
-	if nwk := instance.Spec.Networking; nwk != nil {
-		// inject external hosts
-		mHosts, mGateways := make(map[string]bool), make(map[string]bool)
-		hosts, gateways := make([]string, 0), make([]string, 0)
-		for _, host := range nwk.Hosts {
-			if _, ok := mHosts[host.Name]; !ok {
-				hosts = append(hosts, host.Name)
-				mHosts[host.Name] = true
-			}
-
-			if _, ok := mHosts[host.Gateway]; !ok {
-				gateways = append(gateways, host.Gateway)
-				mGateways[host.Gateway] = true
-			}
-		}
-		vsb = vsb.WithHosts(hosts).WithGateways(gateways)
-	}
-
-	experimentRoute := NewEmptyHTTPRoute(routeNameExperiment)
-
-	// inject baseline destination to route
+	// baseline destination of experiment route
 	baselineDestination := r.handler.buildDestination(instance, destinationOptions{
 		name:   service.Baseline,
 		weight: 100,
 		subset: SubsetBaseline,
 		port:   service.Port,
 	})
-	experimentRoute = experimentRoute.WithDestination(baselineDestination)
 
-	// inject match clauses to route
-	trafficControl := instance.Spec.TrafficControl
-	if trafficControl != nil && trafficControl.Match != nil && len(trafficControl.Match.HTTP) > 0 {
-		experimentRoute = experimentRoute.WithHTTPMatch(trafficControl.Match.HTTP)
+	if !r.rules.isAdopted() {
+		vsb = vsb.
+			InitGateways().
+			InitHosts().
+			InitHTTPRoutes().
+			InitTCPRoutes().
+			InitTLSRoutes()
+
+		// inject internal host
+		if service.Name != "" {
+			vsb = vsb.
+				WithHosts([]string{util.ServiceToFullHostName(service.Name, instance.ServiceNamespace())}).
+				WithMeshGateway()
+		}
+
+		if nwk := instance.Spec.Networking; nwk != nil {
+			// inject external hosts
+			mHosts, mGateways := make(map[string]bool), make(map[string]bool)
+			hosts, gateways := make([]string, 0), make([]string, 0)
+			for _, host := range nwk.Hosts {
+				if _, ok := mHosts[host.Name]; !ok {
+					hosts = append(hosts, host.Name)
+					mHosts[host.Name] = true
+				}
+
+				if _, ok := mHosts[host.Gateway]; !ok {
+					gateways = append(gateways, host.Gateway)
+					mGateways[host.Gateway] = true
+				}
+			}
+			vsb = vsb.WithHosts(hosts).WithGateways(gateways)
+		}
 	}
 
 	// update virtualservice with experiment route
-	vsb = vsb.WithHTTPRoute(experimentRoute.Build())
-
-	// inject base-route if matching clauses exist
-	if trafficControl != nil && trafficControl.Match != nil && len(trafficControl.Match.HTTP) > 0 {
-		baseRoute := NewEmptyHTTPRoute(routeNameBase).WithDestination(baselineDestination)
-		vsb = vsb.WithHTTPRoute(baseRoute.Build())
+	switch instance.Spec.GetProtocol() {
+	case iter8v1alpha2.ProtocolTCP:
+		vsb = r.withTCPExperimentRoute(vsb, getMatch(instance), baselineDestination)
+	case iter8v1alpha2.ProtocolTLS:
+		vsb = r.withTLSExperimentRoute(vsb, getMatch(instance), baselineDestination)
+	default:
+		vsb = r.withHTTPExperimentRoute(vsb, getMatch(instance), baselineDestination)
 	}
+
 	vs := (*v1alpha3.VirtualService)(nil)
 	if _, ok := vsb.GetLabels()[experimentInit]; ok {
 		vs, err = r.client.NetworkingV1alpha3().
@@ -271,9 +324,13 @@ func (r *Router) UpdateRouteWithBaseline(ctx context.Context, instance *iter8v1a
 	// Update destinationrule
 	if r.handler.requireDestinationRule() {
 		dr := (*v1alpha3.DestinationRule)(nil)
+		// user-defined subsets and trafficPolicy are preserved;
+		// baseline subset takes the trafficPolicy of the user-defined subset selecting the same pods
+		d := baseline.(*appsv1.Deployment)
 		drb := NewDestinationRuleBuilder(r.rules.destinationRule).
-			InitSubsets().
-			WithSubset(baseline.(*appsv1.Deployment), SubsetBaseline).
+			RemoveIter8Subsets()
+		drb = drb.
+			WithSubsetPolicy(d, SubsetBaseline, subsetTrafficPolicy(drb.Build(), d.Spec.Template.Labels)).
 			WithInitializingLabel().
 			RemoveKialiLabel().
 			WithRouterRegistered(getRouterID(instance)).
@@ -306,13 +363,15 @@ func (r *Router) UpdateRouteWithCandidates(ctx context.Context, instance *iter8v
 
 	vs := r.rules.virtualService
 
-	route := getExperimentRoute(vs)
-	if route == nil {
-		return fmt.Errorf("Fail to update route with candidates: experiment route missing in vs")
-	}
-	rb := NewHTTPRoute(route)
-
 	service := instance.Spec.Service
+	destinations := []*networkingv1alpha3.HTTPRouteDestination{
+		r.handler.buildDestination(instance, destinationOptions{
+			name:   service.Baseline,
+			weight: 100,
+			subset: SubsetBaseline,
+			port:   service.Port,
+		}),
+	}
 	// update candidates
 	for i, candidate := range instance.Spec.Candidates {
 		destination := r.handler.buildDestination(instance, destinationOptions{
@@ -322,7 +381,11 @@ func (r *Router) UpdateRouteWithCandidates(ctx context.Context, instance *iter8v
 			port:   service.Port,
 		})
 
-		rb = rb.WithDestination(destination)
+		destinations = append(destinations, destination)
+	}
+
+	if !setExperimentDestinations(vs, instance.Spec.GetProtocol(), destinations) {
+		return fmt.Errorf("Fail to update route with candidates: experiment route missing in vs")
 	}
 
 	// update vs to progressing
@@ -341,8 +404,12 @@ func (r *Router) UpdateRouteWithCandidates(ctx context.Context, instance *iter8v
 	// Update destination rule to progressing
 	if r.handler.requireDestinationRule() {
 		drb := NewDestinationRuleBuilder(r.rules.destinationRule)
+		policy := (*networkingv1alpha3.TrafficPolicy)(nil)
+		if instance.Spec.GetInheritTrafficPolicy() {
+			policy = baselineTrafficPolicy(r.rules.destinationRule)
+		}
 		for i, candidate := range candidates {
-			drb = drb.WithSubset(candidate.(*appsv1.Deployment), CandidateSubsetName(i))
+			drb = drb.WithSubsetPolicy(candidate.(*appsv1.Deployment), CandidateSubsetName(i), policy.DeepCopy())
 		}
 
 		dr := drb.WithProgressingLabel().Build()
@@ -361,9 +428,7 @@ func (r *Router) UpdateRouteWithCandidates(ctx context.Context, instance *iter8v
 // UpdateRouteWithTrafficUpdate updates routing rules with new traffic state from assessment
 func (r *Router) UpdateRouteWithTrafficUpdate(ctx context.Context, instance *iter8v1alpha2.Experiment) (err error) {
 	vs := r.rules.virtualService
-	if route := getExperimentRoute(vs); route != nil {
-		r.updateRouteFromExperiment(route, instance)
-	}
+	r.updateRouteFromExperiment(vs, instance)
 
 	vs, err = r.client.NetworkingV1alpha3().VirtualServices(vs.Namespace).Update(ctx, vs, metav1.UpdateOptions{})
 	if err != nil {
@@ -378,10 +443,10 @@ func (r *Router) UpdateRouteWithTrafficUpdate(ctx context.Context, instance *ite
 func (r *Router) UpdateRouteToStable(ctx context.Context, instance *iter8v1alpha2.Experiment) (err error) {
 	if r.rules == nil || !(r.rules.isProgressing() || r.rules.isInitializing()) {
 		r.logger.Info("NoOpInUpdateRouteToStable", "routing rules not initialized", "")
-		return nil
+		return r.releaseLease(ctx, instance)
 	}
 
-	if instance.Spec.GetCleanup() && r.rules.isInit() {
+	if instance.Spec.GetCleanup() && isInitRule(r.rules.virtualService.GetLabels()) {
 		// delete routing rules
 		if err = r.client.NetworkingV1alpha3().VirtualServices(r.rules.virtualService.Namespace).
 			Delete(ctx, r.rules.virtualService.Name, metav1.DeleteOptions{}); err != nil {
@@ -390,29 +455,35 @@ func (r *Router) UpdateRouteToStable(ctx context.Context, instance *iter8v1alpha
 		}
 
 		if r.handler.requireDestinationRule() {
-			if err = r.client.NetworkingV1alpha3().DestinationRules(r.rules.destinationRule.Namespace).
-				Delete(ctx, r.rules.destinationRule.Name, metav1.DeleteOptions{}); err != nil {
-				r.logger.Info("Err in deleting dr", "err", err)
+			if err = r.releaseDestinationRule(ctx, instance); err != nil {
 				return
 			}
 		}
+	} else if r.rules.isAdopted() {
+		// give the adopted virtualservice back to the user
+		vs, err := restoreVirtualService(r.rules.virtualService)
+		if err != nil {
+			return err
+		}
+		if _, err = r.client.NetworkingV1alpha3().
+			VirtualServices(vs.Namespace).
+			Update(ctx, vs, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+
+		if r.handler.requireDestinationRule() {
+			// iter8 subsets are no longer referenced by the restored vs
+			if err = r.releaseDestinationRule(ctx, instance); err != nil {
+				return err
+			}
+		}
 	} else {
 		// only applied to progressing(fully configured) routing rules
 		// otherwise, the routing rule will be remained as its last state
 		vs := r.rules.virtualService
-		if r.rules.isProgressing() {
+		if r.rules.isProgressing() && r.updateRouteFromExperiment(vs, instance) {
 			// retain experiment route only, and rename it to base route
-			route := getExperimentRoute(vs)
-
-			if route != nil {
-				r.updateRouteFromExperiment(route, instance)
-				route.Name = ""
-				route.Match = nil
-				vs = NewVirtualServiceBuilder(vs).
-					InitHTTPRoutes().
-					WithHTTPRoute(route).
-					Build()
-			}
+			vs = retainExperimentRoute(vs, instance.Spec.GetProtocol())
 		}
 
 		// update vs
@@ -428,11 +499,20 @@ func (r *Router) UpdateRouteToStable(ctx context.Context, instance *iter8v1alpha
 
 		// update dr if required
 		if r.handler.requireDestinationRule() {
-			dr := NewDestinationRuleBuilder(r.rules.destinationRule).
+			drb := NewDestinationRuleBuilder(r.rules.destinationRule).
 				WithStableLabel().
 				WithKialiLabel().
-				RemoveExperimentLabel().
-				Build()
+				RemoveExperimentLabel()
+			if isAdoptedRule(r.rules.destinationRule.GetLabels()) {
+				// the retained route refers to iter8 subsets, so the current spec becomes the baseline;
+				// restoring the spec saved before adoption would break the route
+				spec, err := json.Marshal(&r.rules.destinationRule.Spec)
+				if err != nil {
+					return err
+				}
+				drb = drb.WithOriginalSpec(string(spec)).RemoveAdoptedLabel()
+			}
+			dr := drb.Build()
 			if _, err = r.client.NetworkingV1alpha3().
 				DestinationRules(r.rules.destinationRule.Namespace).
 				Update(ctx, dr, metav1.UpdateOptions{}); err != nil {
@@ -440,11 +520,47 @@ func (r *Router) UpdateRouteToStable(ctx context.Context, instance *iter8v1alpha
 			}
 		}
 	}
+	return r.releaseLease(ctx, instance)
+}
+
+// releaseDestinationRule hands over the destinationrule once no route refers to subsets of the experiment
+// adopted rule is restored to its original spec; rule created by iter8 is deleted if cleanup is required
+func (r *Router) releaseDestinationRule(ctx context.Context, instance *iter8v1alpha2.Experiment) error {
+	dr := r.rules.destinationRule
+	switch {
+	case isAdoptedRule(dr.GetLabels()):
+		restored, err := restoreDestinationRule(dr)
+		if err != nil {
+			return err
+		}
+		if _, err = r.client.NetworkingV1alpha3().
+			DestinationRules(restored.Namespace).
+			Update(ctx, restored, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	case instance.Spec.GetCleanup() && isInitRule(dr.GetLabels()):
+		if err := r.client.NetworkingV1alpha3().DestinationRules(dr.Namespace).
+			Delete(ctx, dr.Name, metav1.DeleteOptions{}); err != nil {
+			r.logger.Info("Err in deleting dr", "err", err)
+			return err
+		}
+	default:
+		dr = NewDestinationRuleBuilder(dr).
+			WithStableLabel().
+			RemoveExperimentLabel().
+			Build()
+		if _, err := r.client.NetworkingV1alpha3().
+			DestinationRules(dr.Namespace).
+			Update(ctx, dr, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (r *Router) updateRouteFromExperiment(route *networkingv1alpha3.HTTPRoute, instance *iter8v1alpha2.Experiment) {
-	rb := NewHTTPRoute(route).ClearRoute()
+// updateRouteFromExperiment updates destinations of the experiment route with traffic split in assessment
+// returns false if the experiment route is missing
+func (r *Router) updateRouteFromExperiment(vs *v1alpha3.VirtualService, instance *iter8v1alpha2.Experiment) bool {
 	assessment := instance.Status.Assessment
 
 	// update baseline
@@ -455,7 +571,7 @@ func (r *Router) updateRouteFromExperiment(route *networkingv1alpha3.HTTPRoute,
 		port:   instance.Spec.Service.Port,
 	})
 
-	rb = rb.WithDestination(baselineDestination)
+	destinations := []*networkingv1alpha3.HTTPRouteDestination{baselineDestination}
 
 	// update candidates
 	for i, candidate := range assessment.Candidates {
@@ -466,7 +582,139 @@ func (r *Router) updateRouteFromExperiment(route *networkingv1alpha3.HTTPRoute,
 			port:   instance.Spec.Service.Port,
 		})
 
-		rb = rb.WithDestination(destination)
+		destinations = append(destinations, destination)
+	}
+
+	return setExperimentDestinations(vs, instance.Spec.GetProtocol(), destinations)
+}
+
+// withHTTPExperimentRoute injects http experiment route, as well as base route if matching clauses exist
+func (r *Router) withHTTPExperimentRoute(vsb *VirtualServiceBuilder, match *iter8v1alpha2.Match, destination *networkingv1alpha3.HTTPRouteDestination) *VirtualServiceBuilder {
+	experimentRoute := NewEmptyHTTPRoute(routeNameExperiment).
+		WithDestination(destination).
+		WithHTTPMatch(match.HTTP).
+		WithGRPCMatch(match.GRPC)
+
+	if r.rules.isAdopted() {
+		// user-defined routes are preserved;
+		// experiment route takes over the traffic of the default route and inherits its policies
+		idx := defaultHTTPRouteIndex(r.rules.virtualService)
+		if idx >= 0 {
+			experimentRoute = experimentRoute.WithPoliciesFrom(r.rules.virtualService.Spec.Http[idx])
+		}
+		return vsb.WithHTTPRouteAt(experimentRoute.Build(), idx)
+	}
+
+	vsb = vsb.WithHTTPRoute(experimentRoute.Build())
+	if len(match.HTTP) > 0 || len(match.GRPC) > 0 {
+		baseRoute := NewEmptyHTTPRoute(routeNameBase).WithDestination(destination)
+		vsb = vsb.WithHTTPRoute(baseRoute.Build())
+	}
+	return vsb
+}
+
+// withTCPExperimentRoute injects tcp experiment route, as well as base route if matching clauses exist
+func (r *Router) withTCPExperimentRoute(vsb *VirtualServiceBuilder, match *iter8v1alpha2.Match, destination *networkingv1alpha3.HTTPRouteDestination) *VirtualServiceBuilder {
+	experimentRoute := NewEmptyTCPRoute().
+		WithDestination(destination).
+		WithTCPMatch(match.TCP)
+
+	if r.rules.isAdopted() {
+		// experiment route takes over the traffic of the default route
+		return vsb.WithTCPRouteAt(experimentRoute.Build(), defaultTCPRouteIndex(r.rules.virtualService))
+	}
+
+	vsb = vsb.WithTCPRouteAt(experimentRoute.Build(), 0)
+	if len(match.TCP) > 0 {
+		baseRoute := NewEmptyTCPRoute().WithDestination(destination)
+		vsb = vsb.WithTCPRoute(baseRoute.Build())
+	}
+	return vsb
+}
+
+// withTLSExperimentRoute injects tls experiment route
+// tls routes always require sni matching, so no base route is injected
+func (r *Router) withTLSExperimentRoute(vsb *VirtualServiceBuilder, match *iter8v1alpha2.Match, destination *networkingv1alpha3.HTTPRouteDestination) *VirtualServiceBuilder {
+	experimentRoute := NewEmptyTLSRoute().
+		WithDestination(destination).
+		WithTLSMatch(match.TLS)
+
+	// experiment route takes precedence over user-defined routes for the same sni hosts
+	return vsb.WithTLSRouteAt(experimentRoute.Build(), 0)
+}
+
+// setExperimentDestinations replaces destinations of the experiment route of the protocol
+// returns false if the experiment route is missing
+func setExperimentDestinations(vs *v1alpha3.VirtualService, protocol iter8v1alpha2.ProtocolType, destinations []*networkingv1alpha3.HTTPRouteDestination) bool {
+	switch protocol {
+	case iter8v1alpha2.ProtocolTCP:
+		route := getExperimentTCPRoute(vs, destinations)
+		if route == nil {
+			return false
+		}
+		rb := (*TCPRouteBuilder)(route)
+		rb.Route = nil
+		for _, d := range destinations {
+			rb = rb.WithDestination(d)
+		}
+	case iter8v1alpha2.ProtocolTLS:
+		route := getExperimentTLSRoute(vs, destinations)
+		if route == nil {
+			return false
+		}
+		rb := (*TLSRouteBuilder)(route)
+		rb.Route = nil
+		for _, d := range destinations {
+			rb = rb.WithDestination(d)
+		}
+	default:
+		route := getExperimentRoute(vs)
+		if route == nil {
+			return false
+		}
+		rb := NewHTTPRoute(route).ClearRoute()
+		for _, d := range destinations {
+			rb = rb.WithDestination(d)
+		}
+	}
+	return true
+}
+
+// retainExperimentRoute keeps the experiment route as the only route of the protocol in virtualservice
+// The index of tcp/tls experiment route is recorded by setExperimentDestinations
+func retainExperimentRoute(vs *v1alpha3.VirtualService, protocol iter8v1alpha2.ProtocolType) *v1alpha3.VirtualService {
+	idx := experimentRouteHint(vs)
+	switch protocol {
+	case iter8v1alpha2.ProtocolTCP:
+		if idx < 0 || idx >= len(vs.Spec.Tcp) {
+			return vs
+		}
+		route := vs.Spec.Tcp[idx]
+		route.Match = nil
+		return NewVirtualServiceBuilder(vs).
+			InitTCPRoutes().
+			WithTCPRoute(route).
+			RemoveExperimentRouteIndex().
+			Build()
+	case iter8v1alpha2.ProtocolTLS:
+		// sni matching is required by tls routes
+		if idx < 0 || idx >= len(vs.Spec.Tls) {
+			return vs
+		}
+		route := vs.Spec.Tls[idx]
+		return NewVirtualServiceBuilder(vs).
+			InitTLSRoutes().
+			WithTLSRoute(route).
+			RemoveExperimentRouteIndex().
+			Build()
+	default:
+		route := getExperimentRoute(vs)
+		route.Name = ""
+		route.Match = nil
+		return NewVirtualServiceBuilder(vs).
+			InitHTTPRoutes().
+			WithHTTPRoute(route).
+			Build()
 	}
 }
 
@@ -488,6 +736,306 @@ func getExperimentRoute(vs *v1alpha3.VirtualService) *networkingv1alpha3.HTTPRou
 	return httproutes[experimentRouteIndex]
 }
 
+// experimentRouteHint returns the index of experiment route among tcp/tls routes recorded in annotation, -1 if not recorded
+func experimentRouteHint(vs *v1alpha3.VirtualService) int {
+	val, ok := vs.GetAnnotations()[experimentRouteAnnotation]
+	if !ok {
+		return -1
+	}
+	idx, err := strconv.Atoi(val)
+	if err != nil {
+		return -1
+	}
+	return idx
+}
+
+// experimentRouteIndex returns the index of experiment route among tcp/tls routes, -1 if not found
+// tcp and tls routes have no name, so the experiment route is the one whose destinations all refer to versions
+// of the experiment; routes defined by users may be inserted or reordered during the experiment.
+// The base route refers to the baseline only, so routes with more destinations are preferred,
+// and the index recorded in annotation is a hint among routes with the same destinations.
+// The annotation is updated with the index found.
+func experimentRouteIndex(vs *v1alpha3.VirtualService, routes [][]*networkingv1alpha3.RouteDestination, destinations []*networkingv1alpha3.HTTPRouteDestination) int {
+	versions := make(map[string]bool)
+	for _, d := range destinations {
+		versions[d.Destination.Host+"/"+d.Destination.Subset] = true
+	}
+
+	hint, idx := experimentRouteHint(vs), -1
+	for i, route := range routes {
+		if len(route) == 0 {
+			continue
+		}
+		matched := true
+		for _, d := range route {
+			if d.Destination == nil || !versions[d.Destination.Host+"/"+d.Destination.Subset] {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if idx < 0 || len(route) > len(routes[idx]) || (len(route) == len(routes[idx]) && i == hint) {
+			idx = i
+		}
+	}
+
+	if idx >= 0 && idx != hint {
+		NewVirtualServiceBuilder(vs).WithExperimentRouteIndex(idx)
+	}
+	return idx
+}
+
+func getExperimentTCPRoute(vs *v1alpha3.VirtualService, destinations []*networkingv1alpha3.HTTPRouteDestination) *networkingv1alpha3.TCPRoute {
+	routes := make([][]*networkingv1alpha3.RouteDestination, len(vs.Spec.Tcp))
+	for i, route := range vs.Spec.Tcp {
+		routes[i] = route.Route
+	}
+	idx := experimentRouteIndex(vs, routes, destinations)
+	if idx < 0 {
+		return nil
+	}
+	return vs.Spec.Tcp[idx]
+}
+
+func getExperimentTLSRoute(vs *v1alpha3.VirtualService, destinations []*networkingv1alpha3.HTTPRouteDestination) *networkingv1alpha3.TLSRoute {
+	routes := make([][]*networkingv1alpha3.RouteDestination, len(vs.Spec.Tls))
+	for i, route := range vs.Spec.Tls {
+		routes[i] = route.Route
+	}
+	idx := experimentRouteIndex(vs, routes, destinations)
+	if idx < 0 {
+		return nil
+	}
+	return vs.Spec.Tls[idx]
+}
+
+// getMatch returns match clauses of the experiment; empty match is returned if not specified
+func getMatch(instance *iter8v1alpha2.Experiment) *iter8v1alpha2.Match {
+	tc := instance.Spec.TrafficControl
+	if tc == nil || tc.Match == nil {
+		return &iter8v1alpha2.Match{}
+	}
+	return tc.Match
+}
+
+// defaultTCPRouteIndex returns the index of the first tcp route without match clauses, -1 if not found
+func defaultTCPRouteIndex(vs *v1alpha3.VirtualService) int {
+	for i, route := range vs.Spec.GetTcp() {
+		if len(route.Match) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// defaultHTTPRouteIndex returns the index of the first http route without match clauses, -1 if not found
+func defaultHTTPRouteIndex(vs *v1alpha3.VirtualService) int {
+	for i, route := range vs.Spec.GetHttp() {
+		if len(route.Match) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// findVirtualServiceToAdopt looks for an existing virtualservice, not managed by any router, serving hosts of the experiment
+// returns nil if there is no such virtualservice
+func (r *Router) findVirtualServiceToAdopt(ctx context.Context, instance *iter8v1alpha2.Experiment) (*v1alpha3.VirtualService, error) {
+	vsl, err := r.client.NetworkingV1alpha3().VirtualServices(instance.ServiceNamespace()).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := experimentHosts(instance)
+	found := make([]*v1alpha3.VirtualService, 0)
+	for i := range vsl.Items {
+		vs := &vsl.Items[i]
+		if _, ok := vs.GetLabels()[routerID]; ok {
+			continue
+		}
+		for _, host := range vs.Spec.Hosts {
+			if _, ok := hosts[host]; ok {
+				found = append(found, vs)
+				break
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		r.logger.Info("AdoptVirtualService", "name", found[0].Name)
+		return adoptVirtualService(found[0], instance)
+	default:
+		return nil, fmt.Errorf("%d unlabeled vs detected for hosts of experiment", len(found))
+	}
+}
+
+// adoptVirtualService registers a user-defined virtualservice to the router of the experiment
+// The original spec is saved in an annotation so that it can be restored after the experiment
+func adoptVirtualService(vs *v1alpha3.VirtualService, instance *iter8v1alpha2.Experiment) (*v1alpha3.VirtualService, error) {
+	spec, err := json.Marshal(&vs.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewVirtualServiceBuilder(vs.DeepCopy()).
+		WithRouterRegistered(getRouterID(instance)).
+		WithStableLabel().
+		WithAdoptedLabel().
+		WithOriginalSpec(string(spec)).
+		Build(), nil
+}
+
+// restoreVirtualService reverts an adopted virtualservice to the spec saved before adoption
+// iter8 labels are removed while the saved copy is kept in the annotation
+func restoreVirtualService(vs *v1alpha3.VirtualService) (*v1alpha3.VirtualService, error) {
+	raw, ok := vs.GetAnnotations()[originalSpecAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("original spec missing in adopted vs %s", vs.Name)
+	}
+
+	out := vs.DeepCopy()
+	out.Spec = networkingv1alpha3.VirtualService{}
+	if err := json.Unmarshal([]byte(raw), &out.Spec); err != nil {
+		return nil, err
+	}
+
+	return NewVirtualServiceBuilder(out).
+		RemoveRouterLabels().
+		RemoveExperimentRouteIndex().
+		Build(), nil
+}
+
+// findDestinationRuleToAdopt looks for an existing destinationrule, not managed by any router, of the host of the experiment
+// returns nil if there is no such destinationrule
+func (r *Router) findDestinationRuleToAdopt(ctx context.Context, instance *iter8v1alpha2.Experiment) (*v1alpha3.DestinationRule, error) {
+	drl, err := r.client.NetworkingV1alpha3().DestinationRules(instance.ServiceNamespace()).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := experimentHosts(instance)
+	found := make([]*v1alpha3.DestinationRule, 0)
+	for i := range drl.Items {
+		dr := &drl.Items[i]
+		if _, ok := dr.GetLabels()[routerID]; ok {
+			continue
+		}
+		if _, ok := hosts[dr.Spec.Host]; ok {
+			found = append(found, dr)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		r.logger.Info("AdoptDestinationRule", "name", found[0].Name)
+		return adoptDestinationRule(found[0], instance)
+	default:
+		return nil, fmt.Errorf("%d unlabeled dr detected for host of experiment", len(found))
+	}
+}
+
+// adoptDestinationRule registers a user-defined destinationrule to the router of the experiment
+// The original spec is saved in an annotation so that it can be restored after the experiment
+func adoptDestinationRule(dr *v1alpha3.DestinationRule, instance *iter8v1alpha2.Experiment) (*v1alpha3.DestinationRule, error) {
+	spec, err := json.Marshal(&dr.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDestinationRuleBuilder(dr.DeepCopy()).
+		WithRouterRegistered(getRouterID(instance)).
+		WithStableLabel().
+		WithAdoptedLabel().
+		WithOriginalSpec(string(spec)).
+		Build(), nil
+}
+
+// restoreDestinationRule reverts an adopted destinationrule to the spec saved before adoption
+// iter8 labels are removed while the saved copy is kept in the annotation
+func restoreDestinationRule(dr *v1alpha3.DestinationRule) (*v1alpha3.DestinationRule, error) {
+	raw, ok := dr.GetAnnotations()[originalSpecAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("original spec missing in adopted dr %s", dr.Name)
+	}
+
+	out := dr.DeepCopy()
+	out.Spec = networkingv1alpha3.DestinationRule{}
+	if err := json.Unmarshal([]byte(raw), &out.Spec); err != nil {
+		return nil, err
+	}
+
+	return NewDestinationRuleBuilder(out).
+		RemoveRouterLabels().
+		Build(), nil
+}
+
+// subsetTrafficPolicy returns the trafficPolicy of the first user-defined subset selecting pods with the labels
+func subsetTrafficPolicy(dr *v1alpha3.DestinationRule, podLabels map[string]string) *networkingv1alpha3.TrafficPolicy {
+	for _, subset := range dr.Spec.Subsets {
+		if isIter8Subset(subset.Name) || subset.TrafficPolicy == nil || len(subset.Labels) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(subset.Labels).Matches(labels.Set(podLabels)) {
+			return subset.TrafficPolicy.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// baselineTrafficPolicy returns the trafficPolicy of baseline subset, nil if not found
+func baselineTrafficPolicy(dr *v1alpha3.DestinationRule) *networkingv1alpha3.TrafficPolicy {
+	for _, subset := range dr.Spec.Subsets {
+		if subset.Name == SubsetBaseline {
+			return subset.TrafficPolicy
+		}
+	}
+	return nil
+}
+
+// isIter8Subset tells whether the subset is managed by iter8
+func isIter8Subset(name string) bool {
+	return name == SubsetBaseline || strings.HasPrefix(name, SubsetCandidate+"-")
+}
+
+// experimentHosts returns the set of host names referring to the target service of the experiment
+func experimentHosts(instance *iter8v1alpha2.Experiment) map[string]bool {
+	out := make(map[string]bool)
+	if name := instance.Spec.Service.Name; name != "" {
+		ns := instance.ServiceNamespace()
+		out[name] = true
+		out[name+"."+ns] = true
+		out[name+"."+ns+".svc"] = true
+		out[util.ServiceToFullHostName(name, ns)] = true
+	}
+
+	if nwk := instance.Spec.Networking; nwk != nil {
+		for _, host := range nwk.Hosts {
+			out[host.Name] = true
+		}
+	}
+
+	return out
+}
+
+// isInitRule tells whether the routing rule is created by iter8
+func isInitRule(labels map[string]string) bool {
+	return labels[experimentInit] == "True"
+}
+
+// isAdoptedRule tells whether the routing rule is a user-defined one adopted by iter8
+func isAdoptedRule(labels map[string]string) bool {
+	return labels[experimentAdopted] == "True"
+}
+
 // CandidateSubsetName returns subset name of a candidate with respect to its index in service spec
 func CandidateSubsetName(idx int) string {
 	return SubsetCandidate + "-" + strconv.Itoa(idx)
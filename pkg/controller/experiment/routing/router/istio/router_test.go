@@ -0,0 +1,341 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istio
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/onsi/gomega"
+	networkingv1alpha3 "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+func newTestExperiment() *iter8v1alpha2.Experiment {
+	return &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{
+					Name: "reviews",
+				},
+				Baseline:   "reviews-v1",
+				Candidates: []string{"reviews-v2"},
+			},
+		},
+	}
+}
+
+func newTestDeployment(version string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "reviews", "version": version},
+				},
+			},
+		},
+	}
+}
+
+func TestAdoptAndRestoreVirtualService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	userRoute := &networkingv1alpha3.HTTPRoute{
+		Match: []*networkingv1alpha3.HTTPMatchRequest{{
+			Headers: map[string]*networkingv1alpha3.StringMatch{
+				"end-user": {MatchType: &networkingv1alpha3.StringMatch_Exact{Exact: "jason"}},
+			},
+		}},
+		Route: []*networkingv1alpha3.HTTPRouteDestination{{
+			Destination: &networkingv1alpha3.Destination{Host: "reviews", Subset: "v2"},
+		}},
+	}
+	defaultRoute := &networkingv1alpha3.HTTPRoute{
+		Timeout: &types.Duration{Seconds: 3},
+		Route: []*networkingv1alpha3.HTTPRouteDestination{{
+			Destination: &networkingv1alpha3.Destination{Host: "reviews", Subset: "v1"},
+		}},
+	}
+	vs := &v1alpha3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "default"},
+		Spec: networkingv1alpha3.VirtualService{
+			Hosts: []string{"reviews"},
+			Http:  []*networkingv1alpha3.HTTPRoute{userRoute, defaultRoute},
+		},
+	}
+	original := vs.DeepCopy()
+
+	adopted, err := adoptVirtualService(vs, newTestExperiment())
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(isAdoptedRule(adopted.GetLabels())).To(gomega.BeTrue())
+	g.Expect(adopted.GetLabels()[experimentRole]).To(gomega.Equal(roleStable))
+
+	// experiment route is inserted in front of the default route and inherits its policies
+	idx := defaultHTTPRouteIndex(adopted)
+	g.Expect(idx).To(gomega.Equal(1))
+	route := NewEmptyHTTPRoute(routeNameExperiment).WithPoliciesFrom(adopted.Spec.Http[idx]).Build()
+	adopted = NewVirtualServiceBuilder(adopted).WithHTTPRouteAt(route, idx).Build()
+	g.Expect(adopted.Spec.Http).To(gomega.HaveLen(3))
+	g.Expect(getExperimentRoute(adopted).Timeout.Seconds).To(gomega.Equal(int64(3)))
+	g.Expect(adopted.Spec.Http[0].Match).To(gomega.HaveLen(1))
+
+	restored, err := restoreVirtualService(adopted)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(proto.Equal(&restored.Spec, &original.Spec)).To(gomega.BeTrue())
+	g.Expect(restored.GetLabels()).NotTo(gomega.HaveKey(routerID))
+	g.Expect(restored.GetLabels()).NotTo(gomega.HaveKey(experimentAdopted))
+}
+
+func TestAdoptAndRestoreDestinationRule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	pool := &networkingv1alpha3.TrafficPolicy{
+		ConnectionPool: &networkingv1alpha3.ConnectionPoolSettings{
+			Tcp: &networkingv1alpha3.ConnectionPoolSettings_TCPSettings{MaxConnections: 100},
+		},
+	}
+	v1Policy := &networkingv1alpha3.TrafficPolicy{
+		OutlierDetection: &networkingv1alpha3.OutlierDetection{ConsecutiveErrors: 5},
+	}
+	dr := &v1alpha3.DestinationRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "default"},
+		Spec: networkingv1alpha3.DestinationRule{
+			Host:          "reviews",
+			TrafficPolicy: pool,
+			Subsets: []*networkingv1alpha3.Subset{
+				{Name: "v1", Labels: map[string]string{"version": "v1"}, TrafficPolicy: v1Policy},
+				{Name: "v2", Labels: map[string]string{"version": "v2"}},
+			},
+		},
+	}
+	original := dr.DeepCopy()
+
+	adopted, err := adoptDestinationRule(dr, newTestExperiment())
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// subsets are merged; baseline takes the policy of the user subset selecting the same pods
+	baseline := newTestDeployment("v1")
+	drb := NewDestinationRuleBuilder(adopted).RemoveIter8Subsets()
+	drb = drb.WithSubsetPolicy(baseline, SubsetBaseline, subsetTrafficPolicy(drb.Build(), baseline.Spec.Template.Labels))
+	drb = drb.WithSubsetPolicy(newTestDeployment("v2"), CandidateSubsetName(0), baselineTrafficPolicy(drb.Build()).DeepCopy())
+	adopted = drb.Build()
+
+	g.Expect(proto.Equal(adopted.Spec.TrafficPolicy, pool)).To(gomega.BeTrue())
+	g.Expect(adopted.Spec.Subsets).To(gomega.HaveLen(4))
+	g.Expect(adopted.Spec.Subsets[2].Name).To(gomega.Equal(SubsetBaseline))
+	g.Expect(proto.Equal(adopted.Spec.Subsets[2].TrafficPolicy, v1Policy)).To(gomega.BeTrue())
+	g.Expect(proto.Equal(adopted.Spec.Subsets[3].TrafficPolicy, v1Policy)).To(gomega.BeTrue())
+
+	// re-adding subsets replaces the existing ones
+	adopted = NewDestinationRuleBuilder(adopted).WithSubset(newTestDeployment("v2"), CandidateSubsetName(0)).Build()
+	g.Expect(adopted.Spec.Subsets).To(gomega.HaveLen(4))
+	g.Expect(adopted.Spec.Subsets[3].TrafficPolicy).To(gomega.BeNil())
+
+	restored, err := restoreDestinationRule(adopted)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(proto.Equal(&restored.Spec, &original.Spec)).To(gomega.BeTrue())
+	g.Expect(restored.GetLabels()).NotTo(gomega.HaveKey(routerID))
+}
+
+// newStableTestRules returns progressing routing rules of the experiment on top of an adopted destinationrule,
+// with either an adopted virtualservice or one created by iter8
+func newStableTestRules(instance *iter8v1alpha2.Experiment, adoptVS bool) (*v1alpha3.VirtualService, *v1alpha3.DestinationRule) {
+	dr := &v1alpha3.DestinationRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "default"},
+		Spec: networkingv1alpha3.DestinationRule{
+			Host: "reviews",
+			Subsets: []*networkingv1alpha3.Subset{
+				{Name: "v1", Labels: map[string]string{"version": "v1"}},
+			},
+		},
+	}
+	dr, _ = adoptDestinationRule(dr, instance)
+	dr = NewDestinationRuleBuilder(dr).
+		WithSubset(newTestDeployment("v1"), SubsetBaseline).
+		WithSubset(newTestDeployment("v2"), CandidateSubsetName(0)).
+		WithProgressingLabel().
+		WithExperimentRegistered(instance.Name + "." + instance.Namespace).
+		Build()
+
+	var vs *v1alpha3.VirtualService
+	if adoptVS {
+		vs = &v1alpha3.VirtualService{
+			ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "default"},
+			Spec: networkingv1alpha3.VirtualService{
+				Hosts: []string{"reviews"},
+				Http: []*networkingv1alpha3.HTTPRoute{{
+					Route: []*networkingv1alpha3.HTTPRouteDestination{{
+						Destination: &networkingv1alpha3.Destination{Host: "reviews", Subset: "v1"},
+					}},
+				}},
+			},
+		}
+		vs, _ = adoptVirtualService(vs, instance)
+	} else {
+		vs = NewVirtualService(GetRoutingRuleName(getRouterID(instance)), instance.Name, instance.Namespace).
+			WithInitLabel().
+			WithRouterRegistered(getRouterID(instance)).
+			WithHosts([]string{"reviews"}).
+			Build()
+	}
+	route := NewEmptyHTTPRoute(routeNameExperiment).
+		WithDestination(NewHTTPRouteDestination().WithHost("reviews").WithSubset(SubsetBaseline).WithWeight(100).Build()).
+		Build()
+	vs = NewVirtualServiceBuilder(vs).WithHTTPRouteAt(route, 0).WithProgressingLabel().Build()
+	return vs, dr
+}
+
+func TestUpdateRouteToStable(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name    string
+		adoptVS bool
+		cleanup bool
+	}{
+		{name: "adopted vs with cleanup", adoptVS: true, cleanup: true},
+		{name: "adopted vs without cleanup", adoptVS: true},
+		{name: "iter8 vs with cleanup", cleanup: true},
+		{name: "iter8 vs without cleanup"},
+	} {
+		instance := newTestExperiment()
+		instance.Spec.Cleanup = &tc.cleanup
+		instance.InitStatus()
+		instance.Status.Assessment = &iter8v1alpha2.Assessment{
+			Baseline:   iter8v1alpha2.VersionAssessment{Name: "reviews-v1", Weight: 0},
+			Candidates: []iter8v1alpha2.VersionAssessment{{Name: "reviews-v2", Weight: 100}},
+		}
+		vs, dr := newStableTestRules(instance, tc.adoptVS)
+		original := &networkingv1alpha3.DestinationRule{}
+		g.Expect(json.Unmarshal([]byte(dr.GetAnnotations()[originalSpecAnnotation]), original)).To(gomega.Succeed())
+
+		r := newLeaseTestRouter()
+		r.client = istiofake.NewSimpleClientset(vs.DeepCopy(), dr.DeepCopy())
+		r.handler = deploymentHandler{}
+		r.rules = &istioRoutingRules{virtualService: vs, destinationRule: dr}
+		g.Expect(r.UpdateRouteToStable(ctx, instance)).To(gomega.Succeed(), tc.name)
+
+		dr, err := r.client.NetworkingV1alpha3().DestinationRules("default").Get(ctx, "reviews", metav1.GetOptions{})
+		switch {
+		case tc.adoptVS:
+			// both rules are given back to the user
+			g.Expect(err).NotTo(gomega.HaveOccurred(), tc.name)
+			g.Expect(proto.Equal(&dr.Spec, original)).To(gomega.BeTrue(), tc.name)
+			g.Expect(dr.GetLabels()).NotTo(gomega.HaveKey(routerID), tc.name)
+			vs, err := r.client.NetworkingV1alpha3().VirtualServices("default").Get(ctx, "reviews", metav1.GetOptions{})
+			g.Expect(err).NotTo(gomega.HaveOccurred(), tc.name)
+			g.Expect(vs.Spec.Http).To(gomega.HaveLen(1), tc.name)
+			g.Expect(vs.Spec.Http[0].Route[0].Destination.Subset).To(gomega.Equal("v1"), tc.name)
+		case tc.cleanup:
+			// the adopted destinationrule is restored once the vs created by iter8 is deleted
+			g.Expect(err).NotTo(gomega.HaveOccurred(), tc.name)
+			g.Expect(proto.Equal(&dr.Spec, original)).To(gomega.BeTrue(), tc.name)
+			_, err = r.client.NetworkingV1alpha3().VirtualServices("default").Get(ctx, vs.Name, metav1.GetOptions{})
+			g.Expect(err).To(gomega.HaveOccurred(), tc.name)
+		default:
+			// the retained route refers to iter8 subsets, which must survive the next experiment
+			g.Expect(err).NotTo(gomega.HaveOccurred(), tc.name)
+			g.Expect(dr.GetLabels()).NotTo(gomega.HaveKey(experimentAdopted), tc.name)
+			g.Expect(dr.GetLabels()[experimentRole]).To(gomega.Equal(roleStable), tc.name)
+			g.Expect(dr.Spec.Subsets).To(gomega.HaveLen(3), tc.name)
+			baseline := &networkingv1alpha3.DestinationRule{}
+			g.Expect(json.Unmarshal([]byte(dr.GetAnnotations()[originalSpecAnnotation]), baseline)).To(gomega.Succeed())
+			g.Expect(proto.Equal(baseline, &dr.Spec)).To(gomega.BeTrue(), tc.name)
+			vs, err := r.client.NetworkingV1alpha3().VirtualServices("default").Get(ctx, vs.Name, metav1.GetOptions{})
+			g.Expect(err).NotTo(gomega.HaveOccurred(), tc.name)
+			g.Expect(vs.Spec.Http[0].Route).To(gomega.HaveLen(2), tc.name)
+			g.Expect(vs.Spec.Http[0].Route[1].Destination.Subset).To(gomega.Equal(CandidateSubsetName(0)), tc.name)
+			g.Expect(vs.Spec.Http[0].Route[1].Weight).To(gomega.Equal(int32(100)), tc.name)
+		}
+	}
+}
+
+func TestTCPExperimentRoute(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	vs := &v1alpha3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "default"},
+	}
+	match := &iter8v1alpha2.Match{
+		TCP: []*iter8v1alpha2.L4MatchAttributes{{Port: 6379}},
+	}
+	baseline := NewHTTPRouteDestination().WithHost("redis").WithSubset(SubsetBaseline).WithWeight(100).Build()
+
+	r := &Router{rules: &istioRoutingRules{virtualService: vs}}
+	vs = r.withTCPExperimentRoute(NewVirtualServiceBuilder(vs), match, baseline).Build()
+	g.Expect(vs.Spec.Tcp).To(gomega.HaveLen(2))
+	g.Expect(getExperimentTCPRoute(vs, []*networkingv1alpha3.HTTPRouteDestination{baseline}).Match[0].Port).To(gomega.Equal(uint32(6379)))
+
+	candidate := NewHTTPRouteDestination().WithHost("redis").WithSubset(CandidateSubsetName(0)).WithWeight(40).Build()
+	baseline = NewHTTPRouteDestination().WithHost("redis").WithSubset(SubsetBaseline).WithWeight(60).Build()
+	g.Expect(setExperimentDestinations(vs, iter8v1alpha2.ProtocolTCP,
+		[]*networkingv1alpha3.HTTPRouteDestination{baseline, candidate})).To(gomega.BeTrue())
+	g.Expect(vs.Spec.Tcp[0].Route).To(gomega.HaveLen(2))
+	g.Expect(vs.Spec.Tcp[0].Route[1].Weight).To(gomega.Equal(int32(40)))
+	g.Expect(vs.Spec.Tcp[1].Route[0].Weight).To(gomega.Equal(int32(100)))
+
+	// routes inserted or reordered by users are not mistaken for the experiment route
+	userRoute := &networkingv1alpha3.TCPRoute{
+		Route: []*networkingv1alpha3.RouteDestination{{
+			Destination: &networkingv1alpha3.Destination{Host: "redis-cache"},
+			Weight:      100,
+		}},
+	}
+	vs.Spec.Tcp = []*networkingv1alpha3.TCPRoute{userRoute, vs.Spec.Tcp[1], vs.Spec.Tcp[0]}
+	candidate = NewHTTPRouteDestination().WithHost("redis").WithSubset(CandidateSubsetName(0)).WithWeight(80).Build()
+	baseline = NewHTTPRouteDestination().WithHost("redis").WithSubset(SubsetBaseline).WithWeight(20).Build()
+	g.Expect(setExperimentDestinations(vs, iter8v1alpha2.ProtocolTCP,
+		[]*networkingv1alpha3.HTTPRouteDestination{baseline, candidate})).To(gomega.BeTrue())
+	g.Expect(userRoute.Route[0].Destination.Host).To(gomega.Equal("redis-cache"))
+	g.Expect(vs.Spec.Tcp[1].Route).To(gomega.HaveLen(1))
+	g.Expect(vs.Spec.Tcp[2].Route[1].Weight).To(gomega.Equal(int32(80)))
+	g.Expect(vs.GetAnnotations()[experimentRouteAnnotation]).To(gomega.Equal("2"))
+
+	vs = retainExperimentRoute(vs, iter8v1alpha2.ProtocolTCP)
+	g.Expect(vs.Spec.Tcp).To(gomega.HaveLen(1))
+	g.Expect(vs.Spec.Tcp[0].Route[1].Weight).To(gomega.Equal(int32(80)))
+	g.Expect(vs.Spec.Tcp[0].Match).To(gomega.BeNil())
+	g.Expect(vs.GetAnnotations()).NotTo(gomega.HaveKey(experimentRouteAnnotation))
+}
+
+func TestConvertGRPCMatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	jason := "jason"
+	m := convertGRPCMatchToIstio(&iter8v1alpha2.GRPCMatchRequest{
+		Service: "helloworld.Greeter",
+		Method:  "SayHello",
+		Headers: map[string]iter8v1alpha2.StringMatch{"end-user": {Exact: &jason}},
+	})
+	g.Expect(m.Uri.GetExact()).To(gomega.Equal("/helloworld.Greeter/SayHello"))
+	g.Expect(m.Method.GetExact()).To(gomega.Equal("POST"))
+	g.Expect(m.Headers["content-type"].GetPrefix()).To(gomega.Equal("application/grpc"))
+	g.Expect(m.Headers["end-user"].GetExact()).To(gomega.Equal("jason"))
+
+	m = convertGRPCMatchToIstio(&iter8v1alpha2.GRPCMatchRequest{Service: "helloworld.Greeter"})
+	g.Expect(m.Uri.GetPrefix()).To(gomega.Equal("/helloworld.Greeter/"))
+}
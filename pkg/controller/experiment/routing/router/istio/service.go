@@ -53,7 +53,7 @@ func (h serviceHandler) validateAndInit(drl *v1alpha3.DestinationRuleList, vsl *
 						// valid progressing rules found
 						out.virtualService = vsl.Items[0].DeepCopy()
 					} else {
-						return nil, fmt.Errorf("Progressing rules of other experiment are detected")
+						return nil, util.NewConflictError("Progressing rules of other experiment are detected")
 					}
 				} else {
 					return nil, fmt.Errorf("Experiment label missing in dr or vs")
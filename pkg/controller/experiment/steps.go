@@ -0,0 +1,123 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/targets"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+// applyStep shifts traffic to the step of the schedule active at the current iteration
+// Moving to the next step, and completing after the last one, is gated by the health check of candidates.
+// returns true if traffic is changed; false as the second value if candidates are unhealthy,
+// in which case the experiment is paused or rolled back
+func (r *ReconcileExperiment) applyStep(context context.Context, instance *iter8v1alpha2.Experiment) (bool, bool) {
+	steps := instance.Spec.GetSteps()
+	index := instance.Spec.GetStepAt(*instance.Status.CurrentIteration)
+	current := instance.Status.CurrentStep
+	if current != nil && current.Index == index {
+		return false, true
+	}
+
+	restarts, healthy := r.checkStepHealth(context, instance)
+	if !healthy {
+		return false, false
+	}
+	if index >= int32(len(steps)) {
+		return false, true
+	}
+
+	setStepTraffic(instance, steps[index].Weight)
+	r.markStepUpdate(context, instance, index, restarts, "Step %d/%d: %d%% of traffic to candidates",
+		index+1, len(steps), steps[index].Weight)
+	return true, true
+}
+
+// checkStepHealth checks the health of candidates at the end of the current step
+// Readiness and restarts are only checked when moving from a step to the next one.
+// returns restarts of candidates recorded for the next step, and whether candidates are healthy
+func (r *ReconcileExperiment) checkStepHealth(context context.Context, instance *iter8v1alpha2.Experiment) (map[string]int32, bool) {
+	check := instance.Spec.GetHealthCheck()
+	if check == nil {
+		return nil, true
+	}
+
+	current := instance.Status.CurrentStep
+	health, err := targets.Init(instance, r.Client).WithPodReader(r.apiReader).CheckCandidateHealth(context)
+	if err != nil {
+		if current == nil {
+			util.Logger(context).Error(err, "Fail to check health of candidates")
+			return nil, true
+		}
+		r.markHealthCheckFailed(context, instance, check, "Fail to check health of candidates: %v", err)
+		return nil, false
+	}
+
+	restarts := make(map[string]int32)
+	unhealthy := make([]string, 0)
+	for _, h := range health {
+		restarts[h.Name] = h.Restarts
+		if current == nil {
+			continue
+		}
+		if check.IsReadyRequired() && !h.Ready {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (%s)", h.Name, h.Reason))
+		} else if n := h.Restarts - current.Restarts[h.Name]; n > check.GetMaxRestarts() {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (%d restarts)", h.Name, n))
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		// restarts failing this check are not counted again once the experiment is resumed
+		current.Restarts = restarts
+		r.markHealthCheckFailed(context, instance, check, "Candidates unhealthy at step %d: %s",
+			current.Index+1, strings.Join(unhealthy, ", "))
+		return nil, false
+	}
+	return restarts, true
+}
+
+// onHealthCheckFailure pauses or rolls back the experiment according to the failure policy of the health check
+func (r *ReconcileExperiment) onHealthCheckFailure(context context.Context, instance *iter8v1alpha2.Experiment, check *iter8v1alpha2.HealthCheck) {
+	switch check.GetOnFailure() {
+	case iter8v1alpha2.HealthCheckFailurePause:
+		r.markActionPause(context, instance, "Health check failed")
+	default:
+		util.Logger(context).Info("AbortExperiment", "Health check failed", "")
+		instance.Spec.TerminateExperiment()
+	}
+}
+
+// setStepTraffic sends weight percent of traffic to candidates, split evenly among them, and the rest to baseline
+func setStepTraffic(instance *iter8v1alpha2.Experiment, weight int32) {
+	assessment := instance.Status.Assessment
+	n := int32(len(assessment.Candidates))
+	if n == 0 {
+		return
+	}
+	assessment.Baseline.Weight = 100 - weight
+	for i := range assessment.Candidates {
+		assessment.Candidates[i].Weight = weight / n
+		if int32(i) < weight%n {
+			assessment.Candidates[i].Weight++
+		}
+	}
+}
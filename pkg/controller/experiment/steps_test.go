@@ -0,0 +1,184 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experiment
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+	"github.com/iter8-tools/iter8-istio/pkg/controller/experiment/util"
+)
+
+func newStepTestExperiment(check *iter8v1alpha2.HealthCheck) *iter8v1alpha2.Experiment {
+	interval, oneMinute, fiveMinutes := "1m", "1m", "5m"
+	instance := newReadinessTestExperiment("reviews-v2", "reviews-v3")
+	instance.Spec.Duration = &iter8v1alpha2.Duration{Interval: &interval}
+	instance.Spec.TrafficControl = &iter8v1alpha2.TrafficControl{
+		Steps: []iter8v1alpha2.Step{
+			{Weight: 1},
+			{Weight: 5, Pause: &oneMinute},
+			{Weight: 25, Pause: &fiveMinutes},
+			{Weight: 100},
+		},
+		HealthCheck: check,
+	}
+	return instance
+}
+
+// healthCheckEvent returns the latest event recorded for a failed health check
+func healthCheckEvent(r *ReconcileExperiment) string {
+	event := ""
+	recorder := r.eventRecorder.(*record.FakeRecorder)
+	for len(recorder.Events) > 0 {
+		if e := <-recorder.Events; strings.Contains(e, iter8v1alpha2.ReasonHealthCheckFailed) {
+			event = e
+		}
+	}
+	return event
+}
+
+func TestStepSchedule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newStepTestExperiment(nil)
+	g.Expect(instance.Spec.GetMaxIterations()).To(gomega.Equal(int32(8)))
+
+	steps := make([]int32, 0)
+	for i := int32(0); i <= instance.Spec.GetMaxIterations(); i++ {
+		steps = append(steps, instance.Spec.GetStepAt(i))
+	}
+	g.Expect(steps).To(gomega.Equal([]int32{0, 1, 2, 2, 2, 2, 2, 3, 4}))
+
+	// steps are ignored with criteria
+	instance.Spec.Criteria = []iter8v1alpha2.Criterion{{Metric: "iter8_mean_latency"}}
+	g.Expect(instance.Spec.GetSteps()).To(gomega.BeNil())
+	g.Expect(instance.Spec.GetMaxIterations()).To(gomega.Equal(iter8v1alpha2.DefaultMaxIterations))
+}
+
+func TestApplyStep(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	objs := append(newReadinessTestDeployment("reviews-v2", 1, 0), newReadinessTestDeployment("reviews-v3", 1, 0)...)
+	r := newApprovalTestReconciler()
+	r.Client = fake.NewFakeClientWithScheme(scheme, objs...)
+	r.apiReader = r.Client
+
+	instance := newStepTestExperiment(nil)
+	updated, healthy := r.applyStep(ctx, instance)
+	g.Expect(updated && healthy).To(gomega.BeTrue())
+	g.Expect(instance.Status.Assessment.Baseline.Weight).To(gomega.Equal(int32(99)))
+	g.Expect(instance.Status.Assessment.Candidates[0].Weight).To(gomega.Equal(int32(1)))
+	g.Expect(instance.Status.Assessment.Candidates[1].Weight).To(gomega.Equal(int32(0)))
+
+	// traffic is held during the step
+	updated, _ = r.applyStep(ctx, instance)
+	g.Expect(updated).To(gomega.BeFalse())
+
+	*instance.Status.CurrentIteration = 2
+	updated, _ = r.applyStep(ctx, instance)
+	g.Expect(updated).To(gomega.BeTrue())
+	g.Expect(instance.Status.CurrentStep.Index).To(gomega.Equal(int32(2)))
+	g.Expect(instance.Status.Assessment.Baseline.Weight).To(gomega.Equal(int32(75)))
+	g.Expect(instance.Status.Assessment.Candidates[0].Weight).To(gomega.Equal(int32(13)))
+	g.Expect(instance.Status.Assessment.Candidates[1].Weight).To(gomega.Equal(int32(12)))
+
+	// no traffic change once the schedule is over
+	*instance.Status.CurrentIteration = instance.Spec.GetMaxIterations()
+	updated, healthy = r.applyStep(ctx, instance)
+	g.Expect(updated).To(gomega.BeFalse())
+	g.Expect(healthy).To(gomega.BeTrue())
+}
+
+func TestStepHealthCheck(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.WithValue(context.Background(), util.LoggerKey, logf.Log)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	objs := append(newReadinessTestDeployment("reviews-v2", 1, 0), newReadinessTestDeployment("reviews-v3", 1, 0)...)
+	r := newApprovalTestReconciler()
+	r.Client = fake.NewFakeClientWithScheme(scheme, objs...)
+	r.apiReader = r.Client
+
+	requireReady, pause := false, iter8v1alpha2.HealthCheckFailurePause
+	instance := newStepTestExperiment(&iter8v1alpha2.HealthCheck{RequireReady: &requireReady, OnFailure: &pause})
+	_, healthy := r.applyStep(ctx, instance)
+	g.Expect(healthy).To(gomega.BeTrue())
+	g.Expect(instance.Status.CurrentStep.Restarts).To(gomega.Equal(map[string]int32{"reviews-v2": 0, "reviews-v3": 0}))
+
+	// restarts during the step pause the experiment
+	pod := &corev1.Pod{}
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "reviews-v3-pod"}, pod)).To(gomega.Succeed())
+	pod.Status.ContainerStatuses[0].RestartCount = 1
+	g.Expect(r.Update(ctx, pod)).To(gomega.Succeed())
+
+	*instance.Status.CurrentIteration = 1
+	_, healthy = r.applyStep(ctx, instance)
+	g.Expect(healthy).To(gomega.BeFalse())
+	g.Expect(healthCheckEvent(r)).To(gomega.ContainSubstring("reviews-v3 (1 restarts)"))
+	g.Expect(instance.Status.Phase).To(gomega.Equal(iter8v1alpha2.PhasePause))
+	g.Expect(instance.Status.CurrentStep.Index).To(gomega.Equal(int32(0)))
+
+	// restarts are not counted again once resumed
+	instance.Status.Phase = iter8v1alpha2.PhaseProgressing
+	_, healthy = r.applyStep(ctx, instance)
+	g.Expect(healthy).To(gomega.BeTrue())
+	g.Expect(instance.Status.CurrentStep.Index).To(gomega.Equal(int32(1)))
+
+	// candidates not ready roll back the experiment by default
+	instance.Spec.TrafficControl.HealthCheck = &iter8v1alpha2.HealthCheck{}
+	*instance.Status.CurrentIteration = 2
+	_, healthy = r.applyStep(ctx, instance)
+	g.Expect(healthy).To(gomega.BeFalse())
+	g.Expect(healthCheckEvent(r)).To(gomega.ContainSubstring("0 of 1 replicas ready"))
+	g.Expect(instance.Spec.Terminate()).To(gomega.BeTrue())
+}
+
+func TestValidateSteps(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newStepTestExperiment(&iter8v1alpha2.HealthCheck{})
+	g.Expect(instance.Spec.Validate()).To(gomega.Succeed())
+
+	maxIterations := int32(10)
+	instance.Spec.Duration.MaxIterations = &maxIterations
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+
+	instance = newStepTestExperiment(nil)
+	instance.Spec.Criteria = []iter8v1alpha2.Criterion{{Metric: "iter8_mean_latency"}}
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+
+	instance = newStepTestExperiment(nil)
+	invalid := "later"
+	instance.Spec.TrafficControl.Steps[0].Pause = &invalid
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+
+	instance.Spec.TrafficControl.Steps = nil
+	instance.Spec.TrafficControl.HealthCheck = &iter8v1alpha2.HealthCheck{}
+	g.Expect(instance.Spec.Validate()).NotTo(gomega.Succeed())
+}
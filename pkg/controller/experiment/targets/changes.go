@@ -0,0 +1,56 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targets
+
+// This file contains functions used for detecting changes of targets which invalidate
+// the progress of an iter8 experiment.
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// DeploymentChange describes the change between two versions of a deployment which affects the experiment
+// returns empty string if there is none; status updates and scaling to non-zero replicas are ignored
+func DeploymentChange(old, new *appsv1.Deployment) string {
+	switch {
+	case !equality.Semantic.DeepEqual(old.Spec.Template, new.Spec.Template):
+		return "pod template changed"
+	case !equality.Semantic.DeepEqual(old.Spec.Selector, new.Spec.Selector):
+		return "selector changed"
+	case replicas(new) == 0 && replicas(old) != 0:
+		return "scaled to zero"
+	}
+	return ""
+}
+
+// ServiceChange describes the change between two versions of a service which affects the experiment
+// returns empty string if there is none
+func ServiceChange(old, new *corev1.Service) string {
+	if !equality.Semantic.DeepEqual(old.Spec.Selector, new.Spec.Selector) {
+		return "selector changed"
+	}
+	return ""
+}
+
+// replicas returns the desired number of replicas of the deployment, which defaults to 1
+func replicas(deploy *appsv1.Deployment) int32 {
+	if deploy.Spec.Replicas == nil {
+		return 1
+	}
+	return *deploy.Spec.Replicas
+}
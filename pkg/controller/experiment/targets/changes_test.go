@@ -0,0 +1,62 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targets
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentChange(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	old := newTestDeployment("reviews-v2", 1)
+
+	// status updates are ignored
+	updated := old.DeepCopy()
+	updated.Status.AvailableReplicas = 0
+	g.Expect(DeploymentChange(old, updated)).To(gomega.BeEmpty())
+
+	updated = old.DeepCopy()
+	updated.Spec.Template.Spec.Containers = []corev1.Container{{Name: "app", Image: "reviews:v2.1"}}
+	g.Expect(DeploymentChange(old, updated)).To(gomega.Equal("pod template changed"))
+
+	updated = old.DeepCopy()
+	updated.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"version": "v2"}}
+	g.Expect(DeploymentChange(old, updated)).To(gomega.Equal("selector changed"))
+
+	zero := int32(0)
+	updated = old.DeepCopy()
+	updated.Spec.Replicas = &zero
+	g.Expect(DeploymentChange(old, updated)).To(gomega.Equal("scaled to zero"))
+	g.Expect(DeploymentChange(updated, updated.DeepCopy())).To(gomega.BeEmpty())
+}
+
+func TestServiceChange(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	old := &corev1.Service{
+		Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+	}
+
+	updated := old.DeepCopy()
+	updated.Spec.Ports = []corev1.ServicePort{{Port: 9080}}
+	g.Expect(ServiceChange(old, updated)).To(gomega.BeEmpty())
+
+	updated.Spec.Selector = map[string]string{"app": "reviews", "version": "v2"}
+	g.Expect(ServiceChange(old, updated)).To(gomega.Equal("selector changed"))
+}
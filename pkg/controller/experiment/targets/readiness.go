@@ -0,0 +1,180 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targets
+
+// This file contains functions used for checking whether candidates of an iter8 experiment
+// are available to receive traffic.
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CrashLoopRestartThreshold is the number of restarts after which a container
+	// waiting in CrashLoopBackOff makes its candidate crashlooping
+	CrashLoopRestartThreshold int32 = 3
+
+	reasonCrashLoopBackOff         = "CrashLoopBackOff"
+	reasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+)
+
+// Readiness of a candidate to receive traffic
+type Readiness struct {
+	// Name of the candidate
+	Name string
+	// Ready tells whether the candidate can receive traffic
+	Ready bool
+	// Reason explains why the candidate is not ready
+	Reason string
+	// CrashLooping tells whether pods of the candidate are restarting repeatedly
+	CrashLooping bool
+}
+
+// CheckCandidates checks readiness of all candidates in the targets
+// A candidate which can not be read from cluster is not ready
+func (t *Targets) CheckCandidates(context context.Context) []Readiness {
+	out := make([]Readiness, len(t.service.Candidates))
+	for i, name := range t.service.Candidates {
+		out[i] = Readiness{Name: name, Ready: true}
+		if t.service.Kind == "Service" {
+			continue
+		}
+
+		deploy := &appsv1.Deployment{}
+		if err := t.client.Get(context, client.ObjectKey{Namespace: t.namespace, Name: name}, deploy); err != nil {
+			out[i].Ready, out[i].Reason = false, err.Error()
+			continue
+		}
+
+		out[i].Ready, out[i].Reason = DeploymentAvailable(deploy)
+		crashLooping, err := crashLooping(context, t.podReader, deploy)
+		if err != nil {
+			out[i].Ready, out[i].Reason = false, err.Error()
+		} else if crashLooping {
+			out[i].Ready, out[i].Reason, out[i].CrashLooping = false, "pods in "+reasonCrashLoopBackOff, true
+		}
+	}
+	return out
+}
+
+// Health of a candidate checked before moving to the next step of the schedule
+type Health struct {
+	// Name of the candidate
+	Name string
+	// Ready tells whether all replicas of the candidate are ready
+	Ready bool
+	// Reason explains why the candidate is not ready
+	Reason string
+	// Restarts is the total number of container restarts in pods of the candidate
+	Restarts int32
+}
+
+// CheckCandidateHealth checks readiness of all replicas and restarts of pods of each candidate
+// Candidates of kind Service are always healthy
+func (t *Targets) CheckCandidateHealth(context context.Context) ([]Health, error) {
+	out := make([]Health, len(t.service.Candidates))
+	for i, name := range t.service.Candidates {
+		out[i] = Health{Name: name, Ready: true}
+		if t.service.Kind == "Service" {
+			continue
+		}
+
+		deploy := &appsv1.Deployment{}
+		if err := t.client.Get(context, client.ObjectKey{Namespace: t.namespace, Name: name}, deploy); err != nil {
+			return nil, err
+		}
+		replicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			replicas = *deploy.Spec.Replicas
+		}
+		if deploy.Status.ReadyReplicas < replicas {
+			out[i].Ready, out[i].Reason = false, fmt.Sprintf("%d of %d replicas ready", deploy.Status.ReadyReplicas, replicas)
+		}
+
+		pods, err := listPods(context, t.podReader, deploy)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods {
+			for _, cs := range pod.Status.ContainerStatuses {
+				out[i].Restarts += cs.RestartCount
+			}
+		}
+	}
+	return out, nil
+}
+
+// DeploymentAvailable tells whether the rollout of the deployment is complete and it has available replicas
+// returns the reason if not
+func DeploymentAvailable(deploy *appsv1.Deployment) (bool, string) {
+	if deploy.Status.ObservedGeneration < deploy.Generation {
+		return false, "rollout in progress"
+	}
+	for _, c := range deploy.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == reasonProgressDeadlineExceeded {
+			return false, "rollout exceeded its progress deadline"
+		}
+	}
+	if deploy.Spec.Replicas != nil && deploy.Status.UpdatedReplicas < *deploy.Spec.Replicas {
+		return false, fmt.Sprintf("%d of %d replicas updated", deploy.Status.UpdatedReplicas, *deploy.Spec.Replicas)
+	}
+	if deploy.Status.AvailableReplicas == 0 {
+		return false, "no available replicas"
+	}
+	return true, ""
+}
+
+// crashLooping tells whether any container in pods of the deployment
+// is waiting in CrashLoopBackOff after repeated restarts
+func crashLooping(context context.Context, c client.Reader, deploy *appsv1.Deployment) (bool, error) {
+	pods, err := listPods(context, c, deploy)
+	if err != nil {
+		return false, err
+	}
+
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason == reasonCrashLoopBackOff &&
+				cs.RestartCount >= CrashLoopRestartThreshold {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// listPods returns the pods selected by the deployment
+func listPods(context context.Context, c client.Reader, deploy *appsv1.Deployment) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(context, pods, &client.ListOptions{
+		Namespace:     deploy.Namespace,
+		LabelSelector: selector,
+	}); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
@@ -0,0 +1,167 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+func newTestDeployment(name string, available int32) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: 1},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"version": name}},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  available,
+		},
+	}
+}
+
+func newTestPod(version string, restarts int32, waiting string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      version + "-pod",
+			Namespace: "default",
+			Labels:    map[string]string{"version": version},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+		},
+	}
+	if waiting != "" {
+		pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: waiting}
+	}
+	return pod
+}
+
+func TestDeploymentAvailable(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	deploy := newTestDeployment("reviews-v2", 1)
+	ok, _ := DeploymentAvailable(deploy)
+	g.Expect(ok).To(gomega.BeTrue())
+
+	deploy.Generation = 2
+	ok, reason := DeploymentAvailable(deploy)
+	g.Expect(ok).To(gomega.BeFalse())
+	g.Expect(reason).To(gomega.Equal("rollout in progress"))
+
+	deploy = newTestDeployment("reviews-v2", 1)
+	deploy.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:   appsv1.DeploymentProgressing,
+		Status: corev1.ConditionFalse,
+		Reason: "ProgressDeadlineExceeded",
+	}}
+	ok, _ = DeploymentAvailable(deploy)
+	g.Expect(ok).To(gomega.BeFalse())
+
+	deploy = newTestDeployment("reviews-v2", 1)
+	deploy.Status.UpdatedReplicas = 0
+	ok, _ = DeploymentAvailable(deploy)
+	g.Expect(ok).To(gomega.BeFalse())
+
+	ok, reason = DeploymentAvailable(newTestDeployment("reviews-v2", 0))
+	g.Expect(ok).To(gomega.BeFalse())
+	g.Expect(reason).To(gomega.Equal("no available replicas"))
+}
+
+func TestCheckCandidates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	c := fake.NewFakeClientWithScheme(scheme,
+		newTestDeployment("reviews-v2", 1), newTestPod("reviews-v2", 0, ""),
+		newTestDeployment("reviews-v3", 0),
+		newTestDeployment("reviews-v4", 1), newTestPod("reviews-v4", 5, "CrashLoopBackOff"),
+		newTestDeployment("reviews-v5", 1), newTestPod("reviews-v5", 1, "CrashLoopBackOff"),
+	)
+
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews"},
+				Baseline:        "reviews-v1",
+				Candidates:      []string{"reviews-v2", "reviews-v3", "reviews-v4", "reviews-v5", "reviews-v6"},
+			},
+		},
+	}
+
+	readiness := Init(instance, c).CheckCandidates(context.Background())
+	g.Expect(readiness).To(gomega.HaveLen(5))
+	g.Expect(readiness[0].Ready).To(gomega.BeTrue())
+	g.Expect(readiness[1].Ready).To(gomega.BeFalse())
+	g.Expect(readiness[1].CrashLooping).To(gomega.BeFalse())
+	g.Expect(readiness[2].Ready).To(gomega.BeFalse())
+	g.Expect(readiness[2].CrashLooping).To(gomega.BeTrue())
+	// a few restarts are tolerated
+	g.Expect(readiness[3].Ready).To(gomega.BeTrue())
+	// missing candidate
+	g.Expect(readiness[4].Ready).To(gomega.BeFalse())
+}
+
+func TestCheckCandidateHealth(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	ready := newTestDeployment("reviews-v2", 1)
+	ready.Status.ReadyReplicas = 1
+	c := fake.NewFakeClientWithScheme(scheme, ready, newTestDeployment("reviews-v3", 0))
+	// pods are listed by the pod reader
+	pods := fake.NewFakeClientWithScheme(scheme, newTestPod("reviews-v2", 2, ""))
+
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default"},
+		Spec: iter8v1alpha2.ExperimentSpec{
+			Service: iter8v1alpha2.Service{
+				ObjectReference: &corev1.ObjectReference{Name: "reviews"},
+				Baseline:        "reviews-v1",
+				Candidates:      []string{"reviews-v2", "reviews-v3"},
+			},
+		},
+	}
+
+	health, err := Init(instance, c).WithPodReader(pods).CheckCandidateHealth(context.Background())
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(health).To(gomega.Equal([]Health{
+		{Name: "reviews-v2", Ready: true, Restarts: 2},
+		{Name: "reviews-v3", Ready: false, Reason: "0 of 1 replicas ready"},
+	}))
+
+	// missing candidate
+	instance.Spec.Candidates = append(instance.Spec.Candidates, "reviews-v4")
+	_, err = Init(instance, c).CheckCandidateHealth(context.Background())
+	g.Expect(err).To(gomega.HaveOccurred())
+}
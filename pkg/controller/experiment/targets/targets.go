@@ -49,17 +49,27 @@ type Targets struct {
 	service   iter8v1alpha2.Service
 	namespace string
 	client    client.Client
+	// podReader lists pods of candidates
+	podReader client.Reader
 }
 
 // Init initialize a Targets object with k8s client and namespace of the target service
 func Init(instance *iter8v1alpha2.Experiment, client client.Client) *Targets {
 	return &Targets{
 		client:    client,
+		podReader: client,
 		namespace: instance.ServiceNamespace(),
 		service:   instance.Spec.Service,
 	}
 }
 
+// WithPodReader sets the reader listing pods of candidates, such as one reading from the api server
+// so that pods of the cluster are not cached
+func (t *Targets) WithPodReader(reader client.Reader) *Targets {
+	t.podReader = reader
+	return t
+}
+
 // GetService substantializes internal service in targets
 // returns non-nil error if there is problem in getting the runtime object from cluster
 func (t *Targets) GetService(context context.Context) error {
@@ -103,7 +113,8 @@ func (t *Targets) GetCandidates(context context.Context) (err error) {
 			Namespace: t.namespace,
 		}, t.service.Kind)
 
-		err = getObject(context, t.client, t.Candidates[i])
+		// candidates are not required to be ready here; traffic to them is held until they are
+		err = t.client.Get(context, client.ObjectKey{Namespace: t.namespace, Name: t.service.Candidates[i]}, t.Candidates[i])
 		if err != nil {
 			return
 		}
@@ -16,6 +16,7 @@ package util
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
@@ -29,8 +30,31 @@ const (
 
 	// IstioClientKey is the key used to extract istio client from context
 	IstioClientKey = "istioClient"
+
+	// KubeClientKey is the key used to extract kubernetes client from context
+	KubeClientKey = "kubeClient"
 )
 
+// ConflictError indicates that resources required by the experiment are held by another experiment
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// NewConflictError returns a ConflictError with formatted message
+func NewConflictError(format string, a ...interface{}) error {
+	return &ConflictError{Message: fmt.Sprintf(format, a...)}
+}
+
+// IsConflict tells whether the error is caused by conflict with another experiment
+func IsConflict(err error) bool {
+	_, ok := err.(*ConflictError)
+	return ok
+}
+
 // Logger gets the logger from the context.
 func Logger(ctx context.Context) logr.Logger {
 	return ctx.Value(LoggerKey).(logr.Logger)
@@ -17,7 +17,6 @@ package notifier
 
 import (
 	"context"
-	"os"
 	"reflect"
 
 	"gopkg.in/yaml.v2"
@@ -50,7 +49,7 @@ func getTypedHandler(nc *NotificationCenter) (runtime.Object, toolscache.Filteri
 	handler := toolscache.FilteringResourceEventHandler{
 		FilterFunc: func(obj interface{}) bool {
 			cm := obj.(*corev1.ConfigMap)
-			if cm.GetNamespace() == iter8Namespace() && cm.GetName() == configMapName {
+			if cm.GetNamespace() == nc.namespace && cm.GetName() == configMapName {
 				nc.logger.Info("notifier configmap detected", "name", cm.GetName())
 				return true
 			}
@@ -94,7 +93,7 @@ func updateConfigFromConfigmap(nc *NotificationCenter) func(obj interface{}) {
 			}
 
 			originalConfig, ok := nc.Notifiers[name]
-			if !ok || !reflect.DeepEqual(originalConfig, newConfig) {
+			if !ok || !reflect.DeepEqual(originalConfig.config, &newConfig) {
 				nc.updateNotifier(name, &newConfig)
 			}
 		}
@@ -121,11 +120,3 @@ func removeNotifiers(nc *NotificationCenter) func(obj interface{}) {
 		}
 	}
 }
-
-func iter8Namespace() string {
-	retVal := defaultNamespace
-	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
-		retVal = namespace
-	}
-	return retVal
-}
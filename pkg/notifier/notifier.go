@@ -22,8 +22,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sync"
+	"text/template"
 
 	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
 )
 
@@ -39,12 +42,21 @@ type NotificationCenter struct {
 	m         sync.RWMutex
 	logger    logr.Logger
 	Notifiers map[string]*ConfiguredNotifier
+
+	// channels subscribed by experiments, keyed by namespace/name/subscription of experiment
+	sm            sync.Mutex
+	subscriptions map[string]*ConfiguredNotifier
+	secretReader  client.Reader
+
+	// namespace of the notifier configmap
+	namespace string
 }
 
 // ConfiguredNotifier is the wrapper of the a notifier implementation and its configuration
 type ConfiguredNotifier struct {
 	config *Config
 	impl   Notifier
+	queue  *deliveryQueue
 }
 
 // Notifier is the interface for notifier implementations
@@ -56,28 +68,50 @@ type Notifier interface {
 // NewNotificationCenter returns a new NotificationCenter
 func NewNotificationCenter(logger logr.Logger) *NotificationCenter {
 	return &NotificationCenter{
-		logger:    logger,
-		Notifiers: make(map[string]*ConfiguredNotifier),
+		logger:        logger,
+		Notifiers:     make(map[string]*ConfiguredNotifier),
+		subscriptions: make(map[string]*ConfiguredNotifier),
+		namespace:     defaultNamespace,
 	}
 }
 
-// UpdateNotifier will update the notifier stored inside the center
-func (nc *NotificationCenter) updateNotifier(name string, cfg *Config) {
+// newConfiguredNotifier creates the notifier of a channel with validated config
+func newConfiguredNotifier(name string, cfg *Config, logger logr.Logger) *ConfiguredNotifier {
 	var impl Notifier
+	// template has been validated with the config
+	t, _ := cfg.parseTemplate()
 	switch cfg.Notifier {
 	case NotifierNameSlack:
-		impl = NewSlackWebhook()
+		impl = NewSlackWebhook(t, cfg.Actions)
+	case NotifierNameWebhook:
+		impl = NewWebhook()
+	case NotifierNameTeams:
+		impl = NewTeamsWebhook(t)
+	case NotifierNamePagerDuty:
+		impl = NewPagerDuty(cfg.RoutingKey, t)
 	}
 
-	nc.Notifiers[name] = &ConfiguredNotifier{
+	return &ConfiguredNotifier{
 		config: cfg,
 		impl:   impl,
+		queue:  newDeliveryQueue(name, cfg, logger),
 	}
+}
+
+// UpdateNotifier will update the notifier stored inside the center
+func (nc *NotificationCenter) updateNotifier(name string, cfg *Config) {
+	if old, ok := nc.Notifiers[name]; ok {
+		old.queue.stop()
+	}
+	nc.Notifiers[name] = newConfiguredNotifier(name, cfg, nc.logger)
 	nc.logger.Info("notifier channel updated", "name", name, "level", cfg.Level)
 }
 
 // RemoveNotifier will remove the notifier stored inside the center
 func (nc *NotificationCenter) removeNotifier(name string) {
+	if ntf, ok := nc.Notifiers[name]; ok {
+		ntf.queue.stop()
+	}
 	delete(nc.Notifiers, name)
 	nc.logger.Info("notifier channel removed", "name", name)
 }
@@ -97,20 +131,83 @@ type Config struct {
 	Level string `yaml:"level"`
 
 	// Actions lists the actions that user may want to take during the experiment
+	// Supported values are pause, resume, terminate, approve and promote; only used by slack notifier
 	Actions []string `yaml:"actions,omitempty"`
 
 	// Labels are used to filter out the experiments for report
 	Labels map[string]string `yaml:"labels,omitempty"`
+
+	// RoutingKey is the integration key of PagerDuty service; only used by pagerduty notifier
+	RoutingKey string `yaml:"routingKey,omitempty"`
+
+	// RateLimit is the maximum number of notifications sent to the channel per minute
+	RateLimit int `yaml:"rateLimit,omitempty"`
+
+	// Retries is the maximum number of retries of a failed delivery
+	Retries *int `yaml:"retries,omitempty"`
+
+	// Template is the Go template of notification message; default template of the notifier is used if empty
+	// Not supported by webhook notifier
+	Template string `yaml:"template,omitempty"`
+}
+
+// parseTemplate returns the message template of the channel; nil if not specified
+func (c *Config) parseTemplate() (*template.Template, error) {
+	if c.Template == "" {
+		return nil, nil
+	}
+	return parseTemplate(c.Template)
 }
 
 func (c *Config) validateAndSetDefault() error {
 	switch c.Notifier {
-	case NotifierNameSlack:
-		//valid
+	case NotifierNameSlack, NotifierNameWebhook, NotifierNameTeams:
+		if c.URL == "" {
+			return fmt.Errorf("Missing url for notifier: %s", c.Notifier)
+		}
+	case NotifierNamePagerDuty:
+		if c.RoutingKey == "" {
+			return fmt.Errorf("Missing routingKey for notifier: %s", c.Notifier)
+		}
+		if c.URL == "" {
+			c.URL = PagerDutyEventsURL
+		}
 	default:
 		return fmt.Errorf("Unsupported notifier: %s", c.Notifier)
 	}
 
+	if c.Template != "" {
+		if c.Notifier == NotifierNameWebhook {
+			return fmt.Errorf("Template not supported by notifier: %s", c.Notifier)
+		}
+		if _, err := c.parseTemplate(); err != nil {
+			return fmt.Errorf("Invalid template: %v", err)
+		}
+	}
+
+	for _, action := range c.Actions {
+		if c.Notifier != NotifierNameSlack {
+			return fmt.Errorf("Actions not supported by notifier: %s", c.Notifier)
+		}
+		if _, ok := slackActionLabels[action]; !ok {
+			return fmt.Errorf("Unsupported action: %s", action)
+		}
+	}
+
+	switch {
+	case c.RateLimit == 0:
+		c.RateLimit = DefaultRateLimit
+	case c.RateLimit < 0:
+		return fmt.Errorf("Invalid rateLimit: %d", c.RateLimit)
+	}
+
+	if c.Retries == nil {
+		retries := DefaultRetries
+		c.Retries = &retries
+	} else if *c.Retries < 0 {
+		return fmt.Errorf("Invalid retries: %d", *c.Retries)
+	}
+
 	switch c.Level {
 	case NotifierLevelError, NotifierLevelWarning, NotifierLevelNormal, NotifierLevelVerbose:
 		//valid
@@ -140,17 +237,15 @@ func matchLabels(nLabels, eLabels map[string]string) bool {
 }
 
 // Notify will generate notifications to all the matched notifier specified in the configs
-// Errors occured will only be logged
+// and channels subscribed by the experiment
+// Notifications are queued and delivered asynchronously; errors occured will only be logged
 func (nc *NotificationCenter) Notify(instance *iter8v1alpha2.Experiment, reason string, messageFormat string, messageA ...interface{}) {
-	if len(nc.Notifiers) == 0 {
-		return
-	}
+	nc.notifySubscriptions(instance, reason, messageFormat, messageA...)
 
-	for name, ntf := range nc.Notifiers {
-		// Only send out notification if reason severity is not less than notifier level
-		if reasonSeverity(reason) < level2Int(ntf.config.Level) {
-			continue
-		}
+	nc.m.RLock()
+	defer nc.m.RUnlock()
+
+	for _, ntf := range nc.Notifiers {
 		// match namespace
 		if len(ntf.config.Namespace) > 0 && ntf.config.Namespace != instance.GetNamespace() {
 			continue
@@ -161,13 +256,44 @@ func (nc *NotificationCenter) Notify(instance *iter8v1alpha2.Experiment, reason
 			continue
 		}
 
-		payload := ntf.impl.MakeRequest(instance, reason, messageFormat, messageA...)
-		if err := post(ntf.config.URL, payload); err != nil {
-			nc.logger.Error(err, "Fail to post notification", "channel", name)
-		}
+		ntf.notify(instance, reason, messageFormat, messageA...)
+	}
+}
+
+// RemoveExperiment releases the state kept by notifiers for the experiment, which is being deleted
+func (nc *NotificationCenter) RemoveExperiment(instance *iter8v1alpha2.Experiment) {
+	nc.m.RLock()
+	defer nc.m.RUnlock()
+
+	key := experimentKey(instance)
+	for _, ntf := range nc.Notifiers {
+		ntf.queue.forget(key)
 	}
 }
 
+// experimentKey identifies the experiment in delivery queues
+func experimentKey(instance *iter8v1alpha2.Experiment) string {
+	return string(instance.GetUID()) + "/" + instance.GetNamespace() + "/" + instance.GetName()
+}
+
+// notify queues the notification if reason severity is not less than notifier level
+func (ntf *ConfiguredNotifier) notify(instance *iter8v1alpha2.Experiment, reason string, messageFormat string, messageA ...interface{}) {
+	if reasonSeverity(reason) < level2Int(ntf.config.Level) {
+		return
+	}
+
+	payload := ntf.impl.MakeRequest(instance, reason, messageFormat, messageA...)
+	if payload == nil {
+		// reason not reported by the notifier
+		return
+	}
+	ntf.queue.enqueue(experimentKey(instance),
+		reason+": "+fmt.Sprintf(messageFormat, messageA...),
+		&delivery{url: ntf.config.URL, payload: payload})
+}
+
+var httpClient = &http.Client{Timeout: requestTimeout}
+
 // post sends notification to destination
 // Only reads response status code for now
 func post(url string, payload interface{}) error {
@@ -176,7 +302,7 @@ func post(url string, payload interface{}) error {
 		return err
 	}
 
-	raw, err := http.Post(url, "application/json", bytes.NewBuffer(data))
+	raw, err := httpClient.Post(url, "application/json", bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
@@ -215,16 +341,52 @@ func reasonSeverity(r string) int {
 		iter8v1alpha2.ReasonSyncMetricsError,
 		iter8v1alpha2.ReasonRoutingRulesError,
 		iter8v1alpha2.ReasonAnalyticsServiceError,
-		iter8v1alpha2.ReasonActionPause:
+		iter8v1alpha2.ReasonActionPause,
+		iter8v1alpha2.ReasonApprovalRejected,
+		iter8v1alpha2.ReasonHookFailed,
+		iter8v1alpha2.ReasonCandidateCrashLoop,
+		iter8v1alpha2.ReasonLoadError,
+		iter8v1alpha2.ReasonHealthCheckFailed,
+		iter8v1alpha2.ReasonGuardrailBreached,
+		iter8v1alpha2.ReasonGuardrailError,
+		iter8v1alpha2.ReasonReportError:
 		return 4
 
+	case iter8v1alpha2.ReasonExperimentQueued,
+		iter8v1alpha2.ReasonExperimentNotReady,
+		iter8v1alpha2.ReasonAwaitingApproval,
+		iter8v1alpha2.ReasonApprovalGranted,
+		iter8v1alpha2.ReasonTargetsNotReady,
+		iter8v1alpha2.ReasonTargetsChanged:
+		return 3
+
 	case iter8v1alpha2.ReasonTargetsFound,
 		iter8v1alpha2.ReasonAnalyticsServiceRunning,
 		iter8v1alpha2.ReasonIterationUpdate,
 		iter8v1alpha2.ReasonSyncMetricsSucceeded,
-		iter8v1alpha2.ReasonRoutingRulesReady:
+		iter8v1alpha2.ReasonRoutingRulesReady,
+		iter8v1alpha2.ReasonExperimentDequeued,
+		iter8v1alpha2.ReasonHookSucceeded,
+		iter8v1alpha2.ReasonTargetsReady,
+		iter8v1alpha2.ReasonExperimentReady,
+		iter8v1alpha2.ReasonLoadStarted,
+		iter8v1alpha2.ReasonStepUpdate,
+		iter8v1alpha2.ReasonReportWritten:
 		return 1
 	}
 
 	return 0
 }
+
+// returns the notifier level of reason
+func reasonLevel(r string) string {
+	switch reasonSeverity(r) {
+	case 4:
+		return NotifierLevelError
+	case 3:
+		return NotifierLevelWarning
+	case 1, 0:
+		return NotifierLevelVerbose
+	}
+	return NotifierLevelNormal
+}
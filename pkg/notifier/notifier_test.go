@@ -0,0 +1,154 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+func newTestExperiment() *iter8v1alpha2.Experiment {
+	instance := &iter8v1alpha2.Experiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "exp", Namespace: "default", UID: "1234"},
+	}
+	instance.InitStatus()
+	return instance
+}
+
+func TestPagerDutyEvents(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newTestExperiment()
+	pd := NewPagerDuty("key", nil)
+
+	trigger := pd.MakeRequest(instance, iter8v1alpha2.ReasonTargetsError, "Baseline %s Not Ready", "reviews-v1").(*PagerDutyEvent)
+	g.Expect(trigger.EventAction).To(gomega.Equal(pagerDutyTrigger))
+	g.Expect(trigger.RoutingKey).To(gomega.Equal("key"))
+	g.Expect(trigger.Payload.CustomDetails["message"]).To(gomega.Equal("Baseline reviews-v1 Not Ready"))
+
+	resolve := pd.MakeRequest(instance, iter8v1alpha2.ReasonExperimentCompleted, "").(*PagerDutyEvent)
+	g.Expect(resolve.EventAction).To(gomega.Equal(pagerDutyResolve))
+	g.Expect(resolve.DedupKey).To(gomega.Equal(trigger.DedupKey))
+
+	g.Expect(pd.MakeRequest(instance, iter8v1alpha2.ReasonIterationUpdate, "")).To(gomega.BeNil())
+}
+
+func TestReasonLevel(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for reason, level := range map[string]string{
+		iter8v1alpha2.ReasonTargetsError:     NotifierLevelError,
+		iter8v1alpha2.ReasonApprovalRejected: NotifierLevelError,
+		// waiting for approval is part of the normal course of an experiment
+		iter8v1alpha2.ReasonAwaitingApproval:   NotifierLevelWarning,
+		iter8v1alpha2.ReasonTargetsChanged:     NotifierLevelWarning,
+		iter8v1alpha2.ReasonExperimentNotReady: NotifierLevelWarning,
+		iter8v1alpha2.ReasonExperimentReady:    NotifierLevelVerbose,
+		iter8v1alpha2.ReasonLoadError:          NotifierLevelError,
+		iter8v1alpha2.ReasonLoadStarted:        NotifierLevelVerbose,
+		iter8v1alpha2.ReasonHealthCheckFailed:  NotifierLevelError,
+		iter8v1alpha2.ReasonStepUpdate:         NotifierLevelVerbose,
+		iter8v1alpha2.ReasonGuardrailBreached:  NotifierLevelError,
+		iter8v1alpha2.ReasonGuardrailError:     NotifierLevelError,
+		iter8v1alpha2.ReasonReportError:        NotifierLevelError,
+		iter8v1alpha2.ReasonReportWritten:      NotifierLevelVerbose,
+		iter8v1alpha2.ReasonTargetsFound:       NotifierLevelVerbose,
+	} {
+		g.Expect(reasonLevel(reason)).To(gomega.Equal(level), reason)
+		g.Expect(reasonSeverity(reason)).To(gomega.BeNumerically(">=", level2Int(NotifierLevelVerbose)), reason)
+	}
+
+	pd := NewPagerDuty("key", nil)
+	g.Expect(pd.MakeRequest(newTestExperiment(), iter8v1alpha2.ReasonAwaitingApproval, "")).To(gomega.BeNil())
+}
+
+func TestNotifyWebhook(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received <- body
+	}))
+	defer server.Close()
+
+	cfg := &Config{Notifier: NotifierNameWebhook, URL: server.URL}
+	g.Expect(cfg.validateAndSetDefault()).To(gomega.Succeed())
+
+	nc := NewNotificationCenter(logf.Log)
+	nc.updateNotifier("channel", cfg)
+	nc.Notify(newTestExperiment(), iter8v1alpha2.ReasonTargetsError, "Service Not Ready")
+
+	payload := WebhookPayload{}
+	g.Expect(json.Unmarshal(<-received, &payload)).To(gomega.Succeed())
+	g.Expect(payload.Version).To(gomega.Equal(WebhookPayloadVersion))
+	g.Expect(payload.Severity).To(gomega.Equal(NotifierLevelError))
+	g.Expect(payload.Experiment.Name).To(gomega.Equal("exp"))
+	g.Expect(payload.Status.Phase).To(gomega.Equal(iter8v1alpha2.PhaseProgressing))
+}
+
+func TestValidateConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := &Config{Notifier: NotifierNamePagerDuty}
+	g.Expect(cfg.validateAndSetDefault()).NotTo(gomega.Succeed())
+
+	cfg.RoutingKey = "key"
+	g.Expect(cfg.validateAndSetDefault()).To(gomega.Succeed())
+	g.Expect(cfg.URL).To(gomega.Equal(PagerDutyEventsURL))
+
+	cfg = &Config{Notifier: NotifierNameTeams}
+	g.Expect(cfg.validateAndSetDefault()).NotTo(gomega.Succeed())
+}
+
+func TestSlackTemplates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newTestExperiment()
+	instance.Status.Assessment = &iter8v1alpha2.Assessment{
+		Baseline:   iter8v1alpha2.VersionAssessment{Name: "reviews-v1", Weight: 20},
+		Candidates: []iter8v1alpha2.VersionAssessment{{Name: "reviews-v2", Weight: 80}},
+	}
+
+	// default template restores progress and traffic summary
+	sr := NewSlackWebhook(nil, nil).MakeRequest(instance, iter8v1alpha2.ReasonTrafficUpdate, "New Traffic").(*SlackRequest)
+	g.Expect(sr.Blocks).To(gomega.HaveLen(2))
+	g.Expect(sr.Blocks[1].(SectionBlock).Text.Text).To(gomega.Equal("_New Traffic_\n*Progress:* Iteration 0/100\n*Traffic:* [reviews-v1: 20, reviews-v2: 80]"))
+
+	cfg := &Config{
+		Notifier: NotifierNameSlack,
+		URL:      "http://localhost",
+		Template: "{{ splitReason .Reason }} of {{ .Experiment.Name }} ({{ .Level }})",
+	}
+	g.Expect(cfg.validateAndSetDefault()).To(gomega.Succeed())
+	tmpl, err := cfg.parseTemplate()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	sr = NewSlackWebhook(tmpl, nil).MakeRequest(instance, iter8v1alpha2.ReasonTrafficUpdate, "").(*SlackRequest)
+	g.Expect(sr.Blocks[1].(SectionBlock).Text.Text).To(gomega.Equal("Traffic Update of exp (verbose)"))
+
+	cfg.Template = "{{ .Experiment.Name"
+	g.Expect(cfg.validateAndSetDefault()).NotTo(gomega.Succeed())
+
+	cfg = &Config{Notifier: NotifierNameWebhook, URL: "http://localhost", Template: "{{ .Reason }}"}
+	g.Expect(cfg.validateAndSetDefault()).NotTo(gomega.Succeed())
+}
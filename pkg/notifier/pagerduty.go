@@ -0,0 +1,119 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	NotifierNamePagerDuty = "pagerduty"
+
+	// PagerDutyEventsURL is the default endpoint of PagerDuty Events API v2
+	PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+	pagerDutyTrigger = "trigger"
+	pagerDutyResolve = "resolve"
+	pagerDutySource  = "iter8"
+)
+
+var _ Notifier = (*PagerDuty)(nil)
+
+// PagerDuty is a notifier sending alerts to PagerDuty Events API v2
+// An alert is triggered on error-severity reasons and resolved when the experiment completes;
+// other reasons are not sent.
+type PagerDuty struct {
+	routingKey string
+	template   *template.Template
+}
+
+// DefaultPagerDutyTemplate is the default template of summary of PagerDuty alert
+const DefaultPagerDutyTemplate = `{{ .Experiment.Name }}.{{ .Experiment.Namespace }}: {{ splitReason .Reason }}`
+
+var defaultPagerDutyTemplate = mustParseTemplate(DefaultPagerDutyTemplate)
+
+// NewPagerDuty returns a PagerDuty notifier rendering alert summary with the template; default template is used if nil
+func NewPagerDuty(routingKey string, t *template.Template) *PagerDuty {
+	if t == nil {
+		t = defaultPagerDutyTemplate
+	}
+	return &PagerDuty{routingKey: routingKey, template: t}
+}
+
+type PagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type PagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *PagerDutyPayload `json:"payload,omitempty"`
+}
+
+// MakeRequest implements Notifier MakeRequest function
+func (p *PagerDuty) MakeRequest(instance *iter8v1alpha2.Experiment, reason string, messageFormat string, messageA ...interface{}) interface{} {
+	// alerts of the same experiment are deduplicated by PagerDuty
+	dedupKey := pagerDutySource + "/" + instance.GetNamespace() + "/" + instance.GetName() + "/" + string(instance.GetUID())
+
+	if reason == iter8v1alpha2.ReasonExperimentCompleted {
+		return &PagerDutyEvent{
+			RoutingKey:  p.routingKey,
+			EventAction: pagerDutyResolve,
+			DedupKey:    dedupKey,
+		}
+	}
+
+	if reasonLevel(reason) != NotifierLevelError {
+		return nil
+	}
+
+	component := ""
+	if instance.Spec.Service.ObjectReference != nil {
+		component = instance.Spec.Service.Name
+	}
+
+	message := fmt.Sprintf(messageFormat, messageA...)
+	return &PagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: pagerDutyTrigger,
+		DedupKey:    dedupKey,
+		Payload: &PagerDutyPayload{
+			Summary:   renderTemplate(p.template, instance, reason, messageFormat, messageA...),
+			Source:    pagerDutySource,
+			Severity:  "error",
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Component: component,
+			Group:     instance.GetNamespace(),
+			Class:     reason,
+			CustomDetails: map[string]string{
+				"message": message,
+				"phase":   string(instance.Status.Phase),
+			},
+		},
+	}
+}
@@ -0,0 +1,180 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// DefaultRateLimit is the default maximum number of notifications sent to a channel per minute
+	DefaultRateLimit = 20
+	// DefaultRetries is the default maximum number of retries of a failed delivery
+	DefaultRetries = 3
+
+	// number of notifications buffered per channel before being dropped
+	queueSize = 100
+	// timeout of a single request to notification endpoint
+	requestTimeout = 10 * time.Second
+
+	resultSuccess      = "success"
+	resultFailure      = "failure"
+	resultDropped      = "dropped"
+	resultDeduplicated = "deduplicated"
+)
+
+var (
+	// delay before the first retry; it is doubled after each retry
+	retryDelay = time.Second
+
+	// deliveries counts notifications per channel by result:
+	// success, failure (retries exhausted), dropped (queue full) and deduplicated
+	deliveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iter8_notification_deliveries_total",
+			Help: "Number of notifications handled by notifier channels, partitioned by channel and result",
+		},
+		[]string{"channel", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(deliveries)
+}
+
+// delivery is a notification waiting to be sent
+type delivery struct {
+	url     string
+	payload interface{}
+}
+
+// deliveryQueue sends notifications of a channel asynchronously
+// Notifications are sent in order, no faster than the rate limit of the channel,
+// and retried with exponential backoff until retries are exhausted.
+type deliveryQueue struct {
+	channel string
+	retries int
+	limiter *rate.Limiter
+	queue   chan *delivery
+	cancel  context.CancelFunc
+	logger  logr.Logger
+
+	m sync.Mutex
+	// last message enqueued per experiment; used to deduplicate consecutive messages
+	last map[string]string
+}
+
+// newDeliveryQueue creates a queue and starts its worker
+func newDeliveryQueue(channel string, cfg *Config, logger logr.Logger) *deliveryQueue {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &deliveryQueue{
+		channel: channel,
+		retries: *cfg.Retries,
+		limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(cfg.RateLimit)), 1),
+		queue:   make(chan *delivery, queueSize),
+		cancel:  cancel,
+		logger:  logger,
+		last:    make(map[string]string),
+	}
+	go q.run(ctx)
+	return q
+}
+
+// enqueue adds a notification to the queue without blocking
+// Returns false if it is identical to the last message of the experiment or the queue is full
+func (q *deliveryQueue) enqueue(experiment, message string, d *delivery) bool {
+	q.m.Lock()
+	defer q.m.Unlock()
+	if last, ok := q.last[experiment]; ok && last == message {
+		deliveries.WithLabelValues(q.channel, resultDeduplicated).Inc()
+		return false
+	}
+
+	select {
+	case q.queue <- d:
+		// a dropped message is not recorded, so that it is sent if it occurs again
+		q.last[experiment] = message
+		return true
+	default:
+		deliveries.WithLabelValues(q.channel, resultDropped).Inc()
+		q.logger.Info("Notification dropped, queue full", "channel", q.channel)
+		return false
+	}
+}
+
+// forget removes the last message of the experiment
+func (q *deliveryQueue) forget(experiment string) {
+	q.m.Lock()
+	defer q.m.Unlock()
+	delete(q.last, experiment)
+}
+
+// stop terminates the worker; queued notifications are discarded
+func (q *deliveryQueue) stop() {
+	q.cancel()
+}
+
+// drain terminates the worker after queued notifications are delivered
+// No notification can be enqueued afterwards
+func (q *deliveryQueue) drain() {
+	close(q.queue)
+}
+
+func (q *deliveryQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d, ok := <-q.queue:
+			if !ok {
+				return
+			}
+			if err := q.limiter.Wait(ctx); err != nil {
+				return
+			}
+			if err := q.deliver(ctx, d); err != nil {
+				deliveries.WithLabelValues(q.channel, resultFailure).Inc()
+				q.logger.Error(err, "Fail to post notification", "channel", q.channel)
+			} else {
+				deliveries.WithLabelValues(q.channel, resultSuccess).Inc()
+			}
+		}
+	}
+}
+
+// deliver posts the notification, retrying with exponential backoff
+func (q *deliveryQueue) deliver(ctx context.Context, d *delivery) (err error) {
+	delay := retryDelay
+	for attempt := 0; ; attempt++ {
+		if err = post(d.url, d.payload); err == nil || attempt >= q.retries {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+			delay *= 2
+		}
+	}
+}
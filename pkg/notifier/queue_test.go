@@ -0,0 +1,146 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+// newFlakyServer returns a server failing the first failures requests and counting all requests
+func newFlakyServer(failures int32) (*httptest.Server, *int32) {
+	count := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	return server, &count
+}
+
+func newTestQueue(channel string, retries int) *deliveryQueue {
+	cfg := &Config{Notifier: NotifierNameSlack, URL: "http://localhost", RateLimit: 6000, Retries: &retries}
+	return newDeliveryQueue(channel, cfg, logf.Log)
+}
+
+func TestDeliveryRetry(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	retryDelay = time.Millisecond
+
+	server, count := newFlakyServer(2)
+	defer server.Close()
+
+	q := newTestQueue("retry", 2)
+	defer q.stop()
+
+	g.Expect(q.enqueue("exp", "msg", &delivery{url: server.URL, payload: "msg"})).To(gomega.BeTrue())
+	g.Eventually(func() float64 {
+		return testutil.ToFloat64(deliveries.WithLabelValues("retry", resultSuccess))
+	}).Should(gomega.Equal(float64(1)))
+	g.Expect(atomic.LoadInt32(count)).To(gomega.Equal(int32(3)))
+}
+
+func TestDeliveryFailure(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	retryDelay = time.Millisecond
+
+	server, count := newFlakyServer(10)
+	defer server.Close()
+
+	q := newTestQueue("failure", 1)
+	defer q.stop()
+
+	g.Expect(q.enqueue("exp", "msg", &delivery{url: server.URL, payload: "msg"})).To(gomega.BeTrue())
+	g.Eventually(func() float64 {
+		return testutil.ToFloat64(deliveries.WithLabelValues("failure", resultFailure))
+	}).Should(gomega.Equal(float64(1)))
+	g.Expect(atomic.LoadInt32(count)).To(gomega.Equal(int32(2)))
+}
+
+func TestDeliveryDeduplication(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server, count := newFlakyServer(0)
+	defer server.Close()
+
+	q := newTestQueue("dedup", 0)
+	defer q.stop()
+
+	d := &delivery{url: server.URL, payload: "msg"}
+	g.Expect(q.enqueue("exp", "msg", d)).To(gomega.BeTrue())
+	g.Expect(q.enqueue("exp", "msg", d)).To(gomega.BeFalse())
+	// same message of another experiment is not a duplicate
+	g.Expect(q.enqueue("exp-2", "msg", d)).To(gomega.BeTrue())
+	// only consecutive messages are deduplicated
+	g.Expect(q.enqueue("exp", "other", d)).To(gomega.BeTrue())
+	g.Expect(q.enqueue("exp", "msg", d)).To(gomega.BeTrue())
+
+	g.Eventually(func() int32 { return atomic.LoadInt32(count) }).Should(gomega.Equal(int32(4)))
+	g.Expect(testutil.ToFloat64(deliveries.WithLabelValues("dedup", resultDeduplicated))).To(gomega.Equal(float64(1)))
+}
+
+func TestDeliveryQueueFull(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// no worker is started, so that the queue fills up
+	q := &deliveryQueue{
+		channel: "full",
+		queue:   make(chan *delivery, queueSize),
+		logger:  logf.Log,
+		last:    make(map[string]string),
+	}
+	for i := 0; i < queueSize; i++ {
+		q.queue <- &delivery{}
+	}
+
+	d := &delivery{url: "http://localhost", payload: "msg"}
+	g.Expect(q.enqueue("exp", "msg", d)).To(gomega.BeFalse())
+	g.Expect(testutil.ToFloat64(deliveries.WithLabelValues("full", resultDropped))).To(gomega.Equal(float64(1)))
+
+	// a dropped message is sent once there is room in the queue
+	<-q.queue
+	g.Expect(q.enqueue("exp", "msg", d)).To(gomega.BeTrue())
+	g.Expect(q.last).To(gomega.HaveKeyWithValue("exp", "msg"))
+
+	q.forget("exp")
+	g.Expect(q.last).To(gomega.BeEmpty())
+}
+
+func TestDeliveryRateLimit(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server, count := newFlakyServer(0)
+	defer server.Close()
+
+	retries := 0
+	cfg := &Config{Notifier: NotifierNameSlack, URL: server.URL, RateLimit: 1, Retries: &retries}
+	q := newDeliveryQueue("ratelimit", cfg, logf.Log)
+	defer q.stop()
+
+	q.enqueue("exp", "first", &delivery{url: server.URL, payload: "first"})
+	q.enqueue("exp", "second", &delivery{url: server.URL, payload: "second"})
+
+	// second notification waits for a minute
+	g.Eventually(func() int32 { return atomic.LoadInt32(count) }).Should(gomega.Equal(int32(1)))
+	g.Consistently(func() int32 { return atomic.LoadInt32(count) }, 200*time.Millisecond).Should(gomega.Equal(int32(1)))
+}
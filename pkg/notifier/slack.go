@@ -16,8 +16,8 @@ limitations under the License.
 package notifier
 
 import (
-	"fmt"
 	"strings"
+	"text/template"
 
 	"github.com/fatih/camelcase"
 	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
@@ -27,16 +27,54 @@ const (
 	NotifierNameSlack = "slack"
 
 	SectionBlockType = "section"
+	ActionsBlockType = "actions"
 
-	MarkdownTextType = "mrkdwn"
+	MarkdownTextType  = "mrkdwn"
+	PlainTextType     = "plain_text"
+	ButtonElementType = "button"
+
+	// slackActionPrefix prefixes the action_id of buttons; the rest is the override action
+	slackActionPrefix = "iter8-"
+
+	// SlackActionPromote terminates the experiment sending all traffic to the winner
+	SlackActionPromote = "promote"
 )
 
+// slackActionLabels are the button labels of supported actions
+var slackActionLabels = map[string]string{
+	string(iter8v1alpha2.ActionPause):     "Pause",
+	string(iter8v1alpha2.ActionResume):    "Resume",
+	string(iter8v1alpha2.ActionTerminate): "Terminate",
+	string(iter8v1alpha2.ActionApprove):   "Approve",
+	SlackActionPromote:                    "Promote Winner",
+}
+
 var _ Notifier = (*SlackWebhook)(nil)
 
-type SlackWebhook struct{}
+// DefaultSlackTemplate is the default template of details of slack notification
+const DefaultSlackTemplate = `{{ with .Message }}_{{ . }}_{{ end }}
+*Progress:* {{ progress .Experiment }}
+{{- if .Experiment.Status.Assessment }}
+*Traffic:* {{ .Experiment.Status.TrafficToString }}
+{{- end }}
+{{- if .Experiment.Status.IsWinnerAssessmentAvailable }}
+*Assessment:* {{ .Experiment.Status.WinnerToString }}
+{{- end }}`
+
+var defaultSlackTemplate = mustParseTemplate(DefaultSlackTemplate)
+
+type SlackWebhook struct {
+	template *template.Template
+	actions  []string
+}
 
-func NewSlackWebhook() *SlackWebhook {
-	return &SlackWebhook{}
+// NewSlackWebhook returns a slack notifier rendering details with the template; default template is used if nil
+// Buttons of the actions are attached to notifications of running experiments
+func NewSlackWebhook(t *template.Template, actions []string) *SlackWebhook {
+	if t == nil {
+		t = defaultSlackTemplate
+	}
+	return &SlackWebhook{template: t, actions: actions}
 }
 
 type MarkdownText struct {
@@ -49,6 +87,23 @@ type SectionBlock struct {
 	Text MarkdownText `json:"text"`
 }
 
+type ActionsBlock struct {
+	Type     string          `json:"type"`
+	Elements []ButtonElement `json:"elements"`
+}
+
+type ButtonElement struct {
+	Type     string    `json:"type"`
+	Text     PlainText `json:"text"`
+	ActionID string    `json:"action_id"`
+	Value    string    `json:"value"`
+}
+
+type PlainText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
 type Field struct {
 	Title string `json:"title"`
 	Value string `json:"value"`
@@ -64,9 +119,9 @@ type Attachment struct {
 }
 
 type SlackRequest struct {
-	Text        string         `json:"text"`
-	Blocks      []SectionBlock `json:"blocks"`
-	Attachments []Attachment   `json:"attachments"`
+	Text        string        `json:"text"`
+	Blocks      []interface{} `json:"blocks"`
+	Attachments []Attachment  `json:"attachments"`
 }
 
 // MakeRequest implements Notifier MakeRequest function
@@ -87,58 +142,29 @@ func (s *SlackWebhook) MakeRequest(instance *iter8v1alpha2.Experiment, reason st
 		},
 	})
 
-	details := fmt.Sprintf(messageFormat, messageA...)
+	details := renderTemplate(s.template, instance, reason, messageFormat, messageA...)
 	if len(details) > 0 {
 		sr.Blocks = append(sr.Blocks, SectionBlock{
 			Type: SectionBlockType,
 			Text: MarkdownText{
 				Type: MarkdownTextType,
-				Text: "_" + details + "_",
+				Text: details,
 			},
 		})
 	}
 
-	// color := "good"
-
-	// if reasonSeverity(reason) >= 3 {
-	// 	switch reason {
-	// 	case iter8v1alpha2.ReasonExperimentCompleted:
-	// 		//do nothing
-	// 	default:
-	// 		color = "danger"
-	// 	}
-	// }
-
-	// progress := ""
-	// if *instance.Status.CurrentIteration > instance.Spec.GetMaxIterations() ||
-	// 	reason == iter8v1alpha2.ReasonExperimentCompleted {
-	// 	progress = "Experiment Completed"
-	// } else {
-	// 	progress = "Current Iteration: " + strconv.Itoa(int(*instance.Status.CurrentIteration)) + "/" + strconv.Itoa(int(instance.Spec.GetMaxIterations()))
-	// }
-
-	// sr.Attachments = []Attachment{{
-	// 	Fields: []Field{
-	// 		{
-	// 			Title: "Traffic",
-	// 			Value: "Baseline: " + strconv.Itoa(instance.Status.TrafficSplit.Baseline) +
-	// 				"  Candidate: " + strconv.Itoa(instance.Status.TrafficSplit.Candidate),
-	// 			Short: false,
-	// 		},
-	// 		{
-	// 			Title: "Progress",
-	// 			Value: progress,
-	// 			Short: true,
-	// 		},
-	// 		{
-	// 			Title: "Analytics Assessment Summary",
-	// 			Value: instance.Status.AssessmentSummary.Assessment2String(),
-	// 			Short: false,
-	// 		},
-	// 	},
-	// 	Color: color,
-	// 	Ts:    time.Now().Unix(),
-	// }}
+	if len(s.actions) > 0 && !instance.Status.ExperimentCompleted() {
+		block := ActionsBlock{Type: ActionsBlockType}
+		for _, action := range s.actions {
+			block.Elements = append(block.Elements, ButtonElement{
+				Type:     ButtonElementType,
+				Text:     PlainText{Type: PlainTextType, Text: slackActionLabels[action]},
+				ActionID: slackActionPrefix + action,
+				Value:    instance.GetNamespace() + "/" + instance.GetName(),
+			})
+		}
+		sr.Blocks = append(sr.Blocks, block)
+	}
 
 	return sr
 }
@@ -0,0 +1,291 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	// SlackCallbackPath is the path of the endpoint receiving interactions with slack notifications
+	SlackCallbackPath = "/slack/actions"
+
+	// SlackSigningSecretEnv is the environment variable holding the signing secret of the slack app
+	SlackSigningSecretEnv = "SLACK_SIGNING_SECRET"
+
+	// ReasonSlackAction is the reason of events recorded when an action is triggered from slack
+	ReasonSlackAction = "SlackActionTriggered"
+
+	slackSignatureVersion = "v0"
+	slackSignatureHeader  = "X-Slack-Signature"
+	slackTimestampHeader  = "X-Slack-Request-Timestamp"
+	slackBlockActions     = "block_actions"
+
+	// maxSlackRequestAge is the maximum age of accepted requests, to prevent replay attacks
+	maxSlackRequestAge  = 5 * time.Minute
+	maxSlackRequestSize = 1 << 20
+)
+
+var _ manager.Runnable = (*SlackCallbackServer)(nil)
+
+// SlackCallbackServer receives clicks on buttons of slack notifications
+// and applies the actions to experiments as manual overrides
+type SlackCallbackServer struct {
+	addr          string
+	signingSecret []byte
+	client        client.Client
+	recorder      record.EventRecorder
+	logger        logr.Logger
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// SlackInteraction is the payload of interactions with slack messages
+type SlackInteraction struct {
+	Type    string        `json:"type"`
+	User    SlackUser     `json:"user"`
+	Actions []SlackAction `json:"actions"`
+}
+
+// SlackUser is the user triggering the interaction
+type SlackUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// SlackAction is the action triggered by the interaction
+type SlackAction struct {
+	ActionID string `json:"action_id"`
+	Value    string `json:"value"`
+}
+
+// NewSlackCallbackServer returns a server listening on addr
+func NewSlackCallbackServer(addr, signingSecret string, c client.Client, recorder record.EventRecorder, logger logr.Logger) *SlackCallbackServer {
+	return &SlackCallbackServer{
+		addr:          addr,
+		signingSecret: []byte(signingSecret),
+		client:        c,
+		recorder:      recorder,
+		logger:        logger.WithName("slack-callback"),
+		now:           time.Now,
+	}
+}
+
+// Start implements manager.Runnable; serves the callback endpoint until stop is closed
+func (s *SlackCallbackServer) Start(stop <-chan struct{}) error {
+	mux := http.NewServeMux()
+	mux.Handle(SlackCallbackPath, s)
+	srv := &http.Server{Addr: s.addr, Handler: mux}
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Info("serving slack callback", "addr", s.addr, "path", SlackCallbackPath)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case <-stop:
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		return srv.Shutdown(ctx)
+	case err := <-errCh:
+		return err
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable; callbacks are served by every replica
+// of the controller since slack may reach any of them
+func (s *SlackCallbackServer) NeedLeaderElection() bool {
+	return false
+}
+
+// ServeHTTP verifies the signature of the request and applies the triggered actions
+func (s *SlackCallbackServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxSlackRequestSize))
+	if err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.verify(req.Header, body); err != nil {
+		s.logger.Info("RejectedSlackRequest", "reason", err.Error())
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	interaction, err := parseSlackInteraction(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, action := range interaction.Actions {
+		if err := s.apply(req.Context(), action, interaction.User); err != nil {
+			s.logger.Error(err, "Fail to apply slack action", "action", action.ActionID, "experiment", action.Value)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify checks the timestamp and signature of the request
+// See https://api.slack.com/authentication/verifying-requests-from-slack
+func (s *SlackCallbackServer) verify(header http.Header, body []byte) error {
+	ts := header.Get(slackTimestampHeader)
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid timestamp: %q", ts)
+	}
+	age := s.now().Sub(time.Unix(sec, 0))
+	if age > maxSlackRequestAge || age < -maxSlackRequestAge {
+		return fmt.Errorf("Stale timestamp: %s", ts)
+	}
+
+	expected := slackSignature(s.signingSecret, ts, body)
+	if !hmac.Equal([]byte(expected), []byte(header.Get(slackSignatureHeader))) {
+		return fmt.Errorf("Signature mismatch")
+	}
+	return nil
+}
+
+// slackSignature computes the signature of the request body signed at timestamp ts
+func slackSignature(secret []byte, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(slackSignatureVersion + ":" + ts + ":"))
+	mac.Write(body)
+	return slackSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseSlackInteraction decodes the payload field of the form-encoded body
+func parseSlackInteraction(body []byte) (*SlackInteraction, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid form: %v", err)
+	}
+
+	interaction := &SlackInteraction{}
+	if err := json.Unmarshal([]byte(form.Get("payload")), interaction); err != nil {
+		return nil, fmt.Errorf("Invalid payload: %v", err)
+	}
+	if interaction.Type != slackBlockActions {
+		return nil, fmt.Errorf("Unsupported interaction: %s", interaction.Type)
+	}
+	return interaction, nil
+}
+
+// apply sets the manual override of the experiment referred by the action
+// and records the slack user triggering it
+func (s *SlackCallbackServer) apply(ctx context.Context, action SlackAction, user SlackUser) error {
+	name := strings.TrimPrefix(action.ActionID, slackActionPrefix)
+	if _, ok := slackActionLabels[name]; !ok || name == action.ActionID {
+		return fmt.Errorf("Unsupported action: %s", action.ActionID)
+	}
+
+	parts := strings.SplitN(action.Value, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("Invalid experiment: %s", action.Value)
+	}
+	key := types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+
+	triggeredBy := "slack:" + user.Username
+	if user.Username == "" {
+		triggeredBy = "slack:" + user.ID
+	}
+
+	instance := &iter8v1alpha2.Experiment{}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := s.client.Get(ctx, key, instance); err != nil {
+			return err
+		}
+		if instance.Status.ExperimentCompleted() {
+			return fmt.Errorf("Experiment %s is completed", action.Value)
+		}
+
+		override, err := slackOverride(instance, name)
+		if err != nil {
+			return err
+		}
+		instance.Spec.ManualOverride = override
+
+		annotations := instance.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[iter8v1alpha2.AnnotationOverrideBy] = triggeredBy
+		instance.SetAnnotations(annotations)
+
+		return s.client.Update(ctx, instance)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("SlackActionApplied", "experiment", action.Value, "action", name, "user", triggeredBy)
+	if s.recorder != nil {
+		s.recorder.Eventf(instance, corev1.EventTypeNormal, ReasonSlackAction,
+			"Action %s triggered by %s", name, triggeredBy)
+	}
+	return nil
+}
+
+// slackOverride maps the slack action to the manual override of the experiment
+// Promote terminates the experiment with all traffic sent to the winner
+func slackOverride(instance *iter8v1alpha2.Experiment, action string) (*iter8v1alpha2.ManualOverride, error) {
+	if action != SlackActionPromote {
+		return &iter8v1alpha2.ManualOverride{Action: iter8v1alpha2.ActionType(action)}, nil
+	}
+
+	if !instance.Status.IsWinnerFound() || instance.Status.Assessment.Winner.Name == nil {
+		return nil, fmt.Errorf("No winner found for experiment %s", instance.GetName())
+	}
+	return &iter8v1alpha2.ManualOverride{
+		Action: iter8v1alpha2.ActionTerminate,
+		TrafficSplit: map[string]int32{
+			*instance.Status.Assessment.Winner.Name: 100,
+		},
+	}, nil
+}
@@ -0,0 +1,155 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	analyticsv1alpha2 "github.com/iter8-tools/iter8-istio/pkg/analytics/api/v1alpha2"
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const testSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+func newTestSlackCallbackServer(objs ...runtime.Object) *SlackCallbackServer {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = iter8v1alpha2.AddToScheme(scheme)
+	return NewSlackCallbackServer(":0", testSigningSecret, fake.NewFakeClientWithScheme(scheme, objs...),
+		record.NewFakeRecorder(10), logf.Log)
+}
+
+func newSlackActionRequest(ts time.Time, secret string, actionID string, value string) *http.Request {
+	payload, _ := json.Marshal(SlackInteraction{
+		Type:    slackBlockActions,
+		User:    SlackUser{ID: "U123", Username: "alice"},
+		Actions: []SlackAction{{ActionID: actionID, Value: value}},
+	})
+	body := url.Values{"payload": []string{string(payload)}}.Encode()
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+
+	req := httptest.NewRequest(http.MethodPost, SlackCallbackPath, strings.NewReader(body))
+	req.Header.Set(slackTimestampHeader, timestamp)
+	req.Header.Set(slackSignatureHeader, slackSignature([]byte(secret), timestamp, []byte(body)))
+	return req
+}
+
+func TestSlackCallbackSignature(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := newTestSlackCallbackServer(newTestExperiment())
+	now := time.Now()
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, newSlackActionRequest(now, "wrong", "iter8-pause", "default/exp"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusUnauthorized))
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, newSlackActionRequest(now.Add(-10*time.Minute), testSigningSecret, "iter8-pause", "default/exp"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusUnauthorized))
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, newSlackActionRequest(now, testSigningSecret, "iter8-pause", "default/exp"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+}
+
+func TestSlackCallbackActions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := newTestSlackCallbackServer(newTestExperiment())
+	key := types.NamespacedName{Namespace: "default", Name: "exp"}
+	now := time.Now()
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, newSlackActionRequest(now, testSigningSecret, "iter8-pause", "default/exp"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+	exp := &iter8v1alpha2.Experiment{}
+	g.Expect(s.client.Get(context.Background(), key, exp)).To(gomega.Succeed())
+	g.Expect(exp.Spec.GetAction()).To(gomega.Equal(iter8v1alpha2.ActionPause))
+	g.Expect(exp.GetAnnotations()[iter8v1alpha2.AnnotationOverrideBy]).To(gomega.Equal("slack:alice"))
+	g.Expect(<-s.recorder.(*record.FakeRecorder).Events).To(gomega.ContainSubstring("pause triggered by slack:alice"))
+
+	// no winner to promote
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, newSlackActionRequest(now, testSigningSecret, "iter8-promote", "default/exp"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+
+	winner := "reviews-v2"
+	exp.Status.Assessment = &iter8v1alpha2.Assessment{
+		Winner: &iter8v1alpha2.WinnerAssessment{
+			Name:             &winner,
+			WinnerAssessment: &analyticsv1alpha2.WinnerAssessment{WinnerFound: true},
+		},
+	}
+	g.Expect(s.client.Update(context.Background(), exp)).To(gomega.Succeed())
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, newSlackActionRequest(now, testSigningSecret, "iter8-promote", "default/exp"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(s.client.Get(context.Background(), key, exp)).To(gomega.Succeed())
+	g.Expect(exp.Spec.GetAction()).To(gomega.Equal(iter8v1alpha2.ActionTerminate))
+	g.Expect(exp.Spec.ManualOverride.TrafficSplit).To(gomega.Equal(map[string]int32{winner: 100}))
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, newSlackActionRequest(now, testSigningSecret, "iter8-unknown", "default/exp"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, newSlackActionRequest(now, testSigningSecret, "iter8-resume", "default/missing"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+}
+
+func TestSlackActionButtons(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := newTestExperiment()
+
+	cfg := &Config{Notifier: NotifierNameSlack, URL: "http://localhost", Actions: []string{"pause", "promote"}}
+	g.Expect(cfg.validateAndSetDefault()).To(gomega.Succeed())
+
+	sr := NewSlackWebhook(nil, cfg.Actions).MakeRequest(instance, iter8v1alpha2.ReasonIterationUpdate, "").(*SlackRequest)
+	block, ok := sr.Blocks[len(sr.Blocks)-1].(ActionsBlock)
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(block.Elements).To(gomega.HaveLen(2))
+	g.Expect(block.Elements[1].ActionID).To(gomega.Equal("iter8-promote"))
+	g.Expect(block.Elements[1].Value).To(gomega.Equal("default/exp"))
+
+	// no buttons once the experiment is completed
+	instance.Status.MarkExperimentCompleted("")
+	sr = NewSlackWebhook(nil, cfg.Actions).MakeRequest(instance, iter8v1alpha2.ReasonExperimentCompleted, "").(*SlackRequest)
+	_, ok = sr.Blocks[len(sr.Blocks)-1].(ActionsBlock)
+	g.Expect(ok).To(gomega.BeFalse())
+
+	cfg = &Config{Notifier: NotifierNameSlack, URL: "http://localhost", Actions: []string{"rollback"}}
+	g.Expect(cfg.validateAndSetDefault()).NotTo(gomega.Succeed())
+
+	cfg = &Config{Notifier: NotifierNameTeams, URL: "http://localhost", Actions: []string{"pause"}}
+	g.Expect(cfg.validateAndSetDefault()).NotTo(gomega.Succeed())
+}
@@ -0,0 +1,148 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	// SecretKeyURL is the key of url of notifier in secrets referenced by subscriptions
+	SecretKeyURL = "url"
+	// SecretKeyRoutingKey is the key of routing key of PagerDuty in secrets referenced by subscriptions
+	SecretKeyRoutingKey = "routingKey"
+)
+
+// SetSecretReader sets the reader used to get secrets referenced by notification subscriptions of experiments
+// Secrets are read on every notification sent to subscribed channels, which only happens when the status changes
+// Subscriptions are ignored if not set
+func (nc *NotificationCenter) SetSecretReader(reader client.Reader) {
+	nc.secretReader = reader
+}
+
+// SetNamespace sets the namespace of the notifier configmap; it must be called before RegisterHandler
+func (nc *NotificationCenter) SetNamespace(namespace string) {
+	nc.namespace = namespace
+}
+
+// subscriptionPrefix returns the prefix of names of channels subscribed by the experiment
+func subscriptionPrefix(instance *iter8v1alpha2.Experiment) string {
+	return instance.GetNamespace() + "/" + instance.GetName() + "/"
+}
+
+// notifySubscriptions sends notification to channels subscribed by the experiment
+// Channels are created and updated from the spec of the experiment and the secrets it references.
+// Channels of completed experiments are removed once the notification is delivered.
+func (nc *NotificationCenter) notifySubscriptions(instance *iter8v1alpha2.Experiment, reason string, messageFormat string, messageA ...interface{}) {
+	if nc.secretReader == nil {
+		return
+	}
+
+	nc.sm.Lock()
+	defer nc.sm.Unlock()
+
+	prefix := subscriptionPrefix(instance)
+	current := make(map[string]bool)
+	for _, sub := range instance.Spec.Notifications {
+		name := prefix + sub.Name
+		current[name] = true
+
+		cfg, err := nc.subscriptionConfig(instance, sub)
+		if err != nil {
+			nc.logger.Error(err, "Invalid notification subscription", "name", name)
+			continue
+		}
+
+		ntf, ok := nc.subscriptions[name]
+		if !ok || !reflect.DeepEqual(ntf.config, cfg) {
+			if ok {
+				ntf.queue.stop()
+			}
+			ntf = newConfiguredNotifier(name, cfg, nc.logger)
+			nc.subscriptions[name] = ntf
+			nc.logger.Info("notification subscription updated", "name", name, "level", cfg.Level)
+		}
+
+		ntf.notify(instance, reason, messageFormat, messageA...)
+	}
+
+	// remove channels no longer subscribed
+	for name, ntf := range nc.subscriptions {
+		if strings.HasPrefix(name, prefix) && !current[name] {
+			ntf.queue.stop()
+			delete(nc.subscriptions, name)
+			nc.logger.Info("notification subscription removed", "name", name)
+		}
+	}
+
+	if instance.Status.ExperimentCompleted() {
+		nc.drainSubscriptions(prefix)
+	}
+}
+
+// subscriptionConfig resolves the config of a subscription with the secret it references
+func (nc *NotificationCenter) subscriptionConfig(instance *iter8v1alpha2.Experiment, sub iter8v1alpha2.NotificationSubscription) (*Config, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: instance.GetNamespace(), Name: sub.SecretRef.Name}
+	if err := nc.secretReader.Get(context.Background(), key, secret); err != nil {
+		return nil, fmt.Errorf("Fail to get secret %s: %v", key, err)
+	}
+
+	cfg := &Config{
+		Notifier:   sub.Notifier,
+		URL:        string(secret.Data[SecretKeyURL]),
+		RoutingKey: string(secret.Data[SecretKeyRoutingKey]),
+	}
+	if sub.Level != nil {
+		cfg.Level = *sub.Level
+	}
+	if sub.Template != nil {
+		cfg.Template = *sub.Template
+	}
+
+	if err := cfg.validateAndSetDefault(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// RemoveSubscriptions removes channels subscribed by the experiment
+// Notifications already queued are still delivered
+func (nc *NotificationCenter) RemoveSubscriptions(instance *iter8v1alpha2.Experiment) {
+	nc.sm.Lock()
+	defer nc.sm.Unlock()
+
+	nc.drainSubscriptions(subscriptionPrefix(instance))
+}
+
+// drainSubscriptions removes channels with the prefix after their queued notifications are delivered
+func (nc *NotificationCenter) drainSubscriptions(prefix string) {
+	for name, ntf := range nc.subscriptions {
+		if strings.HasPrefix(name, prefix) {
+			ntf.queue.drain()
+			delete(nc.subscriptions, name)
+		}
+	}
+}
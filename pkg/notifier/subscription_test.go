@@ -0,0 +1,91 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+func TestNotifySubscriptions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	received := make(chan []byte, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received <- body
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-webhook", Namespace: "default"},
+		Data:       map[string][]byte{SecretKeyURL: []byte(server.URL)},
+	}
+	level := NotifierLevelError
+
+	instance := newTestExperiment()
+	instance.Spec.Notifications = []iter8v1alpha2.NotificationSubscription{{
+		Name:      "team",
+		Notifier:  NotifierNameWebhook,
+		SecretRef: corev1.LocalObjectReference{Name: "team-webhook"},
+		Level:     &level,
+	}, {
+		Name:      "missing",
+		Notifier:  NotifierNameWebhook,
+		SecretRef: corev1.LocalObjectReference{Name: "missing"},
+	}}
+
+	nc := NewNotificationCenter(logf.Log)
+	nc.SetSecretReader(fake.NewFakeClient(secret))
+
+	// filtered by level of subscription
+	nc.Notify(instance, iter8v1alpha2.ReasonIterationUpdate, "")
+	nc.Notify(instance, iter8v1alpha2.ReasonTargetsError, "Service Not Ready")
+
+	payload := WebhookPayload{}
+	g.Expect(json.Unmarshal(<-received, &payload)).To(gomega.Succeed())
+	g.Expect(payload.Reason).To(gomega.Equal(iter8v1alpha2.ReasonTargetsError))
+	g.Expect(nc.subscriptions).To(gomega.HaveLen(1))
+	g.Expect(nc.subscriptions).To(gomega.HaveKey("default/exp/team"))
+
+	// channels are removed with subscriptions
+	instance.Spec.Notifications = nil
+	nc.Notify(instance, iter8v1alpha2.ReasonTargetsFound, "")
+	g.Expect(nc.subscriptions).To(gomega.BeEmpty())
+
+	// channels of completed experiments are removed once the notification is delivered
+	instance.Spec.Notifications = []iter8v1alpha2.NotificationSubscription{{
+		Name:      "team",
+		Notifier:  NotifierNameWebhook,
+		SecretRef: corev1.LocalObjectReference{Name: "team-webhook"},
+	}}
+	instance.Status.MarkExperimentCompleted("Traffic To Winner")
+	nc.Notify(instance, iter8v1alpha2.ReasonExperimentCompleted, "Traffic To Winner")
+	g.Expect(nc.subscriptions).To(gomega.BeEmpty())
+	g.Expect(json.Unmarshal(<-received, &payload)).To(gomega.Succeed())
+	g.Expect(payload.Reason).To(gomega.Equal(iter8v1alpha2.ReasonExperimentCompleted))
+}
@@ -0,0 +1,115 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"text/template"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	NotifierNameTeams = "teams"
+
+	messageCardType    = "MessageCard"
+	messageCardContext = "http://schema.org/extensions"
+
+	colorGood    = "2EB886"
+	colorWarning = "DAA038"
+	colorDanger  = "A30200"
+)
+
+var _ Notifier = (*TeamsWebhook)(nil)
+
+// DefaultTeamsTemplate is the default template of text of Microsoft Teams notification
+const DefaultTeamsTemplate = `{{ with .Message }}_{{ . }}_{{ end }}
+{{- if .Experiment.Status.Assessment }}
+
+**Traffic:** {{ .Experiment.Status.TrafficToString }}
+{{- end }}
+{{- if .Experiment.Status.IsWinnerAssessmentAvailable }}
+
+**Assessment:** {{ .Experiment.Status.WinnerToString }}
+{{- end }}`
+
+var defaultTeamsTemplate = mustParseTemplate(DefaultTeamsTemplate)
+
+// TeamsWebhook is a notifier sending MessageCard to an incoming webhook of Microsoft Teams
+type TeamsWebhook struct {
+	template *template.Template
+}
+
+// NewTeamsWebhook returns a Microsoft Teams notifier rendering text with the template; default template is used if nil
+func NewTeamsWebhook(t *template.Template) *TeamsWebhook {
+	if t == nil {
+		t = defaultTeamsTemplate
+	}
+	return &TeamsWebhook{template: t}
+}
+
+type TeamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type TeamsSection struct {
+	ActivityTitle    string      `json:"activityTitle"`
+	ActivitySubtitle string      `json:"activitySubtitle,omitempty"`
+	Text             string      `json:"text,omitempty"`
+	Facts            []TeamsFact `json:"facts,omitempty"`
+	Markdown         bool        `json:"markdown"`
+}
+
+type TeamsRequest struct {
+	Type       string         `json:"@type"`
+	Context    string         `json:"@context"`
+	ThemeColor string         `json:"themeColor"`
+	Summary    string         `json:"summary"`
+	Sections   []TeamsSection `json:"sections"`
+}
+
+// MakeRequest implements Notifier MakeRequest function
+func (t *TeamsWebhook) MakeRequest(instance *iter8v1alpha2.Experiment, reason string, messageFormat string, messageA ...interface{}) interface{} {
+	splittedReason := splitString(reason)
+	expName := instance.GetName() + "." + instance.GetNamespace()
+
+	color := colorGood
+	switch reasonLevel(reason) {
+	case NotifierLevelError:
+		color = colorDanger
+	case NotifierLevelWarning:
+		color = colorWarning
+	}
+
+	facts := []TeamsFact{
+		{Name: "Phase", Value: string(instance.Status.Phase)},
+		{Name: "Progress", Value: progressToString(instance)},
+	}
+
+	return &TeamsRequest{
+		Type:       messageCardType,
+		Context:    messageCardContext,
+		ThemeColor: color,
+		Summary:    expName + ": " + splittedReason,
+		Sections: []TeamsSection{{
+			ActivityTitle:    "**" + splittedReason + "**",
+			ActivitySubtitle: expName,
+			Text:             renderTemplate(t.template, instance, reason, messageFormat, messageA...),
+			Facts:            facts,
+			Markdown:         true,
+		}},
+	}
+}
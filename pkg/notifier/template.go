@@ -0,0 +1,81 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+// TemplateData is the data available to message templates of notifier channels
+type TemplateData struct {
+	// Experiment is the experiment the notification is about
+	Experiment *iter8v1alpha2.Experiment
+	// Reason is the reason of the status change
+	Reason string
+	// Message is the detailed message of the status change
+	Message string
+	// Level is the notifier level of the reason
+	Level string
+}
+
+// templateFuncs are the functions available to message templates in addition to the builtin ones
+var templateFuncs = template.FuncMap{
+	"splitReason": splitString,
+	"progress":    progressToString,
+}
+
+// parseTemplate parses the message template of a channel
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("message").Funcs(templateFuncs).Parse(text)
+}
+
+// mustParseTemplate parses default templates
+func mustParseTemplate(text string) *template.Template {
+	return template.Must(parseTemplate(text))
+}
+
+// renderTemplate renders the message of a notification
+// Falls back to the plain message if the template fails to execute
+func renderTemplate(t *template.Template, instance *iter8v1alpha2.Experiment, reason string, messageFormat string, messageA ...interface{}) string {
+	data := &TemplateData{
+		Experiment: instance,
+		Reason:     reason,
+		Message:    fmt.Sprintf(messageFormat, messageA...),
+		Level:      reasonLevel(reason),
+	}
+
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return fmt.Sprintf("%s (template error: %v)", data.Message, err)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// progressToString outputs the progress of the experiment in human-readable format
+func progressToString(instance *iter8v1alpha2.Experiment) string {
+	if instance.Status.ExperimentCompleted() {
+		return "Experiment Completed"
+	}
+	if instance.Status.CurrentIteration == nil {
+		return "Not Started"
+	}
+	return fmt.Sprintf("Iteration %d/%d", *instance.Status.CurrentIteration, instance.Spec.GetMaxIterations())
+}
@@ -0,0 +1,84 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	iter8v1alpha2 "github.com/iter8-tools/iter8-istio/pkg/apis/iter8/v1alpha2"
+)
+
+const (
+	NotifierNameWebhook = "webhook"
+
+	// WebhookPayloadVersion is the version of payload sent by webhook notifier
+	// It is bumped whenever a field of the payload is removed or changes its meaning
+	WebhookPayloadVersion = "v1"
+)
+
+var _ Notifier = (*Webhook)(nil)
+
+// Webhook is a notifier posting experiment status in JSON to a generic endpoint
+type Webhook struct{}
+
+func NewWebhook() *Webhook {
+	return &Webhook{}
+}
+
+// WebhookExperiment identifies the experiment in webhook payload
+type WebhookExperiment struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	UID       string            `json:"uid"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// WebhookPayload is the body of the request sent by webhook notifier
+type WebhookPayload struct {
+	// Version is the version of this payload format
+	Version string `json:"version"`
+	// Reason is the reason of the status change that triggers the notification
+	Reason string `json:"reason"`
+	// Message is the detailed message of the status change
+	Message string `json:"message,omitempty"`
+	// Severity is the level of the reason; one of error, warning, normal and verbose
+	Severity string `json:"severity"`
+	// Timestamp is the time when the notification is generated, in RFC3339 format
+	Timestamp string `json:"timestamp"`
+	// Experiment identifies the experiment
+	Experiment WebhookExperiment `json:"experiment"`
+	// Status is the full status of the experiment
+	Status iter8v1alpha2.ExperimentStatus `json:"status"`
+}
+
+// MakeRequest implements Notifier MakeRequest function
+func (w *Webhook) MakeRequest(instance *iter8v1alpha2.Experiment, reason string, messageFormat string, messageA ...interface{}) interface{} {
+	return &WebhookPayload{
+		Version:   WebhookPayloadVersion,
+		Reason:    reason,
+		Message:   fmt.Sprintf(messageFormat, messageA...),
+		Severity:  reasonLevel(reason),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Experiment: WebhookExperiment{
+			Name:      instance.GetName(),
+			Namespace: instance.GetNamespace(),
+			UID:       string(instance.GetUID()),
+			Labels:    instance.GetLabels(),
+		},
+		Status: instance.Status,
+	}
+}
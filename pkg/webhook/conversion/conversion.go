@@ -0,0 +1,448 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion serves the webhook converting experiments between API versions
+// and provisions the certificate the API server trusts to call it
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	"github.com/iter8-tools/iter8-istio/pkg/config"
+)
+
+const (
+	// Path is the path of the conversion webhook, as referred in the CRD of experiments
+	Path = "/convert"
+
+	// CRDName is the name of the CRD of experiments
+	CRDName = "experiments.iter8.tools"
+
+	// caCertKey is the key of the CA bundle in the secret, trusted by the API server
+	caCertKey = "ca.crt"
+	// caPrivateKeyKey is the key of the private key of the current CA in the secret
+	caPrivateKeyKey = "ca.key"
+
+	// caValidity is how long the CA signing serving certificates is valid
+	caValidity = 10 * 365 * 24 * time.Hour
+	// caRenewBefore is how long before expiry the CA is replaced
+	caRenewBefore = 365 * 24 * time.Hour
+	// certValidity is how long a serving certificate is valid
+	certValidity = 365 * 24 * time.Hour
+	// renewBefore is how long before expiry the serving certificate is replaced
+	renewBefore = 30 * 24 * time.Hour
+
+	// rotateInterval is how often the certificate is checked for renewal while the controller is running
+	rotateInterval = 12 * time.Hour
+)
+
+var log = logf.Log.WithName("conversion-webhook")
+
+// Add provisions the serving certificate and registers the conversion webhook with the manager
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;update;patch
+func Add(mgr manager.Manager, cfg *config.Config) error {
+	// the cache of the manager isn't started yet
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	c, err := client.New(mgr.GetConfig(), client.Options{Scheme: scheme, Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		return err
+	}
+
+	p := &provisioner{
+		client:      c,
+		namespace:   cfg.Iter8Namespace,
+		serviceName: cfg.Webhook.ServiceName,
+		certDir:     cfg.Webhook.CertDir,
+		now:         time.Now,
+	}
+	if err := p.provision(context.Background()); err != nil {
+		return err
+	}
+
+	// only the secret of the certificate is watched, rather than caching all secrets
+	cs, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	watcher := cache.NewFilteredListWatchFromClient(cs.CoreV1().RESTClient(), "secrets", p.namespace,
+		func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", p.secretName()).String()
+		})
+	if err := mgr.Add(&rotator{provisioner: p, interval: rotateInterval, watcher: watcher}); err != nil {
+		return err
+	}
+
+	mgr.GetWebhookServer().Register(Path, &conversion.Webhook{})
+	return nil
+}
+
+var _ manager.LeaderElectionRunnable = (*rotator)(nil)
+
+// rotator renews the certificate periodically, so that it is renewed before expiry while the controller is running,
+// and writes the certificate as soon as the secret is changed, such as when another replica renews it.
+// The webhook server reloads the certificate once its files are rewritten.
+type rotator struct {
+	*provisioner
+	interval time.Duration
+	watcher  cache.ListerWatcher
+}
+
+// Start implements manager.Runnable; watches the secret and checks the certificate every interval until stop is closed
+func (r *rotator) Start(stop <-chan struct{}) error {
+	_, informer := cache.NewInformer(r.watcher, &corev1.Secret{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.reload,
+		UpdateFunc: func(_, obj interface{}) { r.reload(obj) },
+	})
+	go informer.Run(stop)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			if err := r.provision(context.Background()); err != nil {
+				log.Error(err, "Fail to rotate certificate")
+			}
+		}
+	}
+}
+
+// reload writes the certificate of the secret watched
+func (r *rotator) reload(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok || secret.Name != r.secretName() || len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return
+	}
+	if err := r.writeFiles(secret); err != nil {
+		log.Error(err, "Fail to reload certificate")
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable; every replica serves the webhook
+// with the certificate files of its own
+func (r *rotator) NeedLeaderElection() bool {
+	return false
+}
+
+// provisioner keeps a CA and the serving certificate it signs in a secret shared by replicas of the controller,
+// and sets the CA bundle of the conversion webhook in the CRD
+// The serving certificate is renewed without changing the CA bundle, so that replicas still serving the previous one
+// are trusted; a renewed CA is added to the bundle along with the previous one until the latter expires.
+type provisioner struct {
+	client      client.Client
+	namespace   string
+	serviceName string
+	certDir     string
+
+	// m serializes writing the certificate files
+	m sync.Mutex
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func (p *provisioner) provision(ctx context.Context) error {
+	secret, err := p.secret(ctx)
+	if err != nil {
+		return err
+	}
+	if err := p.writeFiles(secret); err != nil {
+		return err
+	}
+	return p.updateCABundle(ctx, secret.Data[caCertKey])
+}
+
+// writeFiles writes the serving certificate of the secret to the certificate directory
+func (p *provisioner) writeFiles(secret *corev1.Secret) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if err := os.MkdirAll(p.certDir, 0700); err != nil {
+		return err
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		path := filepath.Join(p.certDir, key)
+		if current, err := ioutil.ReadFile(path); err == nil && bytes.Equal(current, secret.Data[key]) {
+			// unchanged files are not rewritten, which would reload the certificate
+			continue
+		}
+		if err := ioutil.WriteFile(path, secret.Data[key], 0600); err != nil {
+			return fmt.Errorf("Fail to write certificate: %v", err)
+		}
+	}
+	return nil
+}
+
+// secretName is the name of the secret holding the certificate
+func (p *provisioner) secretName() string {
+	return p.serviceName + "-webhook-cert"
+}
+
+// secret returns the secret holding a valid certificate, which is renewed if missing or about to expire
+func (p *provisioner) secret(ctx context.Context) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: p.namespace, Name: p.secretName()}
+	err := p.client.Get(ctx, key, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	found := err == nil
+	if found && p.valid(secret) {
+		return secret, nil
+	}
+
+	data, err := p.renew(secret.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		secret.Data = data
+		err = p.client.Update(ctx, secret)
+	} else {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Type:       corev1.SecretTypeTLS,
+			Data:       data,
+		}
+		err = p.client.Create(ctx, secret)
+	}
+	if errors.IsAlreadyExists(err) || errors.IsConflict(err) {
+		// another replica has just written the certificate
+		log.Info("certificate written by another replica", "secret", key)
+		secret = &corev1.Secret{}
+		if err := p.client.Get(ctx, key, secret); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Info("certificate generated", "secret", key, "host", p.host())
+	return secret, nil
+}
+
+// host is the name of the service the API server calls the webhook at
+func (p *provisioner) host() string {
+	return fmt.Sprintf("%s.%s.svc", p.serviceName, p.namespace)
+}
+
+// renew returns the data of the secret with a serving certificate signed by the CA in the data,
+// which is replaced if missing or about to expire
+func (p *provisioner) renew(data map[string][]byte) (map[string][]byte, error) {
+	ca, caKey := p.ca(data)
+	bundle := p.unexpired(data[caCertKey])
+	if ca == nil {
+		var err error
+		var caPEM []byte
+		if ca, caKey, caPEM, err = p.generateCA(); err != nil {
+			return nil, fmt.Errorf("Fail to generate CA: %v", err)
+		}
+		// certificates trusted so far are kept in the bundle until they expire
+		previous := data[caCertKey]
+		if len(previous) == 0 {
+			previous = data[corev1.TLSCertKey]
+		}
+		bundle = append(caPEM, p.unexpired(previous)...)
+	}
+
+	certPEM, keyPEM, err := p.sign(ca, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("Fail to generate certificate: %v", err)
+	}
+	caKeyPEM, err := keyutil.MarshalPrivateKeyToPEM(caKey)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		caCertKey:               bundle,
+		caPrivateKeyKey:         caKeyPEM,
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}, nil
+}
+
+// ca returns the current CA of the data, which is the first one in the bundle; nil if missing or about to expire
+func (p *provisioner) ca(data map[string][]byte) (*x509.Certificate, crypto.Signer) {
+	certs, err := cert.ParseCertsPEM(data[caCertKey])
+	if err != nil || len(certs) == 0 || !p.now().Add(caRenewBefore).Before(certs[0].NotAfter) {
+		return nil, nil
+	}
+	key, err := keyutil.ParsePrivateKeyPEM(data[caPrivateKeyKey])
+	if err != nil {
+		return nil, nil
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil
+	}
+	return certs[0], signer
+}
+
+// unexpired returns the certificates of the bundle which are not expired yet
+func (p *provisioner) unexpired(bundle []byte) []byte {
+	certs, err := cert.ParseCertsPEM(bundle)
+	if err != nil {
+		return nil
+	}
+	out := []byte{}
+	for _, c := range certs {
+		if p.now().Before(c.NotAfter) {
+			out = append(out, pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: c.Raw})...)
+		}
+	}
+	return out
+}
+
+// generateCA returns a new self-signed CA
+func (p *provisioner) generateCA() (*x509.Certificate, crypto.Signer, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	now := p.now()
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("%s-ca@%d", p.host(), now.Unix())},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := p.create(template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return ca, key, pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: der}), nil
+}
+
+// sign returns a new serving certificate for the service of the webhook, signed by the CA
+func (p *provisioner) sign(ca *x509.Certificate, caKey crypto.Signer) ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := p.now()
+	host := p.host()
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: host},
+		DNSNames:    []string{host, host + ".cluster.local"},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(certValidity),
+		KeyUsage:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := p.create(template, ca, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: der}), keyPEM, nil
+}
+
+// create signs the template with a random serial number
+func (p *provisioner) create(template, parent *x509.Certificate, pub crypto.PublicKey, signer crypto.Signer) ([]byte, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serial
+	return x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+}
+
+// valid tells whether the secret holds a CA and a serving certificate signed by it, neither about to expire
+func (p *provisioner) valid(secret *corev1.Secret) bool {
+	ca, _ := p.ca(secret.Data)
+	if ca == nil || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return false
+	}
+	certs, err := cert.ParseCertsPEM(secret.Data[corev1.TLSCertKey])
+	if err != nil || len(certs) == 0 || certs[0].CheckSignatureFrom(ca) != nil {
+		return false
+	}
+	return p.now().Add(renewBefore).Before(certs[0].NotAfter)
+}
+
+// updateCABundle sets the CA bundle of the conversion webhook in the CRD of experiments
+func (p *provisioner) updateCABundle(ctx context.Context, caBundle []byte) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := p.client.Get(ctx, types.NamespacedName{Name: CRDName}, crd); err != nil {
+		return fmt.Errorf("Fail to read CRD %s: %v", CRDName, err)
+	}
+
+	conv := crd.Spec.Conversion
+	if conv == nil || conv.Strategy != apiextensionsv1.WebhookConverter ||
+		conv.Webhook == nil || conv.Webhook.ClientConfig == nil {
+		log.Info("CRD is not converted by webhook", "crd", CRDName)
+		return nil
+	}
+	if bytes.Equal(conv.Webhook.ClientConfig.CABundle, caBundle) {
+		return nil
+	}
+
+	patch := client.MergeFrom(crd.DeepCopy())
+	conv.Webhook.ClientConfig.CABundle = caBundle
+	if err := p.client.Patch(ctx, crd, patch); err != nil {
+		return fmt.Errorf("Fail to update CA bundle of CRD %s: %v", CRDName, err)
+	}
+	log.Info("CA bundle updated", "crd", CRDName)
+	return nil
+}
@@ -0,0 +1,224 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestProvisioner(t *testing.T, crd *apiextensionsv1.CustomResourceDefinition) *provisioner {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "iter8-webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return &provisioner{
+		client:      fake.NewFakeClientWithScheme(scheme, crd),
+		namespace:   "iter8",
+		serviceName: "iter8-controller",
+		certDir:     filepath.Join(dir, "certs"),
+		now:         time.Now,
+	}
+}
+
+func testCRD(conversion *apiextensionsv1.CustomResourceConversion) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: CRDName},
+		Spec:       apiextensionsv1.CustomResourceDefinitionSpec{Conversion: conversion},
+	}
+}
+
+// verify tells whether the serving certificate of the secret is trusted by the CA bundle
+func verify(bundle, certPEM []byte, now time.Time) error {
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(bundle)
+	certs, err := cert.ParseCertsPEM(certPEM)
+	if err != nil {
+		return err
+	}
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		DNSName:     "iter8-controller.iter8.svc",
+		Roots:       pool,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	return err
+}
+
+func TestProvision(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	p := newTestProvisioner(t, testCRD(&apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{Namespace: "iter8", Name: "iter8-controller"},
+			},
+		},
+	}))
+	key := types.NamespacedName{Namespace: "iter8", Name: "iter8-controller-webhook-cert"}
+
+	g.Expect(p.provision(ctx)).To(gomega.Succeed())
+	secret := &corev1.Secret{}
+	g.Expect(p.client.Get(ctx, key, secret)).To(gomega.Succeed())
+	g.Expect(p.valid(secret)).To(gomega.BeTrue())
+
+	certPEM, err := ioutil.ReadFile(filepath.Join(p.certDir, corev1.TLSCertKey))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(certPEM).To(gomega.Equal(secret.Data[corev1.TLSCertKey]))
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	g.Expect(p.client.Get(ctx, types.NamespacedName{Name: CRDName}, crd)).To(gomega.Succeed())
+	bundle := crd.Spec.Conversion.Webhook.ClientConfig.CABundle
+	g.Expect(bundle).To(gomega.Equal(secret.Data[caCertKey]))
+	g.Expect(verify(bundle, certPEM, time.Now())).To(gomega.Succeed())
+
+	// the certificate is reused by other replicas
+	g.Expect(p.provision(ctx)).To(gomega.Succeed())
+	reused := &corev1.Secret{}
+	g.Expect(p.client.Get(ctx, key, reused)).To(gomega.Succeed())
+	g.Expect(reused.Data).To(gomega.Equal(secret.Data))
+
+	// and replaced before expiry by one signed by the same CA, so that the previous one is still trusted
+	now := time.Now().Add(340 * 24 * time.Hour)
+	p.now = func() time.Time { return now }
+	g.Expect(p.valid(reused)).To(gomega.BeFalse())
+	g.Expect(p.provision(ctx)).To(gomega.Succeed())
+	renewed := &corev1.Secret{}
+	g.Expect(p.client.Get(ctx, key, renewed)).To(gomega.Succeed())
+	g.Expect(renewed.Data[corev1.TLSCertKey]).NotTo(gomega.Equal(certPEM))
+	g.Expect(p.client.Get(ctx, types.NamespacedName{Name: CRDName}, crd)).To(gomega.Succeed())
+	g.Expect(crd.Spec.Conversion.Webhook.ClientConfig.CABundle).To(gomega.Equal(bundle))
+	g.Expect(verify(bundle, certPEM, now.Add(-time.Hour))).To(gomega.Succeed())
+	g.Expect(verify(bundle, renewed.Data[corev1.TLSCertKey], now)).To(gomega.Succeed())
+
+	// a renewed CA is trusted along with the previous one
+	now = time.Now().Add(caValidity - caRenewBefore/2)
+	g.Expect(p.provision(ctx)).To(gomega.Succeed())
+	g.Expect(p.client.Get(ctx, types.NamespacedName{Name: CRDName}, crd)).To(gomega.Succeed())
+	cas, err := cert.ParseCertsPEM(crd.Spec.Conversion.Webhook.ClientConfig.CABundle)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cas).To(gomega.HaveLen(2))
+	previous, err := cert.ParseCertsPEM(bundle)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cas[1].Equal(previous[0])).To(gomega.BeTrue())
+}
+
+func TestProvisionSelfSigned(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	p := newTestProvisioner(t, testCRD(nil))
+
+	// a self-signed certificate written by a previous version is trusted until it expires
+	certPEM, keyPEM, err := cert.GenerateSelfSignedCertKey("iter8-controller.iter8.svc", nil, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(p.client.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "iter8", Name: "iter8-controller-webhook-cert"},
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	})).To(gomega.Succeed())
+
+	g.Expect(p.provision(ctx)).To(gomega.Succeed())
+	secret := &corev1.Secret{}
+	g.Expect(p.client.Get(ctx, types.NamespacedName{Namespace: "iter8", Name: "iter8-controller-webhook-cert"}, secret)).To(gomega.Succeed())
+	g.Expect(p.valid(secret)).To(gomega.BeTrue())
+	g.Expect(string(secret.Data[caCertKey])).To(gomega.HaveSuffix(string(certPEM)))
+}
+
+// newTestWatcher returns a watcher of secrets whose events are sent by w
+func newTestWatcher(w *watch.FakeWatcher) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc:  func(metav1.ListOptions) (runtime.Object, error) { return &corev1.SecretList{}, nil },
+		WatchFunc: func(metav1.ListOptions) (watch.Interface, error) { return w, nil },
+	}
+}
+
+func TestRotator(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	p := newTestProvisioner(t, testCRD(nil))
+	g.Expect(p.provision(context.Background())).To(gomega.Succeed())
+	certPath := filepath.Join(p.certDir, corev1.TLSCertKey)
+	certPEM, err := ioutil.ReadFile(certPath)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	w := watch.NewFake()
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- (&rotator{provisioner: p, interval: time.Hour, watcher: newTestWatcher(w)}).Start(stop)
+	}()
+
+	// the certificate is written as soon as another replica renews it
+	w.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "iter8", Name: "iter8-controller-webhook-cert"},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("renewed"), corev1.TLSPrivateKeyKey: []byte("key")},
+	})
+	g.Eventually(func() string {
+		renewed, _ := ioutil.ReadFile(certPath)
+		return string(renewed)
+	}, 10*time.Second).Should(gomega.Equal("renewed"))
+	close(stop)
+	var stopErr error
+	g.Eventually(done, 10*time.Second).Should(gomega.Receive(&stopErr))
+	g.Expect(stopErr).NotTo(gomega.HaveOccurred())
+
+	// the certificate is renewed while running
+	p.now = func() time.Time { return time.Now().Add(340 * 24 * time.Hour) }
+	stop = make(chan struct{})
+	go func() {
+		done <- (&rotator{provisioner: p, interval: 10 * time.Millisecond, watcher: newTestWatcher(watch.NewFake())}).Start(stop)
+	}()
+	g.Eventually(func() []byte {
+		renewed, _ := ioutil.ReadFile(certPath)
+		return renewed
+	}, 10*time.Second).ShouldNot(gomega.Or(gomega.Equal(certPEM), gomega.Equal([]byte("renewed"))))
+	close(stop)
+	g.Eventually(done, 10*time.Second).Should(gomega.Receive(&stopErr))
+	g.Expect(stopErr).NotTo(gomega.HaveOccurred())
+}
+
+func TestProvisionWithoutWebhookConversion(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	p := newTestProvisioner(t, testCRD(nil))
+
+	g.Expect(p.provision(context.Background())).To(gomega.Succeed())
+	_, err := os.Stat(filepath.Join(p.certDir, corev1.TLSPrivateKeyKey))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+}